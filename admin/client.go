@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/event"
+)
+
+var (
+	_ approval.Manager        = &Client{}
+	_ event.DeadLetterManager = &Client{}
+)
+
+// Client manages the running oracle via its admin socket.
+type Client struct {
+	path   string
+	client *http.Client
+}
+
+func NewClient(path string) *Client {
+	return &Client{
+		path: path,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", path)
+				},
+			},
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Dial returns the client if the oracle is running and listening on the admin socket.
+func Dial(path string) (*Client, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the admin socket: %w", err)
+	}
+	_ = conn.Close()
+	return NewClient(path), nil
+}
+
+func (c *Client) ListRequests() ([]approval.Request, error) {
+	var requests []approval.Request
+	if err := c.do(http.MethodGet, "/approvals", nil, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (c *Client) Decide(id, status string) (*approval.Request, error) {
+	var request approval.Request
+	if err := c.do(http.MethodPost, "/approvals/decide", decideRequest{ID: id, Status: status}, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (c *Client) ListFailedEvents() ([]event.FailedEvent, error) {
+	var events []event.FailedEvent
+	if err := c.do(http.MethodGet, "/events", nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) RetryFailedEvents(ids []string, all bool) ([]event.FailedEvent, error) {
+	var events []event.FailedEvent
+	if err := c.do(http.MethodPost, "/events/retry", failedEventsRequest{IDs: ids, All: all}, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) DropFailedEvents(ids []string, all bool) ([]event.FailedEvent, error) {
+	var events []event.FailedEvent
+	if err := c.do(http.MethodPost, "/events/drop", failedEventsRequest{IDs: ids, All: all}, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) do(method, path string, reqBody, v interface{}) error {
+	var body []byte
+	if reqBody != nil {
+		var err error
+		body, err = json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the host is ignored, since the client always dials the socket.
+	req, err := http.NewRequest(method, "http://admin"+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request the oracle: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return errors.New(strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the response of the oracle: %w", err)
+	}
+	return nil
+}
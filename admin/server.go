@@ -0,0 +1,170 @@
+// Package admin serves the management of the running oracle on a UNIX socket in the data directory,
+// so that the approval requests and the failed events can be managed without stopping the oracle.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/event"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SocketDir is the directory of the UNIX socket in the data directory, which only the owner can access.
+	SocketDir = "admin"
+	// SocketName is the name of the UNIX socket in SocketDir.
+	SocketName = "admin.sock"
+)
+
+// SocketPath returns the path of the UNIX socket in the data directory.
+func SocketPath(dataDir string) string {
+	return filepath.Join(dataDir, SocketDir, SocketName)
+}
+
+type decideRequest struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type failedEventsRequest struct {
+	IDs []string `json:"ids"`
+	All bool     `json:"all"`
+}
+
+// Server serves the approval requests and the failed events on a UNIX socket, which only the owner can access.
+type Server struct {
+	path   string
+	server *http.Server
+}
+
+func NewServer(path string, approvals approval.Manager, deadLetters event.DeadLetterManager) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/approvals", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		requests, err := approvals.ListRequests()
+		writeResponse(w, requests, err)
+	})
+	mux.HandleFunc("/approvals/decide", func(w http.ResponseWriter, r *http.Request) {
+		var req decideRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		request, err := approvals.Decide(req.ID, req.Status)
+		if err == nil {
+			log.Infof("approval request %s is %s", req.ID, req.Status)
+		}
+		writeResponse(w, request, err)
+	})
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		events, err := deadLetters.ListFailedEvents()
+		writeResponse(w, events, err)
+	})
+	mux.HandleFunc("/events/retry", func(w http.ResponseWriter, r *http.Request) {
+		var req failedEventsRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		events, err := deadLetters.RetryFailedEvents(req.IDs, req.All)
+		for _, failed := range events {
+			log.Infof("event %s will be retried", failed.ID)
+		}
+		writeResponse(w, events, err)
+	})
+	mux.HandleFunc("/events/drop", func(w http.ResponseWriter, r *http.Request) {
+		var req failedEventsRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		events, err := deadLetters.DropFailedEvents(req.IDs, req.All)
+		for _, failed := range events {
+			log.Infof("event %s is dropped", failed.ID)
+		}
+		writeResponse(w, events, err)
+	})
+
+	return &Server{
+		path: path,
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Run listens on the UNIX socket, removing the socket left by the previous run.
+// The socket is created in a directory which only the owner can access,
+// so that it is never accessible by others before its permission is set.
+func (s *Server) Run() error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the admin socket directory: %w", err)
+	}
+	// the directory may have been created with a looser permission
+	if err := os.Chmod(dir, 0700); err != nil {
+		return fmt.Errorf("failed to set permission of the admin socket directory: %w", err)
+	}
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove the old admin socket: %w", err)
+	}
+
+	lis, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on the admin socket: %w", err)
+	}
+	if err := os.Chmod(s.path, 0600); err != nil {
+		_ = lis.Close()
+		return fmt.Errorf("failed to set permission of the admin socket: %w", err)
+	}
+
+	log.Infof("admin server is started: %s", s.path)
+	if err := s.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve admin: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Close() error {
+	log.Info("Close admin server")
+	return s.server.Shutdown(context.Background())
+}
+
+// readRequest decodes the body of a POST request. It writes the error response and returns false if it fails.
+func readRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warnf("failed to write admin response: %v", err)
+	}
+}
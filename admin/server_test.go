@@ -0,0 +1,99 @@
+package admin_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/admin"
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	testUniqueID = "uniqueID"
+	testAddress  = "panacea1ewugvs354xput6xydl5cd5tvkzcuymkejekwk3"
+)
+
+func TestAdminServer(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.Mode = "manual"
+	approvalStore := approval.NewDBStore(dbm.NewMemDB())
+	policy, err := approval.NewPolicy(conf, &mocks.MockQueryClient{}, approvalStore)
+	require.NoError(t, err)
+
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+	request.Status = approval.StatusPending
+	request.RequestedAt = time.Now()
+	require.NoError(t, approvalStore.SetRequest(request))
+
+	deadLetters := event.NewDeadLetterQueue(event.NewDBDeadLetterStore(dbm.NewMemDB()), 0, time.Minute)
+	failed, err := deadLetters.Add("test", ctypes.ResultEvent{}, errors.New("failed"))
+	require.NoError(t, err)
+
+	path := admin.SocketPath(t.TempDir())
+	server := admin.NewServer(path, policy, deadLetters)
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Run() }()
+	defer func() {
+		require.NoError(t, server.Close())
+		require.NoError(t, <-errCh)
+	}()
+
+	var client *admin.Client
+	require.Eventually(t, func() bool {
+		client, err = admin.Dial(path)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// only the owner can access the socket
+	info, err := os.Stat(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// approvals
+	requests, err := client.ListRequests()
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, request.ID, requests[0].ID)
+
+	decided, err := client.Decide(request.ID, approval.StatusApproved)
+	require.NoError(t, err)
+	require.Equal(t, approval.StatusApproved, decided.Status)
+
+	stored, err := approvalStore.GetRequest(request.ID)
+	require.NoError(t, err)
+	require.Equal(t, approval.StatusApproved, stored.Status)
+
+	_, err = client.Decide(request.ID, "unknown")
+	require.ErrorContains(t, err, "invalid status")
+
+	// failed events
+	events, err := client.ListFailedEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, failed.ID, events[0].ID)
+
+	retried, err := client.RetryFailedEvents([]string{failed.ID}, false)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	require.False(t, retried[0].NextRetryAt.IsZero())
+
+	_, err = client.DropFailedEvents([]string{"unknown"}, false)
+	require.ErrorContains(t, err, "no failed event")
+
+	dropped, err := client.DropFailedEvents(nil, true)
+	require.NoError(t, err)
+	require.Len(t, dropped, 1)
+
+	events, err = deadLetters.ListFailedEvents()
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -0,0 +1,142 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/panacea"
+	log "github.com/sirupsen/logrus"
+)
+
+// CoordinatorStats counts how the requests are coordinated among the oracles.
+type CoordinatorStats struct {
+	// Led is the number of requests for which this oracle is the leader.
+	Led uint64
+	// Followed is the number of requests which this oracle approves as a follower, since they are not approved during the delay.
+	Followed uint64
+	// Skipped is the number of requests which are approved by another oracle during the delay.
+	Skipped uint64
+}
+
+// Coordinator avoids duplicate approvals when all oracles with the same unique ID receive the same request.
+// The oracles are ranked by the hash of the request ID and their addresses, so that every oracle gets the same ranking
+// without communication. The leader approves the request right away, and each of the others waits for its turn.
+// The wait is capped at maxWait, so that a follower ranked behind many offline oracles still approves the request in time.
+type Coordinator struct {
+	grpcClient panacea.GRPCClient
+	uniqueID   string
+	address    string
+	delay      time.Duration
+	maxWait    time.Duration
+
+	mtx   sync.Mutex
+	stats CoordinatorStats
+}
+
+// NewCoordinator returns a coordinator of this oracle. If delay is 0, the requests are not coordinated.
+// maxWait should be less than the timeout of the event handler, leaving time to approve the request.
+func NewCoordinator(grpcClient panacea.GRPCClient, uniqueID, address string, delay, maxWait time.Duration) *Coordinator {
+	return &Coordinator{
+		grpcClient: grpcClient,
+		uniqueID:   uniqueID,
+		address:    address,
+		delay:      delay,
+		maxWait:    maxWait,
+	}
+}
+
+// Wait waits for the turn of this oracle to approve the request.
+// It returns false if the request has been approved by another oracle while waiting.
+func (c *Coordinator) Wait(ctx context.Context, request Request, approved func(context.Context) (bool, error)) (bool, error) {
+	if c.delay == 0 {
+		return true, nil
+	}
+
+	rank, total := c.rank(request)
+	if rank == 0 {
+		log.Infof("this oracle is the leader to approve %s among %d oracles", request.ID, total)
+		c.record(func(stats *CoordinatorStats) { stats.Led++ })
+		return true, nil
+	}
+
+	wait := c.delay * time.Duration(rank)
+	if wait > c.maxWait {
+		wait = c.maxWait
+	}
+	log.Infof("this oracle is the follower(%d/%d) to approve %s. waiting for %s", rank, total, request.ID, wait)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return false, fmt.Errorf("failed to wait for the turn to approve %s: %w", request.ID, ctx.Err())
+	}
+
+	isApproved, err := approved(ctx)
+	if err != nil {
+		return false, err
+	}
+	if isApproved {
+		log.Infof("%s has been approved by another oracle", request.ID)
+		c.record(func(stats *CoordinatorStats) { stats.Skipped++ })
+		return false, nil
+	}
+
+	log.Infof("%s is still not approved. approving it as the follower(%d/%d)", request.ID, rank, total)
+	c.record(func(stats *CoordinatorStats) { stats.Followed++ })
+	return true, nil
+}
+
+// rank returns the rank of this oracle for the request, and the number of oracles ranked.
+// If the oracles cannot be listed, this oracle regards itself as the leader.
+func (c *Coordinator) rank(request Request) (int, int) {
+	oracles, err := c.grpcClient.GetOracles()
+	if err != nil {
+		log.Warnf("failed to list oracles. approving %s without coordination: %v", request.ID, err)
+		return 0, 1
+	}
+
+	addresses := []string{c.address}
+	for _, oracle := range oracles {
+		if oracle.UniqueId != c.uniqueID || oracle.OracleAddress == c.address || oracle.OracleAddress == request.OracleAddress {
+			continue
+		}
+		addresses = append(addresses, oracle.OracleAddress)
+	}
+
+	hashes := make(map[string][]byte, len(addresses))
+	for _, address := range addresses {
+		hash := sha256.Sum256([]byte(request.ID + "/" + address))
+		hashes[address] = hash[:]
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(hashes[addresses[i]], hashes[addresses[j]]) < 0
+	})
+
+	for i, address := range addresses {
+		if address == c.address {
+			return i, len(addresses)
+		}
+	}
+	return 0, len(addresses)
+}
+
+func (c *Coordinator) record(fn func(*CoordinatorStats)) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	fn(&c.stats)
+}
+
+// Stats returns the number of requests by how they are coordinated.
+func (c *Coordinator) Stats() CoordinatorStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.stats
+}
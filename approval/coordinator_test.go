@@ -0,0 +1,140 @@
+package approval_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var testOracleAddresses = []string{"panacea1oracle1", "panacea1oracle2", "panacea1oracle3"}
+
+func newTestCoordinators(delay, maxWait time.Duration) []*approval.Coordinator {
+	grpcClient := &mocks.MockGrpcClient{
+		Oracles: []*oracletypes.Oracle{
+			// the target oracle and the oracles of another unique ID are not ranked
+			{OracleAddress: testAddress, UniqueId: testUniqueID},
+			{OracleAddress: "panacea1other", UniqueId: "otherUniqueID"},
+		},
+	}
+	for _, address := range testOracleAddresses {
+		grpcClient.Oracles = append(grpcClient.Oracles, &oracletypes.Oracle{OracleAddress: address, UniqueId: testUniqueID})
+	}
+
+	coordinators := make([]*approval.Coordinator, len(testOracleAddresses))
+	for i, address := range testOracleAddresses {
+		coordinators[i] = approval.NewCoordinator(grpcClient, testUniqueID, address, delay, maxWait)
+	}
+	return coordinators
+}
+
+func sumStats(coordinators []*approval.Coordinator) approval.CoordinatorStats {
+	var sum approval.CoordinatorStats
+	for _, coordinator := range coordinators {
+		stats := coordinator.Stats()
+		sum.Led += stats.Led
+		sum.Followed += stats.Followed
+		sum.Skipped += stats.Skipped
+	}
+	return sum
+}
+
+func TestCoordinatorOneLeader(t *testing.T) {
+	coordinators := newTestCoordinators(50*time.Millisecond, time.Hour)
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	// the leader approves the request right away, and the followers skip it after it is approved
+	var approved atomic.Bool
+	var wg sync.WaitGroup
+	for _, coordinator := range coordinators {
+		wg.Add(1)
+		go func(coordinator *approval.Coordinator) {
+			defer wg.Done()
+			ok, err := coordinator.Wait(context.Background(), request, func(context.Context) (bool, error) {
+				return approved.Load(), nil
+			})
+			require.NoError(t, err)
+			if ok {
+				approved.Store(true)
+			}
+		}(coordinator)
+	}
+	wg.Wait()
+
+	require.Equal(t, approval.CoordinatorStats{Led: 1, Skipped: 2}, sumStats(coordinators))
+}
+
+func TestCoordinatorFollowers(t *testing.T) {
+	coordinators := newTestCoordinators(10*time.Millisecond, time.Hour)
+	request := approval.NewRequest(approval.KindUpgrade, testUniqueID, testAddress)
+
+	// all oracles approve the request if it is never approved
+	for _, coordinator := range coordinators {
+		ok, err := coordinator.Wait(context.Background(), request, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	require.Equal(t, approval.CoordinatorStats{Led: 1, Followed: 2}, sumStats(coordinators))
+}
+
+func TestCoordinatorMaxWait(t *testing.T) {
+	coordinators := newTestCoordinators(time.Hour, 10*time.Millisecond)
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	// the followers approve the request after max-wait, regardless of their ranks
+	for _, coordinator := range coordinators {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		ok, err := coordinator.Wait(ctx, request, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		cancel()
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	require.Equal(t, approval.CoordinatorStats{Led: 1, Followed: 2}, sumStats(coordinators))
+}
+
+func TestCoordinatorCancelled(t *testing.T) {
+	coordinators := newTestCoordinators(time.Hour, time.Hour)
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var errs int
+	for _, coordinator := range coordinators {
+		ok, err := coordinator.Wait(ctx, request, func(context.Context) (bool, error) {
+			return false, nil
+		})
+		if err != nil {
+			require.ErrorIs(t, err, context.Canceled)
+			require.False(t, ok)
+			errs++
+		}
+	}
+	require.Equal(t, 2, errs)
+}
+
+func TestCoordinatorDisabled(t *testing.T) {
+	coordinators := newTestCoordinators(0, time.Hour)
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	for _, coordinator := range coordinators {
+		ok, err := coordinator.Wait(context.Background(), request, func(context.Context) (bool, error) {
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.Equal(t, approval.CoordinatorStats{}, sumStats(coordinators))
+}
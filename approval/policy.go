@@ -0,0 +1,238 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/panacea"
+)
+
+var (
+	// ErrDenied is returned if the request doesn't satisfy the policy, or if it is rejected manually.
+	ErrDenied = errors.New("denied by the approval policy")
+	// ErrDeferred is returned if the request is waiting for a manual approval, or if the approvals are over the limit.
+	ErrDeferred = errors.New("deferred by the approval policy")
+)
+
+// Manager lists and decides the approval requests.
+// It is the policy of the running oracle, or an admin client connected to it.
+type Manager interface {
+	ListRequests() ([]Request, error)
+	Decide(id, status string) (*Request, error)
+}
+
+var _ Manager = &Policy{}
+
+// Policy decides whether an oracle registration or upgrade can be approved by this oracle.
+// The remote report of the request should be verified before the policy is evaluated.
+type Policy struct {
+	conf        config.ApprovalConfig
+	minStake    *sdk.Coin
+	queryClient panacea.QueryClient
+	store       Store
+
+	mtx      sync.Mutex
+	inFlight map[string]struct{}
+}
+
+func NewPolicy(conf config.ApprovalConfig, queryClient panacea.QueryClient, store Store) (*Policy, error) {
+	p := &Policy{
+		conf:        conf,
+		queryClient: queryClient,
+		store:       store,
+		inFlight:    make(map[string]struct{}),
+	}
+
+	if conf.MinStake != "" {
+		minStake, err := sdk.ParseCoinNormalized(conf.MinStake)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min-stake: %w", err)
+		}
+		p.minStake = &minStake
+	}
+
+	return p, nil
+}
+
+// Evaluate returns nil if the request can be approved now. Otherwise, it returns ErrDenied or ErrDeferred with the reason,
+// or another error if the policy cannot be evaluated now, for example because a query has failed. Then, it can be evaluated again later.
+// In the manual mode, a new request is queued as pending.
+// If nil is returned, Done should be called with the result of the approval.
+func (p *Policy) Evaluate(ctx context.Context, request Request) error {
+	if err := p.checkAddress(request.OracleAddress); err != nil {
+		return err
+	}
+	if err := p.checkStake(ctx, request.OracleAddress); err != nil {
+		return err
+	}
+	if err := p.checkAccountAge(ctx, request.OracleAddress); err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.inFlight[request.ID]; ok {
+		return fmt.Errorf("%w: the request is being approved", ErrDeferred)
+	}
+
+	if p.conf.Mode == "manual" {
+		if err := p.checkManual(request); err != nil {
+			return err
+		}
+	}
+	// the requests approved manually are also deferred until the period has room for them.
+	if err := p.checkLimit(); err != nil {
+		return err
+	}
+
+	p.inFlight[request.ID] = struct{}{}
+	return nil
+}
+
+// Done records the approval if approved, so that it is counted in the limit of approvals.
+func (p *Policy) Done(request Request, approved bool) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.inFlight, request.ID)
+
+	if !approved {
+		return nil
+	}
+	if err := p.store.DeleteRequest(request.ID); err != nil {
+		return err
+	}
+	return p.store.AddApproval(time.Now())
+}
+
+// ListRequests returns the requests in the order of the request time.
+func (p *Policy) ListRequests() ([]Request, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.store.ListRequests()
+}
+
+// Decide marks the request as approved or rejected manually.
+func (p *Policy) Decide(id, status string) (*Request, error) {
+	if status != StatusApproved && status != StatusRejected {
+		return nil, fmt.Errorf("invalid status of approval request: %s", status)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	request, err := p.store.GetRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, fmt.Errorf("no approval request: %s", id)
+	}
+
+	request.Status = status
+	request.DecidedAt = time.Now()
+	if err := p.store.SetRequest(*request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (p *Policy) checkAddress(address string) error {
+	for _, denied := range p.conf.Denylist {
+		if address == denied {
+			return fmt.Errorf("%w: %s is in the denylist", ErrDenied, address)
+		}
+	}
+
+	if len(p.conf.Allowlist) == 0 {
+		return nil
+	}
+	for _, allowed := range p.conf.Allowlist {
+		if address == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not in the allowlist", ErrDenied, address)
+}
+
+func (p *Policy) checkStake(ctx context.Context, address string) error {
+	if p.minStake == nil {
+		return nil
+	}
+
+	stake, err := p.queryClient.GetBondedStake(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get stake of %s: %w", address, err)
+	}
+	if stake.Denom != p.minStake.Denom {
+		return fmt.Errorf("min-stake should be in the bond denom %s, not %s", stake.Denom, p.minStake.Denom)
+	}
+	if stake.IsLT(*p.minStake) {
+		return fmt.Errorf("%w: stake of %s is %s, less than %s", ErrDenied, address, stake, p.minStake)
+	}
+	return nil
+}
+
+func (p *Policy) checkAccountAge(ctx context.Context, address string) error {
+	if p.conf.MinAccountAge == 0 {
+		return nil
+	}
+
+	createdTime, err := p.queryClient.GetAccountCreatedTime(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get created time of %s: %w", address, err)
+	}
+	if age := time.Since(createdTime); age < p.conf.MinAccountAge {
+		return fmt.Errorf("%w: account %s is created %s ago, less than %s", ErrDenied, address, age.Truncate(time.Second), p.conf.MinAccountAge)
+	}
+	return nil
+}
+
+// checkManual returns nil only if the request has been approved manually. The lock must be held.
+func (p *Policy) checkManual(request Request) error {
+	stored, err := p.store.GetRequest(request.ID)
+	if err != nil {
+		return err
+	}
+
+	if stored == nil {
+		request.Status = StatusPending
+		request.RequestedAt = time.Now()
+		if err := p.store.SetRequest(request); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: waiting for a manual approval", ErrDeferred)
+	}
+
+	switch stored.Status {
+	case StatusApproved:
+		return nil
+	case StatusRejected:
+		return fmt.Errorf("%w: rejected manually", ErrDenied)
+	default:
+		return fmt.Errorf("%w: waiting for a manual approval", ErrDeferred)
+	}
+}
+
+// checkLimit returns an error if the approvals in the current period have reached the limit. The lock must be held.
+func (p *Policy) checkLimit() error {
+	if p.conf.MaxApprovals == 0 {
+		return nil
+	}
+
+	count, err := p.store.CountApprovalsSince(time.Now().Add(-p.conf.Period))
+	if err != nil {
+		return err
+	}
+	if count+len(p.inFlight) >= p.conf.MaxApprovals {
+		return fmt.Errorf("%w: %d approvals are already made in %s", ErrDeferred, p.conf.MaxApprovals, p.conf.Period)
+	}
+	return nil
+}
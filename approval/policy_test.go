@@ -0,0 +1,185 @@
+package approval_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	testUniqueID = "uniqueID"
+	testAddress  = "panacea1ewugvs354xput6xydl5cd5tvkzcuymkejekwk3"
+)
+
+func newTestPolicy(t *testing.T, conf config.ApprovalConfig, queryClient *mocks.MockQueryClient) (*approval.Policy, approval.Store) {
+	store := approval.NewDBStore(dbm.NewMemDB())
+	policy, err := approval.NewPolicy(conf, queryClient, store)
+	require.NoError(t, err)
+	return policy, store
+}
+
+func TestPolicyAutoMode(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	policy, store := newTestPolicy(t, conf, &mocks.MockQueryClient{})
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	require.NoError(t, policy.Evaluate(context.Background(), request))
+
+	// the request being approved is not approved twice
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDeferred)
+
+	require.NoError(t, policy.Done(request, true))
+	count, err := store.CountApprovalsSince(time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestPolicyAddressLists(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.Denylist = []string{testAddress}
+	policy, _ := newTestPolicy(t, conf, &mocks.MockQueryClient{})
+
+	err := policy.Evaluate(context.Background(), approval.NewRequest(approval.KindUpgrade, testUniqueID, testAddress))
+	require.ErrorIs(t, err, approval.ErrDenied)
+	require.ErrorContains(t, err, "denylist")
+
+	conf = config.DefaultConfig().Approval
+	conf.Allowlist = []string{"panacea1allowed"}
+	policy, _ = newTestPolicy(t, conf, &mocks.MockQueryClient{})
+
+	err = policy.Evaluate(context.Background(), approval.NewRequest(approval.KindUpgrade, testUniqueID, testAddress))
+	require.ErrorIs(t, err, approval.ErrDenied)
+	require.ErrorContains(t, err, "allowlist")
+
+	require.NoError(t, policy.Evaluate(context.Background(), approval.NewRequest(approval.KindUpgrade, testUniqueID, "panacea1allowed")))
+}
+
+func TestPolicyStakeAndAccountAge(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.MinStake = "1000umed"
+	conf.MinAccountAge = time.Hour
+
+	stake := sdk.NewInt64Coin("umed", 999)
+	queryClient := &mocks.MockQueryClient{
+		BondedStake:        &stake,
+		AccountCreatedTime: time.Now().Add(-2 * time.Hour),
+	}
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	policy, _ := newTestPolicy(t, conf, queryClient)
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDenied)
+
+	stake = sdk.NewInt64Coin("umed", 1000)
+	queryClient.AccountCreatedTime = time.Now().Add(-time.Minute)
+	policy, _ = newTestPolicy(t, conf, queryClient)
+	err := policy.Evaluate(context.Background(), request)
+	require.ErrorIs(t, err, approval.ErrDenied)
+	require.ErrorContains(t, err, "less than 1h0m0s")
+
+	queryClient.AccountCreatedTime = time.Now().Add(-2 * time.Hour)
+	policy, _ = newTestPolicy(t, conf, queryClient)
+	require.NoError(t, policy.Evaluate(context.Background(), request))
+	require.NoError(t, policy.Done(request, false))
+
+	// a failed query doesn't deny the request, so that it can be evaluated again
+	queryClient.BondedStakeError = errors.New("connection refused")
+	err = policy.Evaluate(context.Background(), request)
+	require.ErrorContains(t, err, "connection refused")
+	require.NotErrorIs(t, err, approval.ErrDenied)
+	require.NotErrorIs(t, err, approval.ErrDeferred)
+}
+
+func TestPolicyMaxApprovals(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.MaxApprovals = 2
+	conf.Period = time.Hour
+	policy, store := newTestPolicy(t, conf, &mocks.MockQueryClient{})
+
+	// an approval in the previous period is not counted
+	require.NoError(t, store.AddApproval(time.Now().Add(-2*time.Hour)))
+	require.NoError(t, store.AddApproval(time.Now().Add(-time.Minute)))
+
+	request1 := approval.NewRequest(approval.KindRegistration, testUniqueID, "panacea1first")
+	request2 := approval.NewRequest(approval.KindRegistration, testUniqueID, "panacea1second")
+
+	require.NoError(t, policy.Evaluate(context.Background(), request1))
+	// the approval in flight is counted
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request2), approval.ErrDeferred)
+
+	// the failed approval is not counted
+	require.NoError(t, policy.Done(request1, false))
+	require.NoError(t, policy.Evaluate(context.Background(), request2))
+	require.NoError(t, policy.Done(request2, true))
+
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request1), approval.ErrDeferred)
+
+	count, err := store.CountApprovalsSince(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestPolicyManualMode(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.Mode = "manual"
+	policy, store := newTestPolicy(t, conf, &mocks.MockQueryClient{})
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+
+	// a new request is queued
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDeferred)
+	requests, err := store.ListRequests()
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, request.ID, requests[0].ID)
+	require.Equal(t, approval.StatusPending, requests[0].Status)
+
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDeferred)
+
+	_, err = policy.Decide(request.ID, approval.StatusRejected)
+	require.NoError(t, err)
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDenied)
+
+	decided, err := policy.Decide(request.ID, approval.StatusApproved)
+	require.NoError(t, err)
+	require.Equal(t, approval.StatusApproved, decided.Status)
+	require.NoError(t, policy.Evaluate(context.Background(), request))
+
+	_, err = policy.Decide("registration/unknown", approval.StatusApproved)
+	require.ErrorContains(t, err, "no approval request")
+
+	// the request is removed from the queue once it is approved on chain
+	require.NoError(t, policy.Done(request, true))
+	stored, err := store.GetRequest(request.ID)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func TestPolicyManualModeMaxApprovals(t *testing.T) {
+	conf := config.DefaultConfig().Approval
+	conf.Mode = "manual"
+	conf.MaxApprovals = 1
+	conf.Period = time.Hour
+	policy, store := newTestPolicy(t, conf, &mocks.MockQueryClient{})
+	require.NoError(t, store.AddApproval(time.Now().Add(-time.Minute)))
+
+	request := approval.NewRequest(approval.KindRegistration, testUniqueID, testAddress)
+	require.ErrorIs(t, policy.Evaluate(context.Background(), request), approval.ErrDeferred)
+	_, err := policy.Decide(request.ID, approval.StatusApproved)
+	require.NoError(t, err)
+
+	// the request approved manually is still deferred by the limit
+	err = policy.Evaluate(context.Background(), request)
+	require.ErrorIs(t, err, approval.ErrDeferred)
+	require.ErrorContains(t, err, "approvals are already made")
+
+	stored, err := store.GetRequest(request.ID)
+	require.NoError(t, err)
+	require.Equal(t, approval.StatusApproved, stored.Status)
+}
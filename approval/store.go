@@ -0,0 +1,182 @@
+package approval
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// DBName is the name of the sealed DB which has the approval requests and the history of approvals.
+const DBName = "oracle-approval"
+
+const (
+	KindRegistration = "registration"
+	KindUpgrade      = "upgrade"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+var (
+	requestKeyPrefix  = []byte("request/")
+	approvalKeyPrefix = []byte("approval/")
+)
+
+// Request is an oracle registration or upgrade which is subject to the approval policy.
+// In the manual mode, it is kept with its status until it is approved.
+type Request struct {
+	ID            string    `json:"id"`
+	Kind          string    `json:"kind"`
+	UniqueID      string    `json:"unique_id"`
+	OracleAddress string    `json:"oracle_address"`
+	Status        string    `json:"status"`
+	RequestedAt   time.Time `json:"requested_at"`
+	DecidedAt     time.Time `json:"decided_at,omitempty"`
+}
+
+func NewRequest(kind, uniqueID, oracleAddress string) Request {
+	return Request{
+		ID:            fmt.Sprintf("%s/%s/%s", kind, uniqueID, oracleAddress),
+		Kind:          kind,
+		UniqueID:      uniqueID,
+		OracleAddress: oracleAddress,
+	}
+}
+
+// Store stores the approval requests and the time of approvals.
+type Store interface {
+	// GetRequest returns nil if there is no such request.
+	GetRequest(id string) (*Request, error)
+	SetRequest(request Request) error
+	DeleteRequest(id string) error
+	// ListRequests returns the requests in the order of the request time.
+	ListRequests() ([]Request, error)
+
+	AddApproval(at time.Time) error
+	// CountApprovalsSince counts the approvals since the time, and deletes the older ones.
+	CountApprovalsSince(since time.Time) (int, error)
+}
+
+var _ Store = &DBStore{}
+
+// DBStore stores the approval requests in a DB, which should be sealed.
+type DBStore struct {
+	db dbm.DB
+}
+
+func NewDBStore(db dbm.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+func (s *DBStore) GetRequest(id string) (*Request, error) {
+	bz, err := s.db.Get(requestKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval request %s: %w", id, err)
+	}
+	if bz == nil {
+		return nil, nil
+	}
+
+	var request Request
+	if err := json.Unmarshal(bz, &request); err != nil {
+		return nil, fmt.Errorf("invalid approval request %s: %w", id, err)
+	}
+	return &request, nil
+}
+
+func (s *DBStore) SetRequest(request Request) error {
+	bz, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.SetSync(requestKey(request.ID), bz); err != nil {
+		return fmt.Errorf("failed to set approval request %s: %w", request.ID, err)
+	}
+	return nil
+}
+
+func (s *DBStore) DeleteRequest(id string) error {
+	if err := s.db.DeleteSync(requestKey(id)); err != nil {
+		return fmt.Errorf("failed to delete approval request %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *DBStore) ListRequests() ([]Request, error) {
+	it, err := dbm.IteratePrefix(s.db, requestKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var requests []Request
+	for ; it.Valid(); it.Next() {
+		var request Request
+		if err := json.Unmarshal(it.Value(), &request); err != nil {
+			return nil, fmt.Errorf("invalid approval request %s: %w", it.Key(), err)
+		}
+		requests = append(requests, request)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].RequestedAt.Before(requests[j].RequestedAt)
+	})
+	return requests, nil
+}
+
+func (s *DBStore) AddApproval(at time.Time) error {
+	if err := s.db.SetSync(approvalKey(at), []byte{}); err != nil {
+		return fmt.Errorf("failed to add approval: %w", err)
+	}
+	return nil
+}
+
+func (s *DBStore) CountApprovalsSince(since time.Time) (int, error) {
+	it, err := dbm.IteratePrefix(s.db, approvalKeyPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	var expired [][]byte
+	for ; it.Valid(); it.Next() {
+		if string(it.Key()) < string(approvalKey(since)) {
+			expired = append(expired, it.Key())
+		} else {
+			count++
+		}
+	}
+	err = it.Error()
+	it.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range expired {
+		if err := s.db.Delete(key); err != nil {
+			return 0, fmt.Errorf("failed to delete expired approval: %w", err)
+		}
+	}
+	return count, nil
+}
+
+func requestKey(id string) []byte {
+	return append(append([]byte{}, requestKeyPrefix...), id...)
+}
+
+// approvalKey is ordered by the time of approval.
+func approvalKey(at time.Time) []byte {
+	key := append([]byte{}, approvalKeyPrefix...)
+	return binary.BigEndian.AppendUint64(key, uint64(at.UnixNano()))
+}
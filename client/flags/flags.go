@@ -12,4 +12,15 @@ const (
 	FlagOracleCommissionMaxChangeRate = "oracle-commission-max-change-rate"
 
 	FlagFromOracleRegistrationOrUpgrade = "from"
+
+	FlagGenerateOnly  = "generate-only"
+	FlagOracleAddress = "oracle-address"
+
+	FlagExpectedUniqueID   = "unique-id"
+	FlagExpectedSignerID   = "signer-id"
+	FlagExpectedProductID  = "product-id"
+	FlagOracleRegistration = "oracle-registration"
+	FlagOracleUpgrade      = "oracle-upgrade"
+
+	FlagAll = "all"
 )
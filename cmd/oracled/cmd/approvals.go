@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/medibloc/panacea-oracle/admin"
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/medibloc/panacea-oracle/store/sgxleveldb"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func approvalsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "Manage the oracle registrations and upgrades waiting for a manual approval",
+		Long: `Manage the oracle registrations and upgrades queued by the manual approval mode.
+The approved requests are approved on chain at the next reconciliation, or when the oracle starts.
+If the oracle is running, the requests are managed via its admin socket in the data directory.
+Otherwise, they are managed in the approval DB directly.`,
+	}
+
+	cmd.AddCommand(
+		listApprovalsCmd(),
+		decideApprovalsCmd("approve", approval.StatusApproved),
+		decideApprovalsCmd("reject", approval.StatusRejected),
+	)
+
+	return cmd
+}
+
+func listApprovalsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the approval requests in JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withApprovalManager(cmd, func(manager approval.Manager) error {
+				requests, err := manager.ListRequests()
+				if err != nil {
+					return err
+				}
+				return printApprovalRequests(cmd.OutOrStdout(), requests)
+			})
+		},
+	}
+}
+
+func decideApprovalsCmd(use, status string) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("%s [request-id...]", use),
+		Short: fmt.Sprintf("Mark the approval requests as %s", status),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withApprovalManager(cmd, func(manager approval.Manager) error {
+				for _, id := range args {
+					if _, err := manager.Decide(id, status); err != nil {
+						return err
+					}
+					log.Infof("approval request %s is %s", id, status)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// withApprovalManager calls fn with the admin client of the running oracle, or with the policy on the approval DB if the oracle is not running.
+func withApprovalManager(cmd *cobra.Command, fn func(approval.Manager) error) error {
+	conf, err := loadConfigFromHome(cmd)
+	if err != nil {
+		return err
+	}
+	if conf.Approval.Mode != "manual" {
+		log.Warnf("approval mode is %s. the requests are approved only in the manual mode", conf.Approval.Mode)
+	}
+
+	if client, err := admin.Dial(admin.SocketPath(conf.AbsDataDirPath())); err == nil {
+		return fn(client)
+	}
+
+	oracleSgx, err := sgx.New(conf)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SGX: %w", err)
+	}
+
+	db, err := sgxleveldb.NewSgxLevelDB(approval.DBName, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(conf.Sealing.ApprovalDB))
+	if err != nil {
+		return fmt.Errorf("failed to open %s DB. the oracle may be running: %w", approval.DBName, err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Warn(err)
+		}
+	}()
+
+	policy, err := approval.NewPolicy(conf.Approval, nil, approval.NewDBStore(db))
+	if err != nil {
+		return err
+	}
+	return fn(policy)
+}
+
+func printApprovalRequests(w io.Writer, requests []approval.Request) error {
+	if requests == nil {
+		requests = []approval.Request{}
+	}
+
+	bz, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval requests: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
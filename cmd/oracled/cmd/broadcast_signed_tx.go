@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/client/flags"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/panacea"
+	"github.com/medibloc/panacea-oracle/service"
+	"github.com/medibloc/panacea-oracle/sgx"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func broadcastSignedTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast-signed-tx [signed-tx-file]",
+		Short: "Broadcast a tx signed offline, and wait for its approval if it registers or upgrades the oracle",
+		Long: `Broadcast a tx which is generated by --generate-only and signed offline.
+If the tx contains MsgRegisterOracle or MsgUpgradeOracle, the oracle private key is retrieved when the registration or the upgrade is approved.
+The oracle mnemonic is not needed, since the oracle address is taken from the msgs in the tx, or from --oracle-address.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txJSON, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read the signed tx: %w", err)
+			}
+
+			conf, err := loadConfigFromHome(cmd)
+			if err != nil {
+				return err
+			}
+
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
+
+			queryClient, err := panacea.LoadVerifiedQueryClient(context.Background(), conf, sgx)
+			if err != nil {
+				return fmt.Errorf("failed to load query client: %w", err)
+			}
+			defer queryClient.Close()
+
+			grpcClient, err := panacea.NewGRPCClient(conf.Panacea.GRPCAddr, conf.Panacea.ChainID)
+			if err != nil {
+				return fmt.Errorf("failed to create a new gRPC client: %w", err)
+			}
+			txBytes, msgs, err := panacea.NewTxBuilder(grpcClient).DecodeSignedTxJSON(txJSON)
+			if closeErr := grpcClient.Close(); closeErr != nil {
+				log.Warn(closeErr)
+			}
+			if err != nil {
+				return err
+			}
+
+			oracleAddress, err := cmd.Flags().GetString(flags.FlagOracleAddress)
+			if err != nil {
+				return err
+			}
+			if oracleAddress == "" {
+				oracleAddress = oracleAddressOfMsgs(msgs)
+			}
+			if oracleAddress == "" {
+				return fmt.Errorf("no oracle msg in the tx. please set --%s", flags.FlagOracleAddress)
+			}
+
+			svc, err := service.NewWithOracleAddress(conf, sgx, queryClient, oracleAddress)
+			if err != nil {
+				return fmt.Errorf("failed to create service: %w", err)
+			}
+			defer svc.Close()
+
+			txHeight, txHash, err := svc.BroadcastSignedTx(context.Background(), txBytes)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast the signed tx: %w", err)
+			}
+			log.Infof("signed transaction succeed. height(%v), hash(%s)", txHeight, txHash)
+
+			for _, msg := range msgs {
+				switch msg.(type) {
+				case *oracletypes.MsgRegisterOracle:
+					return subscribeApproveOracleRegistrationEvent(svc)
+				case *oracletypes.MsgUpgradeOracle:
+					return subscribeApproveOracleUpgradeEvent(svc)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagOracleAddress, "", "Address of the oracle, if the tx has no msg of the oracle")
+
+	return cmd
+}
+
+// oracleAddressOfMsgs returns the oracle address of the first oracle msg, or an empty string if there is no oracle msg.
+func oracleAddressOfMsgs(msgs []sdk.Msg) string {
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case *oracletypes.MsgRegisterOracle:
+			return msg.OracleAddress
+		case *oracletypes.MsgUpgradeOracle:
+			return msg.OracleAddress
+		case *oracletypes.MsgUpdateOracleInfo:
+			return msg.OracleAddress
+		}
+	}
+	return ""
+}
+
+// newTxService returns the service which signs txs with the oracle mnemonic.
+// If --oracle-address is set with --generate-only, the service of the address is returned without loading the mnemonic,
+// since the tx is signed offline by the key in custody.
+func newTxService(cmd *cobra.Command, conf *config.Config, oracleSgx sgx.Sgx, queryClient panacea.QueryClient, generateOnly bool) (service.Service, error) {
+	oracleAddress, err := cmd.Flags().GetString(flags.FlagOracleAddress)
+	if err != nil {
+		return nil, err
+	}
+	if oracleAddress == "" {
+		return service.New(conf, oracleSgx, queryClient)
+	}
+	if !generateOnly {
+		return nil, fmt.Errorf("--%s can be used only with --%s", flags.FlagOracleAddress, flags.FlagGenerateOnly)
+	}
+	return service.NewWithOracleAddress(conf, oracleSgx, queryClient, oracleAddress)
+}
+
+// printUnsignedTx prints the unsigned tx of the msgs, so that the tx is signed offline and broadcast by broadcast-signed-tx.
+func printUnsignedTx(cmd *cobra.Command, svc service.Service, msg ...sdk.Msg) error {
+	txJSON, err := panacea.NewTxBuilder(svc.GRPCClient()).GenerateUnsignedTxJSON(svc.Config(), msg...)
+	if err != nil {
+		return fmt.Errorf("failed to generate the unsigned tx: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(txJSON)); err != nil {
+		return err
+	}
+
+	log.Infof("sign the tx offline, and broadcast it by 'oracled broadcast-signed-tx'")
+	return nil
+}
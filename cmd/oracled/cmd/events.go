@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/medibloc/panacea-oracle/admin"
+	"github.com/medibloc/panacea-oracle/client/flags"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/medibloc/panacea-oracle/store/sgxleveldb"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func eventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Manage the events failed to be handled",
+		Long: `Manage the events in the dead-letter queue, which were failed to be handled.
+If the oracle is running, the events are managed via its admin socket in the data directory.
+Otherwise, they are managed in the dead-letter DB directly.`,
+	}
+
+	cmd.AddCommand(
+		listEventsCmd(),
+		retryEventsCmd(),
+		dropEventsCmd(),
+	)
+
+	return cmd
+}
+
+func listEventsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the failed events in JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDeadLetterManager(cmd, func(manager event.DeadLetterManager) error {
+				events, err := manager.ListFailedEvents()
+				if err != nil {
+					return err
+				}
+				return printFailedEvents(cmd.OutOrStdout(), events)
+			})
+		},
+	}
+}
+
+func retryEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry [event-id...]",
+		Short: "Retry the failed events as soon as possible",
+		Long: `Schedule the failed events to be retried as soon as possible, or when the oracle starts if it is not running.
+Each of the events is retried once more, even if it has been retried as many as max-retries.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, err := cmd.Flags().GetBool(flags.FlagAll)
+			if err != nil {
+				return err
+			}
+			return withDeadLetterManager(cmd, func(manager event.DeadLetterManager) error {
+				events, err := manager.RetryFailedEvents(args, all)
+				if err != nil {
+					return err
+				}
+				for _, failed := range events {
+					log.Infof("event %s will be retried", failed.ID)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().Bool(flags.FlagAll, false, "retry all the failed events")
+
+	return cmd
+}
+
+func dropEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drop [event-id...]",
+		Short: "Drop the failed events without retry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, err := cmd.Flags().GetBool(flags.FlagAll)
+			if err != nil {
+				return err
+			}
+			return withDeadLetterManager(cmd, func(manager event.DeadLetterManager) error {
+				events, err := manager.DropFailedEvents(args, all)
+				if err != nil {
+					return err
+				}
+				for _, failed := range events {
+					log.Infof("event %s is dropped", failed.ID)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().Bool(flags.FlagAll, false, "drop all the failed events")
+
+	return cmd
+}
+
+// withDeadLetterManager calls fn with the admin client of the running oracle, or with the queue on the dead-letter DB if the oracle is not running.
+func withDeadLetterManager(cmd *cobra.Command, fn func(event.DeadLetterManager) error) error {
+	conf, err := loadConfigFromHome(cmd)
+	if err != nil {
+		return err
+	}
+
+	if client, err := admin.Dial(admin.SocketPath(conf.AbsDataDirPath())); err == nil {
+		return fn(client)
+	}
+
+	oracleSgx, err := sgx.New(conf)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SGX: %w", err)
+	}
+
+	db, err := sgxleveldb.NewSgxLevelDB(event.DeadLetterDBName, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(conf.Sealing.EventDeadLetterDB))
+	if err != nil {
+		return fmt.Errorf("failed to open %s DB. the oracle may be running: %w", event.DeadLetterDBName, err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Warn(err)
+		}
+	}()
+
+	return fn(event.NewDeadLetterQueue(event.NewDBDeadLetterStore(db), conf.Event.MaxRetries, conf.Event.RetryInterval))
+}
+
+func printFailedEvents(w io.Writer, events []event.FailedEvent) error {
+	if events == nil {
+		events = []event.FailedEvent{}
+	}
+
+	bz, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal failed events: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
@@ -62,10 +62,14 @@ func genOracleKeyCmd() *cobra.Command {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
+			oraclePrivKeyPolicy := sgx.SealPolicy(conf.Sealing.OraclePrivKey)
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
 
 			// seal and store oracle private key
-			if err := sgx.SealToFile(oraclePrivKey.Serialize(), oraclePrivKeyPath); err != nil {
+			if err := sgx.SealToFile(oraclePrivKey.Serialize(), oraclePrivKeyPath, oraclePrivKeyPolicy); err != nil {
 				log.Errorf("failed to write %s: %v", oraclePrivKeyPath, err)
 				return err
 			}
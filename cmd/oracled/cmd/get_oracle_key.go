@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/client/flags"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/key"
 	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/medibloc/panacea-oracle/service"
 	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/medibloc/panacea-oracle/store/sgxleveldb"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -21,61 +26,34 @@ func getOracleKeyCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get-oracle-key",
 		Short: "Get a shared oracle private key",
+		Long: `Get a shared oracle private key.
+If the key is got from the upgrade, the state sealed by the previous enclave is resealed by this enclave.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			conf, err := loadConfigFromHome(cmd)
 			if err != nil {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
-
-			queryClient, err := panacea.LoadVerifiedQueryClient(context.Background(), conf, sgx)
+			sgx, err := sgx.New(conf)
 			if err != nil {
-				return fmt.Errorf("failed to load query client: %w", err)
+				return fmt.Errorf("failed to initialize SGX: %w", err)
 			}
-			defer queryClient.Close()
 
-			svc, err := service.New(conf, sgx, queryClient)
+			from, err := cmd.Flags().GetString(flags.FlagFromOracleRegistrationOrUpgrade)
 			if err != nil {
 				return err
 			}
-			defer svc.Close()
-
-			ctx := context.Background()
 
-			uniqueID := svc.EnclaveInfo().UniqueIDHex()
-			oracleAddress := svc.OracleAcc().GetAddress()
-
-			from, err := cmd.Flags().GetString(flags.FlagFromOracleRegistrationOrUpgrade)
-			if err != nil {
+			if err := getOracleKey(conf, sgx, from); err != nil {
 				return err
 			}
 
-			switch from {
-			case fromRegistration:
-				oracleRegistration, err := svc.QueryClient().GetOracleRegistration(ctx, uniqueID, oracleAddress)
-				if err != nil {
-					return fmt.Errorf("failed to get oracle registration: %w", err)
-				}
-
-				if len(oracleRegistration.EncryptedOraclePrivKey) == 0 {
-					return fmt.Errorf("the encrypted oracle private key has not set yet. please try again later")
-				}
-				return key.DecryptAndStoreOraclePrivKey(ctx, svc, oracleRegistration.EncryptedOraclePrivKey)
-
-			case fromUpgrade:
-				oracleUpgrade, err := svc.QueryClient().GetOracleUpgrade(ctx, uniqueID, oracleAddress)
-				if err != nil {
-					return fmt.Errorf("failed to get oracle upgrade: %w", err)
-				}
-				if len(oracleUpgrade.EncryptedOraclePrivKey) == 0 {
-					return fmt.Errorf("the encrypted oracle private key has not set yet. please try again later")
-				}
-				return key.DecryptAndStoreOraclePrivKey(ctx, svc, oracleUpgrade.EncryptedOraclePrivKey)
-
-			default:
-				return fmt.Errorf("invalid --from flag input. please put \"registration\" or \"upgrade\"")
+			if from == fromUpgrade {
+				// the sealed DBs should be closed before resealing
+				return resealState(conf, sgx)
 			}
+
+			return nil
 		},
 	}
 
@@ -83,3 +61,81 @@ func getOracleKeyCmd() *cobra.Command {
 
 	return cmd
 }
+
+func getOracleKey(conf *config.Config, sgx sgx.Sgx, from string) error {
+	queryClient, err := panacea.LoadVerifiedQueryClient(context.Background(), conf, sgx)
+	if err != nil {
+		return fmt.Errorf("failed to load query client: %w", err)
+	}
+	defer queryClient.Close()
+
+	svc, err := service.New(conf, sgx, queryClient)
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+
+	ctx := context.Background()
+
+	uniqueID := svc.EnclaveInfo().UniqueIDHex()
+	oracleAddress := svc.OracleAcc().GetAddress()
+
+	switch from {
+	case fromRegistration:
+		oracleRegistration, err := svc.QueryClient().GetOracleRegistration(ctx, uniqueID, oracleAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get oracle registration: %w", err)
+		}
+
+		if len(oracleRegistration.EncryptedOraclePrivKey) == 0 {
+			return fmt.Errorf("the encrypted oracle private key has not set yet. please try again later")
+		}
+		return key.DecryptAndStoreOraclePrivKey(ctx, svc, oracleRegistration.EncryptedOraclePrivKey)
+
+	case fromUpgrade:
+		oracleUpgrade, err := svc.QueryClient().GetOracleUpgrade(ctx, uniqueID, oracleAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get oracle upgrade: %w", err)
+		}
+		if len(oracleUpgrade.EncryptedOraclePrivKey) == 0 {
+			return fmt.Errorf("the encrypted oracle private key has not set yet. please try again later")
+		}
+		return key.DecryptAndStoreOraclePrivKey(ctx, svc, oracleUpgrade.EncryptedOraclePrivKey)
+
+	default:
+		return fmt.Errorf("invalid --from flag input. please put \"registration\" or \"upgrade\"")
+	}
+}
+
+// resealState reseals the sealed DBs with this enclave and the seal policies in the config.
+// The previous enclave should have sealed them with a product key, so that this enclave can unseal them.
+func resealState(conf *config.Config, oracleSgx sgx.Sgx) error {
+	sealedDBs := []struct {
+		name   string
+		policy string
+	}{
+		{panacea.LightClientDBName, conf.Sealing.LightClientDB},
+		{event.CheckpointDBName, conf.Sealing.EventCheckpointDB},
+		{event.DeadLetterDBName, conf.Sealing.EventDeadLetterDB},
+		{approval.DBName, conf.Sealing.ApprovalDB},
+	}
+
+	for _, sealedDB := range sealedDBs {
+		db, err := sgxleveldb.NewSgxLevelDB(sealedDB.name, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(sealedDB.policy))
+		if err != nil {
+			return fmt.Errorf("failed to open %s DB: %w", sealedDB.name, err)
+		}
+
+		count, err := db.Reseal()
+		if closeErr := db.Close(); closeErr != nil {
+			log.Warn(closeErr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reseal %s DB. it may have been sealed with a unique key by the previous enclave: %w", sealedDB.name, err)
+		}
+
+		log.Infof("%d values in %s DB are resealed", count, sealedDB.name)
+	}
+
+	return nil
+}
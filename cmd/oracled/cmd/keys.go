@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/medibloc/panacea-oracle/panacea"
+	"github.com/medibloc/panacea-oracle/sgx"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	tos "github.com/tendermint/tendermint/libs/os"
+)
+
+func keysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the key of the oracle account",
+	}
+
+	cmd.AddCommand(
+		importKeyCmd(),
+	)
+
+	return cmd
+}
+
+func importKeyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import",
+		Short: "Import the mnemonic of the oracle account",
+		Long: `Import the mnemonic of the oracle account from stdin, and seal it to the oracle-mnemonic-file.
+After importing, remove the plain oracle-mnemonic from the config.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadConfigFromHome(cmd)
+			if err != nil {
+				return err
+			}
+
+			buf := bufio.NewReader(os.Stdin)
+
+			mnemonicPath := conf.AbsOracleMnemonicPath()
+			if tos.FileExists(mnemonicPath) {
+				ok, err := input.GetConfirmation(fmt.Sprintf("This can replace the existing %s file.\nAre you sure to import a new mnemonic?", mnemonicPath), buf, os.Stderr)
+				if err != nil || !ok {
+					log.Infof("Mnemonic import is canceled.")
+					return err
+				}
+			}
+
+			mnemonic, err := input.GetString("Enter your bip39 mnemonic", buf)
+			if err != nil {
+				return err
+			}
+			mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+
+			// check if the oracle account can be derived before sealing the mnemonic
+			oracleAccount, err := panacea.NewOracleAccount(mnemonic, conf.OracleAccNum, conf.OracleAccIndex)
+			if err != nil {
+				return fmt.Errorf("failed to get oracle account from mnemonic: %w", err)
+			}
+
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
+
+			if err := panacea.SealOracleMnemonic(conf, sgx, mnemonic); err != nil {
+				return err
+			}
+
+			log.Infof("the mnemonic of the oracle account %s is sealed in %s", oracleAccount.GetSignerAddress(), mnemonicPath)
+			if conf.OracleMnemonic != "" {
+				log.Warnf("remove the plain oracle-mnemonic from the config")
+			}
+			return nil
+		},
+	}
+}
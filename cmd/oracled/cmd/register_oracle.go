@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,7 +11,6 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/input"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/edgelesssys/ego/enclave"
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/client/flags"
 	"github.com/medibloc/panacea-oracle/crypto"
@@ -42,7 +40,10 @@ func registerOracleCmd() *cobra.Command {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
 
 			queryClient, err := panacea.NewVerifiedQueryClient(context.Background(), conf, trustedBlockInfo, sgx)
 			if err != nil {
@@ -50,12 +51,25 @@ func registerOracleCmd() *cobra.Command {
 			}
 			defer queryClient.Close()
 
-			svc, err := service.New(conf, sgx, queryClient)
+			generateOnly, err := cmd.Flags().GetBool(flags.FlagGenerateOnly)
+			if err != nil {
+				return err
+			}
+
+			svc, err := newTxService(cmd, conf, sgx, queryClient, generateOnly)
 			if err != nil {
 				return fmt.Errorf("failed to create service: %w", err)
 			}
 			defer svc.Close()
 
+			if generateOnly {
+				msgRegisterOracle, err := generateMsgRegisterOracle(cmd, svc, svc.OracleAcc(), trustedBlockInfo)
+				if err != nil || msgRegisterOracle == nil {
+					return err
+				}
+				return printUnsignedTx(cmd, svc, msgRegisterOracle)
+			}
+
 			if err := sendTxRegisterOracle(cmd, svc, trustedBlockInfo); err != nil {
 				return fmt.Errorf("failed to send tx RegisterOracle. %w", err)
 			}
@@ -75,6 +89,8 @@ func registerOracleCmd() *cobra.Command {
 	cmd.Flags().String(flags.FlagOracleCommissionRate, "0.1", "oracle commission rate")
 	cmd.Flags().String(flags.FlagOracleCommissionMaxRate, "", "oracle commission rate")
 	cmd.Flags().String(flags.FlagOracleCommissionMaxChangeRate, "", "oracle commission rate")
+	cmd.Flags().Bool(flags.FlagGenerateOnly, false, "Print the unsigned tx to be signed offline, instead of broadcasting it")
+	cmd.Flags().String(flags.FlagOracleAddress, "", "Address of the oracle account in custody, used with --generate-only instead of the oracle mnemonic")
 	if err := cmd.MarkFlagRequired(flags.FlagTrustedBlockHeight); err != nil {
 		panic(err)
 	}
@@ -103,7 +119,7 @@ func sendTxRegisterOracle(cmd *cobra.Command, svc service.Service, trustedBlockI
 		return err
 	}
 
-	txHeight, txHash, err := svc.BroadcastTx(msgRegisterOracle)
+	txHeight, txHash, err := svc.BroadcastTx(context.Background(), msgRegisterOracle)
 	if err != nil {
 		return fmt.Errorf("failed to RegisterOracle transaction: %v", err)
 	}
@@ -128,16 +144,13 @@ func generateMsgRegisterOracle(cmd *cobra.Command, svc service.Service, oracleAc
 	}
 
 	// generate node key and its remote report
-	nodePubKey, nodePubKeyRemoteReport, err := generateAndSealedNodeKey(svc.SGX(), nodePrivKeyPath)
+	nodePubKey, nodePubKeyRemoteReport, err := generateAndSealedNodeKey(svc.SGX(), nodePrivKeyPath, sgx.SealPolicy(conf.Sealing.NodePrivKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate node key pair: %w", err)
 	}
 
-	report, err := enclave.VerifyRemoteReport(nodePubKeyRemoteReport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verification remoteReport. %w", err)
-	}
-	uniqueID := hex.EncodeToString(report.UniqueID)
+	// the node key report is generated by this enclave
+	uniqueID := svc.EnclaveInfo().UniqueIDHex()
 
 	oracleEndpoint, err := cmd.Flags().GetString(flags.FlagOracleEndpoint)
 	if err != nil {
@@ -192,13 +205,13 @@ func generateMsgRegisterOracle(cmd *cobra.Command, svc service.Service, oracleAc
 
 // generateAndSealedNodeKey generates random node key and its remote report
 // And the generated private key is sealed and stored
-func generateAndSealedNodeKey(sgx sgx.Sgx, nodePrivKeyPath string) ([]byte, []byte, error) {
+func generateAndSealedNodeKey(sgx sgx.Sgx, nodePrivKeyPath string, policy sgx.SealPolicy) ([]byte, []byte, error) {
 	nodePrivKey, err := crypto.NewPrivKey()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if err := sgx.SealToFile(nodePrivKey.Serialize(), nodePrivKeyPath); err != nil {
+	if err := sgx.SealToFile(nodePrivKey.Serialize(), nodePrivKeyPath, policy); err != nil {
 		return nil, nil, err
 	}
 
@@ -42,6 +42,10 @@ func init() {
 		startCmd(),
 		verifyReportCmd(),
 		upgradeOracle(),
+		eventsCmd(),
+		approvalsCmd(),
+		broadcastSignedTxCmd(),
+		keysCmd(),
 	)
 }
 
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	datadealevent "github.com/medibloc/panacea-oracle/event/datadeal"
 	oracleevent "github.com/medibloc/panacea-oracle/event/oracle"
 	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/medibloc/panacea-oracle/server"
@@ -26,7 +27,10 @@ func startCmd() *cobra.Command {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
 
 			queryClient, err := panacea.LoadVerifiedQueryClient(context.Background(), conf, sgx)
 			if err != nil {
@@ -43,11 +47,21 @@ func startCmd() *cobra.Command {
 			err = svc.StartSubscriptions(
 				oracleevent.NewRegisterOracleEvent(svc),
 				oracleevent.NewUpgradeOracleEvent(svc),
+				oracleevent.NewUpdateOracleInfoEvent(svc),
+				oracleevent.NewOracleRegistrationApprovedEvent(svc),
+				oracleevent.NewOracleUpgradeApprovedEvent(svc),
+				datadealevent.NewCreateDealEvent(svc),
+				datadealevent.NewDeactivateDealEvent(svc),
+				datadealevent.NewSubmitConsentEvent(svc),
 			)
 			if err != nil {
 				return fmt.Errorf("failed to start event subscription: %w", err)
 			}
 
+			reconciler := oracleevent.NewReconciler(svc, conf.Event.ReconcileInterval)
+			reconciler.Start()
+			defer reconciler.Close()
+
 			servers, errChan := server.Serve(svc)
 
 			sigChan := make(chan os.Signal, 1)
@@ -8,7 +8,6 @@ import (
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/client/flags"
 	"github.com/medibloc/panacea-oracle/panacea"
-	"github.com/medibloc/panacea-oracle/service"
 	"github.com/medibloc/panacea-oracle/sgx"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -24,7 +23,10 @@ func updateOracleInfoCmd() *cobra.Command {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
 
 			queryClient, err := panacea.LoadVerifiedQueryClient(context.Background(), conf, sgx)
 			if err != nil {
@@ -32,16 +34,18 @@ func updateOracleInfoCmd() *cobra.Command {
 			}
 			defer queryClient.Close()
 
-			svc, err := service.New(conf, sgx, queryClient)
+			generateOnly, err := cmd.Flags().GetBool(flags.FlagGenerateOnly)
+			if err != nil {
+				return err
+			}
+
+			svc, err := newTxService(cmd, conf, sgx, queryClient, generateOnly)
 			if err != nil {
 				return fmt.Errorf("failed to create service: %w", err)
 			}
 			defer svc.Close()
 
 			oracleAccount := svc.OracleAcc()
-			if err != nil {
-				return fmt.Errorf("failed to get oracle account from mnemonic: %w", err)
-			}
 
 			oracleEndPoint, err := cmd.Flags().GetString(flags.FlagOracleEndpoint)
 			if err != nil {
@@ -59,7 +63,12 @@ func updateOracleInfoCmd() *cobra.Command {
 			}
 
 			msgUpdateOracleInfo := oracletypes.NewMsgUpdateOracleInfo(oracleAccount.GetAddress(), oracleEndPoint, &oracleCommissionRate)
-			txHeight, txHash, err := svc.BroadcastTx(msgUpdateOracleInfo)
+
+			if generateOnly {
+				return printUnsignedTx(cmd, svc, msgUpdateOracleInfo)
+			}
+
+			txHeight, txHash, err := svc.BroadcastTx(context.Background(), msgUpdateOracleInfo)
 			if err != nil {
 				return err
 			}
@@ -71,6 +80,8 @@ func updateOracleInfoCmd() *cobra.Command {
 
 	cmd.Flags().String(flags.FlagOracleEndpoint, "", "endpoint of oracle")
 	cmd.Flags().String(flags.FlagOracleCommissionRate, "", "oracle commission rate")
+	cmd.Flags().Bool(flags.FlagGenerateOnly, false, "Print the unsigned tx to be signed offline, instead of broadcasting it")
+	cmd.Flags().String(flags.FlagOracleAddress, "", "Address of the oracle account in custody, used with --generate-only instead of the oracle mnemonic")
 
 	return cmd
 }
@@ -3,14 +3,12 @@ package cmd
 import (
 	"bufio"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/cosmos/cosmos-sdk/client/input"
-	"github.com/edgelesssys/ego/enclave"
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/client/flags"
 	oracleevent "github.com/medibloc/panacea-oracle/event/oracle"
@@ -39,7 +37,10 @@ func upgradeOracle() *cobra.Command {
 				return err
 			}
 
-			sgx := sgx.NewOracleSGX()
+			sgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
 
 			queryClient, err := panacea.NewVerifiedQueryClient(context.Background(), conf, trustedBlockInfo, sgx)
 			if err != nil {
@@ -85,7 +86,7 @@ func sendTxUpgradeOracle(svc service.Service, trustedBlockInfo *panacea.TrustedB
 		return fmt.Errorf("failed to generate MsgUpgradeOracle: %w", err)
 	}
 
-	txHeight, txHash, err := svc.BroadcastTx(msgRegisterOracle)
+	txHeight, txHash, err := svc.BroadcastTx(context.Background(), msgRegisterOracle)
 	if err != nil {
 		return fmt.Errorf("failed to broadcast UpgradeOracle Tx: %w", err)
 	}
@@ -110,13 +111,13 @@ func generateMsgUpgradeOracle(svc service.Service, oracleAccount *panacea.Oracle
 	}
 
 	// generate node key and its remote report
-	nodePubKey, nodePubKeyRemoteReport, err := generateAndSealedNodeKey(svc.SGX(), nodePrivKeyPath)
+	nodePubKey, nodePubKeyRemoteReport, err := generateAndSealedNodeKey(svc.SGX(), nodePrivKeyPath, sgx.SealPolicy(conf.Sealing.NodePrivKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate node key pair: %w", err)
 	}
 
-	report, _ := enclave.VerifyRemoteReport(nodePubKeyRemoteReport)
-	uniqueID := hex.EncodeToString(report.UniqueID)
+	// the node key report is generated by this enclave
+	uniqueID := svc.EnclaveInfo().UniqueIDHex()
 
 	msgRegisterOracle := &oracletypes.MsgUpgradeOracle{
 		UniqueId:               uniqueID,
@@ -1,17 +1,72 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/edgelesssys/ego/attestation"
+	"github.com/medibloc/panacea-oracle/client/flags"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/medibloc/panacea-oracle/sgx"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+const (
+	reportSourceFile         = "file"
+	reportSourceRegistration = "registration"
+	reportSourceUpgrade      = "upgrade"
+)
+
+// reportTarget is a public key and its remote report to be verified.
+type reportTarget struct {
+	source        string
+	oracleAddress string
+	pubKey        []byte
+	remoteReport  []byte
+}
+
+// ReportExpectation is what the remote report is verified against.
+type ReportExpectation struct {
+	UniqueID           string   `json:"unique_id"`
+	SignerID           string   `json:"signer_id,omitempty"`
+	ProductID          uint16   `json:"product_id,omitempty"`
+	MinSecurityVersion uint     `json:"min_security_version"`
+	AllowDebug         bool     `json:"allow_debug"`
+	AllowedTCBStatuses []string `json:"allowed_tcb_statuses"`
+}
+
+// ReportContent is the content of the remote report.
+type ReportContent struct {
+	Data            string `json:"data"`
+	SecurityVersion uint   `json:"security_version"`
+	Debug           bool   `json:"debug"`
+	UniqueID        string `json:"unique_id"`
+	SignerID        string `json:"signer_id"`
+	ProductID       uint16 `json:"product_id"`
+	TCBStatus       string `json:"tcb_status"`
+}
+
+// ReportVerdict is the result of verify-report printed in JSON.
+type ReportVerdict struct {
+	Verified        bool              `json:"verified"`
+	Reasons         []string          `json:"reasons,omitempty"`
+	Source          string            `json:"source"`
+	OracleAddress   string            `json:"oracle_address,omitempty"`
+	PublicKeyBase64 string            `json:"public_key_base64"`
+	Expected        ReportExpectation `json:"expected"`
+	Report          *ReportContent    `json:"report,omitempty"`
+}
+
 func verifyReportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "verify-report [report-file-path]",
@@ -24,31 +79,180 @@ func verifyReportCmd() *cobra.Command {
 				"public_key_base64":"<base64-encoded-public-key>",
 				"remote_report_base64":"<base64-encoded-remote-report>"
 			}
+
+			Instead of a file, the node key and its remote report can be taken from Panacea
+			by --oracle-registration or --oracle-upgrade with the oracle address.
+			They are queried with the light client of the oracle in the home directory, which should be initialized beforehand.
+
+			The expected unique ID is --unique-id if given. Otherwise, it is the unique ID of the oracle upgrade info
+			for --oracle-upgrade, or the unique ID of this binary.
+			The other requirements are taken from the attestation config, and can be overridden by --signer-id and --product-id.
+
+			The verdict is printed in JSON. If the report is not verified, the command exits with an error.
 		`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// read oracle remote targetReport
-			pubKeyInfo, err := readOracleRemoteReport(args[0])
+			// the SGX simulation is used only if it is enabled in the config of the home directory
+			conf, err := loadConfigFromHome(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			oracleSgx, err := sgx.New(conf)
+			if err != nil {
+				return fmt.Errorf("failed to initialize SGX: %w", err)
+			}
+
+			policy, err := reportPolicyFromFlags(cmd, conf)
+			if err != nil {
+				return err
+			}
+
+			expectedUniqueID, err := cmd.Flags().GetString(flags.FlagExpectedUniqueID)
+			if err != nil {
+				return err
+			}
+
+			target, expectedUniqueID, err := readReportTarget(cmd, args, conf, oracleSgx, strings.ToLower(expectedUniqueID))
 			if err != nil {
-				log.Errorf("failed to read remote targetReport: %v", err)
 				return err
 			}
 
-			if err := verifyPubKeyRemoteReport(*pubKeyInfo); err != nil {
-				log.Errorf("failed to verify the public key and its remote report: %v", err)
+			verdict := verifyReportTarget(oracleSgx, policy, *target, expectedUniqueID)
+			if err := printReportVerdict(cmd.OutOrStdout(), verdict); err != nil {
 				return err
 			}
 
-			log.Infof("remote report is verified successfully")
+			if !verdict.Verified {
+				return fmt.Errorf("remote report is not verified")
+			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().String(flags.FlagExpectedUniqueID, "", "expected unique ID (hex) of the enclave")
+	cmd.Flags().String(flags.FlagExpectedSignerID, "", "expected signer ID (hex) of the enclave. it overrides the attestation config")
+	cmd.Flags().Uint16(flags.FlagExpectedProductID, 0, "expected product ID of the enclave. it overrides the attestation config")
+	cmd.Flags().String(flags.FlagOracleRegistration, "", "address of the oracle whose registration has the report to verify")
+	cmd.Flags().String(flags.FlagOracleUpgrade, "", "address of the oracle whose upgrade has the report to verify")
+
 	return cmd
 }
 
-func readOracleRemoteReport(filename string) (*OraclePubKeyInfo, error) {
+// reportPolicyFromFlags returns the attestation policy in the config overridden by the flags.
+func reportPolicyFromFlags(cmd *cobra.Command, conf *config.Config) (sgx.AttestationPolicy, error) {
+	policy, err := sgx.NewConfigAttestationPolicy(conf)
+	if err != nil {
+		return sgx.AttestationPolicy{}, fmt.Errorf("failed to create attestation policy: %w", err)
+	}
+
+	if cmd.Flags().Changed(flags.FlagExpectedSignerID) {
+		signerIDHex, err := cmd.Flags().GetString(flags.FlagExpectedSignerID)
+		if err != nil {
+			return sgx.AttestationPolicy{}, err
+		}
+		policy.SignerID, err = hex.DecodeString(signerIDHex)
+		if err != nil {
+			return sgx.AttestationPolicy{}, fmt.Errorf("invalid signer ID: %w", err)
+		}
+	}
+
+	if cmd.Flags().Changed(flags.FlagExpectedProductID) {
+		policy.ProductID, err = cmd.Flags().GetUint16(flags.FlagExpectedProductID)
+		if err != nil {
+			return sgx.AttestationPolicy{}, err
+		}
+	}
+
+	return policy, nil
+}
+
+// readReportTarget reads the public key and its remote report from the file or Panacea, and returns them with the expected unique ID.
+func readReportTarget(cmd *cobra.Command, args []string, conf *config.Config, oracleSgx sgx.Sgx, expectedUniqueID string) (*reportTarget, string, error) {
+	registrationAddr, err := cmd.Flags().GetString(flags.FlagOracleRegistration)
+	if err != nil {
+		return nil, "", err
+	}
+	upgradeAddr, err := cmd.Flags().GetString(flags.FlagOracleUpgrade)
+	if err != nil {
+		return nil, "", err
+	}
+
+	numSources := len(args)
+	if registrationAddr != "" {
+		numSources++
+	}
+	if upgradeAddr != "" {
+		numSources++
+	}
+	if numSources != 1 {
+		return nil, "", fmt.Errorf("exactly one of a report file, --%s and --%s is required", flags.FlagOracleRegistration, flags.FlagOracleUpgrade)
+	}
+
+	if len(args) == 1 {
+		if expectedUniqueID == "" {
+			expectedUniqueID, err = selfUniqueID(oracleSgx)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		target, err := readOracleRemoteReport(args[0])
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read remote report: %w", err)
+		}
+		return target, expectedUniqueID, nil
+	}
+
+	// the report and the expected unique ID are verified by the light client, so that a gRPC node cannot forge them
+	ctx := context.Background()
+	queryClient, err := panacea.LoadVerifiedQueryClient(ctx, conf, oracleSgx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load query client: %w", err)
+	}
+	defer queryClient.Close()
+
+	if upgradeAddr != "" {
+		if expectedUniqueID == "" {
+			upgradeInfo, err := queryClient.GetOracleUpgradeInfo(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			expectedUniqueID = upgradeInfo.UniqueId
+		}
+
+		upgrade, err := queryClient.GetOracleUpgrade(ctx, expectedUniqueID, upgradeAddr)
+		if err != nil {
+			return nil, "", err
+		}
+		return &reportTarget{
+			source:        reportSourceUpgrade,
+			oracleAddress: upgradeAddr,
+			pubKey:        upgrade.NodePubKey,
+			remoteReport:  upgrade.NodePubKeyRemoteReport,
+		}, expectedUniqueID, nil
+	}
+
+	if expectedUniqueID == "" {
+		expectedUniqueID, err = selfUniqueID(oracleSgx)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	registration, err := queryClient.GetOracleRegistration(ctx, expectedUniqueID, registrationAddr)
+	if err != nil {
+		return nil, "", err
+	}
+	return &reportTarget{
+		source:        reportSourceRegistration,
+		oracleAddress: registrationAddr,
+		pubKey:        registration.NodePubKey,
+		remoteReport:  registration.NodePubKeyRemoteReport,
+	}, expectedUniqueID, nil
+}
+
+func readOracleRemoteReport(filename string) (*reportTarget, error) {
 	file, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
@@ -60,32 +264,103 @@ func readOracleRemoteReport(filename string) (*OraclePubKeyInfo, error) {
 		return nil, err
 	}
 
-	return &pubKeyInfo, nil
-}
-
-func verifyPubKeyRemoteReport(pubKeyInfo OraclePubKeyInfo) error {
 	pubKey, err := base64.StdEncoding.DecodeString(pubKeyInfo.PublicKeyBase64)
 	if err != nil {
-		return fmt.Errorf("failed to decode oracle public key: %w", err)
+		return nil, fmt.Errorf("failed to decode oracle public key: %w", err)
 	}
-	pubKeyHash := sha256.Sum256(pubKey)
 
-	targetReport, err := base64.StdEncoding.DecodeString(pubKeyInfo.RemoteReportBase64)
+	remoteReport, err := base64.StdEncoding.DecodeString(pubKeyInfo.RemoteReportBase64)
 	if err != nil {
-		return fmt.Errorf("failed to decode oracle public key remote report: %w", err)
+		return nil, fmt.Errorf("failed to decode oracle public key remote report: %w", err)
 	}
 
-	sgx := sgx.NewOracleSGX()
+	return &reportTarget{
+		source:       reportSourceFile,
+		pubKey:       pubKey,
+		remoteReport: remoteReport,
+	}, nil
+}
 
-	selfEnclaveInfo, err := sgx.GenerateSelfEnclaveInfo()
+func selfUniqueID(oracleSgx sgx.Sgx) (string, error) {
+	selfEnclaveInfo, err := oracleSgx.GenerateSelfEnclaveInfo()
 	if err != nil {
-		return fmt.Errorf("failed to set self-enclave info: %w", err)
+		return "", fmt.Errorf("failed to set self-enclave info: %w", err)
 	}
+	return selfEnclaveInfo.UniqueIDHex(), nil
+}
 
-	// verify remote report
-	if err := sgx.VerifyRemoteReport(targetReport, pubKeyHash[:], selfEnclaveInfo.UniqueID); err != nil {
-		return fmt.Errorf("failed to verify report: %w", err)
+// verifyReportTarget verifies the remote report of the target, and returns the verdict with all the reasons of rejection.
+func verifyReportTarget(oracleSgx sgx.Sgx, policy sgx.AttestationPolicy, target reportTarget, expectedUniqueID string) ReportVerdict {
+	verdict := ReportVerdict{
+		Source:          target.source,
+		OracleAddress:   target.oracleAddress,
+		PublicKeyBase64: base64.StdEncoding.EncodeToString(target.pubKey),
+		Expected:        newReportExpectation(policy, expectedUniqueID),
+	}
+
+	report, err := oracleSgx.ParseRemoteReport(target.remoteReport)
+	if err != nil {
+		verdict.Reasons = []string{fmt.Sprintf("failed to verify report: %v", err)}
+		return verdict
+	}
+	verdict.Report = newReportContent(report)
+
+	if err := policy.Verify(report); err != nil {
+		var violation *sgx.PolicyViolationError
+		if errors.As(err, &violation) {
+			verdict.Reasons = append(verdict.Reasons, violation.Reasons...)
+		} else {
+			verdict.Reasons = append(verdict.Reasons, err.Error())
+		}
+	}
+
+	if uniqueID := hex.EncodeToString(report.UniqueID); uniqueID != expectedUniqueID {
+		verdict.Reasons = append(verdict.Reasons, fmt.Sprintf("unique ID is %s, but expected %s", uniqueID, expectedUniqueID))
+	}
+
+	pubKeyHash := sha256.Sum256(target.pubKey)
+	if len(report.Data) < len(pubKeyHash) || !bytes.Equal(report.Data[:len(pubKeyHash)], pubKeyHash[:]) {
+		verdict.Reasons = append(verdict.Reasons, "report data is not the hash of the public key")
+	}
+
+	verdict.Verified = len(verdict.Reasons) == 0
+	return verdict
+}
+
+func newReportExpectation(policy sgx.AttestationPolicy, expectedUniqueID string) ReportExpectation {
+	allowedTCBStatuses := make([]string, 0, len(policy.AllowedTCBStatuses))
+	for _, status := range policy.AllowedTCBStatuses {
+		allowedTCBStatuses = append(allowedTCBStatuses, status.String())
+	}
+
+	return ReportExpectation{
+		UniqueID:           expectedUniqueID,
+		SignerID:           hex.EncodeToString(policy.SignerID),
+		ProductID:          policy.ProductID,
+		MinSecurityVersion: policy.MinSecurityVersion,
+		AllowDebug:         policy.AllowDebug,
+		AllowedTCBStatuses: allowedTCBStatuses,
+	}
+}
+
+func newReportContent(report attestation.Report) *ReportContent {
+	return &ReportContent{
+		Data:            hex.EncodeToString(report.Data),
+		SecurityVersion: report.SecurityVersion,
+		Debug:           report.Debug,
+		UniqueID:        hex.EncodeToString(report.UniqueID),
+		SignerID:        hex.EncodeToString(report.SignerID),
+		ProductID:       sgx.ReportProductID(report),
+		TCBStatus:       report.TCBStatus.String(),
+	}
+}
+
+func printReportVerdict(w io.Writer, verdict ReportVerdict) error {
+	bz, err := json.MarshalIndent(verdict, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal verdict: %w", err)
 	}
 
-	return nil
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"time"
 
@@ -18,32 +20,59 @@ type Config struct {
 	API APIConfig `mapstructure:"api"`
 
 	Consumer ConsumerConfig `mapstructure:"consumer"`
+
+	Threshold ThresholdConfig `mapstructure:"threshold"`
+
+	Sealing SealingConfig `mapstructure:"sealing"`
+
+	Attestation AttestationConfig `mapstructure:"attestation"`
+
+	RATLS RATLSConfig `mapstructure:"ra-tls"`
+
+	Event EventConfig `mapstructure:"event"`
+
+	Approval ApprovalConfig `mapstructure:"approval"`
+
+	QueryCache QueryCacheConfig `mapstructure:"query-cache"`
 }
 
 type BaseConfig struct {
 	homeDir string // not read from toml file
 
-	LogLevel       string `mapstructure:"log-level"`
-	OracleMnemonic string `mapstructure:"oracle-mnemonic"`
-	OracleAccNum   uint32 `mapstructure:"oracle-acc-num"`
-	OracleAccIndex uint32 `mapstructure:"oracle-acc-index"`
-	Subscriber     string `mapstructure:"subscriber"`
-	DataDir        string `mapstructure:"data-dir"`
+	LogLevel               string `mapstructure:"log-level"`
+	OracleMnemonic         string `mapstructure:"oracle-mnemonic"`
+	OracleMnemonicFile     string `mapstructure:"oracle-mnemonic-file"`
+	AllowPlaintextMnemonic bool   `mapstructure:"allow-plaintext-mnemonic"`
+	AllowLegacyCiphertext  bool   `mapstructure:"allow-legacy-ciphertext"`
+	OracleAccNum           uint32 `mapstructure:"oracle-acc-num"`
+	OracleAccIndex         uint32 `mapstructure:"oracle-acc-index"`
+	Subscriber             string `mapstructure:"subscriber"`
+	DataDir                string `mapstructure:"data-dir"`
 
 	OraclePrivKeyFile string `mapstructure:"oracle-priv-key-file"`
 	OraclePubKeyFile  string `mapstructure:"oracle-pub-key-file"`
 	NodePrivKeyFile   string `mapstructure:"node-priv-key-file"`
+
+	SgxSimulation        bool   `mapstructure:"sgx-simulation"`
+	SgxSimulationKeyFile string `mapstructure:"sgx-simulation-key-file"`
 }
 
 type PanaceaConfig struct {
-	GRPCAddr                string   `mapstructure:"grpc-addr"`
-	RPCAddr                 string   `mapstructure:"rpc-addr"`
-	ChainID                 string   `mapstructure:"chain-id"`
-	DefaultGasLimit         uint64   `mapstructure:"default-gas-limit"`
-	DefaultFeeAmount        string   `mapstructure:"default-fee-amount"`
-	LightClientPrimaryAddr  string   `mapstructure:"light-client-primary-addr"`
-	LightClientWitnessAddrs []string `mapstructure:"light-client-witness-addrs"`
-	LightClientLogLevel     string   `mapstructure:"light-client-log-level"`
+	GRPCAddr                string        `mapstructure:"grpc-addr"`
+	RPCAddr                 string        `mapstructure:"rpc-addr"`
+	ChainID                 string        `mapstructure:"chain-id"`
+	DefaultGasLimit         uint64        `mapstructure:"default-gas-limit"`
+	DefaultFeeAmount        string        `mapstructure:"default-fee-amount"`
+	GasMultiplier           float64       `mapstructure:"gas-multiplier"`
+	MinGasPrices            string        `mapstructure:"min-gas-prices"`
+	FeeDenom                string        `mapstructure:"fee-denom"`
+	MaxFeeAmount            string        `mapstructure:"max-fee-amount"`
+	TxConfirmTimeout        time.Duration `mapstructure:"tx-confirm-timeout"`
+	AuthzGranter            string        `mapstructure:"authz-granter"`
+	FeeGranter              string        `mapstructure:"fee-granter"`
+	LightClientPrimaryAddr  string        `mapstructure:"light-client-primary-addr"`
+	LightClientWitnessAddrs []string      `mapstructure:"light-client-witness-addrs"`
+	LightClientLogLevel     string        `mapstructure:"light-client-log-level"`
 }
 
 type APIConfig struct {
@@ -75,26 +104,105 @@ type ConsumerConfig struct {
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
+type ThresholdConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	Threshold   int  `mapstructure:"threshold"`
+	TotalShares int  `mapstructure:"total-shares"`
+}
+
+// SealingConfig has a seal policy ("unique-key" or "product-key") for each kind of sealed data.
+type SealingConfig struct {
+	OraclePrivKey     string `mapstructure:"oracle-priv-key"`
+	NodePrivKey       string `mapstructure:"node-priv-key"`
+	OracleMnemonic    string `mapstructure:"oracle-mnemonic"`
+	LightClientDB     string `mapstructure:"light-client-db"`
+	EventCheckpointDB string `mapstructure:"event-checkpoint-db"`
+	EventDeadLetterDB string `mapstructure:"event-dead-letter-db"`
+	ApprovalDB        string `mapstructure:"approval-db"`
+}
+
+// AttestationConfig is a policy that remote reports of other oracles should satisfy.
+type AttestationConfig struct {
+	SignerID           string   `mapstructure:"signer-id"`
+	ProductID          uint16   `mapstructure:"product-id"`
+	MinSecurityVersion uint     `mapstructure:"min-security-version"`
+	AllowDebug         bool     `mapstructure:"allow-debug"`
+	AllowedTCBStatuses []string `mapstructure:"allowed-tcb-statuses"`
+}
+
+type RATLSConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Validity time.Duration `mapstructure:"validity"`
+}
+
+// EventConfig is for the workers which handle the events from Panacea.
+type EventConfig struct {
+	Workers        int           `mapstructure:"workers"`
+	QueueSize      int           `mapstructure:"queue-size"`
+	HandlerTimeout time.Duration `mapstructure:"handler-timeout"`
+	MaxRetries     int           `mapstructure:"max-retries"`
+	RetryInterval  time.Duration `mapstructure:"retry-interval"`
+
+	ReconcileInterval time.Duration `mapstructure:"reconcile-interval"`
+
+	PollInterval time.Duration `mapstructure:"poll-interval"`
+}
+
+// ApprovalConfig is a policy that oracle registrations and upgrades should satisfy to be approved by this oracle.
+type ApprovalConfig struct {
+	Mode          string        `mapstructure:"mode"`
+	Allowlist     []string      `mapstructure:"allowlist"`
+	Denylist      []string      `mapstructure:"denylist"`
+	MinStake      string        `mapstructure:"min-stake"`
+	MinAccountAge time.Duration `mapstructure:"min-account-age"`
+	MaxApprovals  int           `mapstructure:"max-approvals"`
+	Period        time.Duration `mapstructure:"period"`
+	FollowerDelay time.Duration `mapstructure:"follower-delay"`
+}
+
+// QueryCacheConfig limits the cache of the verified query results for each kind of query.
+// A result older than the max age of its kind is queried again. If a max age is 0, the kind of query is not cached.
+type QueryCacheConfig struct {
+	MaxEntries    int           `mapstructure:"max-entries"`
+	AccountMaxAge time.Duration `mapstructure:"account-max-age"`
+	DealMaxAge    time.Duration `mapstructure:"deal-max-age"`
+	OracleMaxAge  time.Duration `mapstructure:"oracle-max-age"`
+	ParamsMaxAge  time.Duration `mapstructure:"params-max-age"`
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
-			LogLevel:       "info",
-			OracleMnemonic: "",
-			OracleAccNum:   0,
-			OracleAccIndex: 0,
-			DataDir:        "data",
+			LogLevel:               "info",
+			OracleMnemonic:         "",
+			OracleMnemonicFile:     "oracle_mnemonic.sealed",
+			AllowPlaintextMnemonic: false,
+			AllowLegacyCiphertext:  true,
+			OracleAccNum:           0,
+			OracleAccIndex:         0,
+			Subscriber:             "websocket",
+			DataDir:                "data",
 
 			OraclePrivKeyFile: "oracle_priv_key.sealed",
 			OraclePubKeyFile:  "oracle_pub_key.json",
 			NodePrivKeyFile:   "node_priv_key.sealed",
+
+			SgxSimulation:        false,
+			SgxSimulationKeyFile: "sgx_simulation.key",
 		},
 		Panacea: PanaceaConfig{
-			GRPCAddr: "tcp://127.0.0.1:9090",
-			RPCAddr:  "tcp://127.0.0.1:26657",
-			ChainID:  "",
-			// TODO: calculate fee instead of using default fee
+			GRPCAddr:                "tcp://127.0.0.1:9090",
+			RPCAddr:                 "tcp://127.0.0.1:26657",
+			ChainID:                 "",
 			DefaultGasLimit:         400000,
 			DefaultFeeAmount:        "2000000umed",
+			GasMultiplier:           1.3,
+			MinGasPrices:            "",
+			FeeDenom:                "",
+			MaxFeeAmount:            "20000000umed",
+			TxConfirmTimeout:        time.Minute,
+			AuthzGranter:            "",
+			FeeGranter:              "",
 			LightClientPrimaryAddr:  "tcp://127.0.0.1:26657",
 			LightClientWitnessAddrs: []string{"tcp://127.0.0.1:26657"},
 			LightClientLogLevel:     "error",
@@ -125,6 +233,59 @@ func DefaultConfig() *Config {
 		Consumer: ConsumerConfig{
 			Timeout: time.Second * 5,
 		},
+		Threshold: ThresholdConfig{
+			Enabled:     false,
+			Threshold:   2,
+			TotalShares: 3,
+		},
+		Sealing: SealingConfig{
+			OraclePrivKey:     "unique-key",
+			NodePrivKey:       "unique-key",
+			OracleMnemonic:    "product-key",
+			LightClientDB:     "product-key",
+			EventCheckpointDB: "product-key",
+			EventDeadLetterDB: "product-key",
+			ApprovalDB:        "product-key",
+		},
+		Attestation: AttestationConfig{
+			SignerID:           "",
+			ProductID:          0,
+			MinSecurityVersion: 1,
+			AllowDebug:         false,
+			AllowedTCBStatuses: []string{"UpToDate"},
+		},
+		RATLS: RATLSConfig{
+			Enabled:  false,
+			Validity: time.Hour * 6,
+		},
+		Event: EventConfig{
+			Workers:        4,
+			QueueSize:      400,
+			HandlerTimeout: time.Minute * 2,
+			MaxRetries:     5,
+			RetryInterval:  time.Minute,
+
+			ReconcileInterval: time.Minute * 10,
+
+			PollInterval: time.Second * 5,
+		},
+		Approval: ApprovalConfig{
+			Mode:          "auto",
+			Allowlist:     []string{},
+			Denylist:      []string{},
+			MinStake:      "",
+			MinAccountAge: 0,
+			MaxApprovals:  0,
+			Period:        time.Hour * 24,
+			FollowerDelay: time.Second * 10,
+		},
+		QueryCache: QueryCacheConfig{
+			MaxEntries:    1000,
+			AccountMaxAge: time.Second * 30,
+			DealMaxAge:    time.Second * 30,
+			OracleMaxAge:  time.Minute,
+			ParamsMaxAge:  time.Minute * 5,
+		},
 	}
 }
 
@@ -133,13 +294,130 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	if c.Panacea.GasMultiplier != 0 && c.Panacea.GasMultiplier < 1 {
+		return errors.New("gas-multiplier should be 0 or at least 1")
+	}
+	if c.Panacea.MinGasPrices != "" {
+		if _, err := sdk.ParseDecCoins(c.Panacea.MinGasPrices); err != nil {
+			return fmt.Errorf("invalid min-gas-prices: %w", err)
+		}
+	}
+	if c.Panacea.FeeDenom != "" {
+		if err := sdk.ValidateDenom(c.Panacea.FeeDenom); err != nil {
+			return fmt.Errorf("invalid fee-denom: %w", err)
+		}
+	}
+	if c.Panacea.MaxFeeAmount != "" {
+		if _, err := sdk.ParseCoinsNormalized(c.Panacea.MaxFeeAmount); err != nil {
+			return fmt.Errorf("invalid max-fee-amount: %w", err)
+		}
+	}
+
+	if c.Panacea.TxConfirmTimeout <= 0 {
+		return errors.New("tx-confirm-timeout should be positive")
+	}
+
 	if c.Panacea.ChainID == "" {
 		return errors.New("chain id should not be empty")
 	}
 
+	if c.OracleMnemonicFile == "" {
+		return errors.New("oracle-mnemonic-file should not be empty")
+	}
+
+	if c.Threshold.Enabled {
+		if c.Threshold.Threshold < 2 {
+			return errors.New("threshold should be at least 2")
+		}
+		if c.Threshold.TotalShares < c.Threshold.Threshold || c.Threshold.TotalShares > 255 {
+			return errors.New("total-shares should be between threshold and 255")
+		}
+	}
+
+	if c.RATLS.Enabled && (c.RATLS.Validity < 2*time.Minute || c.RATLS.Validity > 24*time.Hour) {
+		return errors.New("ra-tls validity should be between 2m and 24h")
+	}
+
+	for _, policy := range []string{c.Sealing.OraclePrivKey, c.Sealing.NodePrivKey, c.Sealing.OracleMnemonic, c.Sealing.LightClientDB, c.Sealing.EventCheckpointDB, c.Sealing.EventDeadLetterDB, c.Sealing.ApprovalDB} {
+		if policy != "unique-key" && policy != "product-key" {
+			return fmt.Errorf("invalid seal policy: %s. please put \"unique-key\" or \"product-key\"", policy)
+		}
+	}
+
+	if signerID, err := hex.DecodeString(c.Attestation.SignerID); err != nil || (len(signerID) != 0 && len(signerID) != 32) {
+		return fmt.Errorf("signer-id should be an empty string or a hex-encoded 32-byte ID: %s", c.Attestation.SignerID)
+	}
+
+	if len(c.Attestation.AllowedTCBStatuses) == 0 {
+		return errors.New("allowed-tcb-statuses should not be empty")
+	}
+
+	// the subscriber is empty in the config files written before it was used.
+	if c.Subscriber != "" && c.Subscriber != "websocket" && c.Subscriber != "polling" {
+		return fmt.Errorf("invalid subscriber: %s. please put \"websocket\" or \"polling\"", c.Subscriber)
+	}
+	if c.Subscriber == "polling" && c.Event.PollInterval <= 0 {
+		return errors.New("event poll-interval should be positive")
+	}
+
+	if c.Event.Workers <= 0 {
+		return errors.New("event workers should be positive")
+	}
+	if c.Event.QueueSize < c.Event.Workers {
+		return errors.New("event queue-size should not be less than workers")
+	}
+	if c.Event.HandlerTimeout <= 0 {
+		return errors.New("event handler-timeout should be positive")
+	}
+	if c.Event.MaxRetries < 0 {
+		return errors.New("event max-retries should not be negative")
+	}
+	if c.Event.RetryInterval <= 0 {
+		return errors.New("event retry-interval should be positive")
+	}
+	if c.Event.ReconcileInterval <= 0 {
+		return errors.New("event reconcile-interval should be positive")
+	}
+
+	if c.Approval.Mode != "auto" && c.Approval.Mode != "manual" {
+		return fmt.Errorf("invalid approval mode: %s. please put \"auto\" or \"manual\"", c.Approval.Mode)
+	}
+	if c.Approval.MinStake != "" {
+		if _, err := sdk.ParseCoinNormalized(c.Approval.MinStake); err != nil {
+			return fmt.Errorf("invalid approval min-stake: %w", err)
+		}
+	}
+	if c.Approval.MinAccountAge < 0 {
+		return errors.New("approval min-account-age should not be negative")
+	}
+	if c.Approval.MaxApprovals < 0 {
+		return errors.New("approval max-approvals should not be negative")
+	}
+	if c.Approval.FollowerDelay < 0 {
+		return errors.New("approval follower-delay should not be negative")
+	}
+	if c.Approval.MaxApprovals > 0 && c.Approval.Period <= 0 {
+		return errors.New("approval period should be positive")
+	}
+
+	if c.QueryCache.MaxEntries < 0 {
+		return errors.New("query-cache max-entries should not be negative")
+	}
+	for _, maxAge := range []time.Duration{c.QueryCache.AccountMaxAge, c.QueryCache.DealMaxAge, c.QueryCache.OracleMaxAge, c.QueryCache.ParamsMaxAge} {
+		if maxAge < 0 {
+			return errors.New("query-cache max ages should not be negative")
+		}
+	}
+
 	return nil
 }
 
+// MaxFollowerWait returns the max time that a follower waits for its turn to approve a request.
+// It is half of the handler timeout, so that the follower has time to approve the request after waiting.
+func (c *Config) MaxFollowerWait() time.Duration {
+	return c.Event.HandlerTimeout / 2
+}
+
 func (c *Config) SetHomeDir(dir string) {
 	c.homeDir = dir
 }
@@ -160,6 +438,14 @@ func (c *Config) AbsNodePrivKeyPath() string {
 	return rootify(c.NodePrivKeyFile, c.homeDir)
 }
 
+func (c *Config) AbsOracleMnemonicPath() string {
+	return rootify(c.OracleMnemonicFile, c.homeDir)
+}
+
+func (c *Config) AbsSgxSimulationKeyPath() string {
+	return rootify(c.SgxSimulationKeyFile, c.homeDir)
+}
+
 func rootify(path, root string) string {
 	if filepath.IsAbs(path) {
 		return path
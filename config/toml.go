@@ -20,15 +20,37 @@ const DefaultConfigTemplate = `# This is a TOML config file.
 ###############################################################################
 
 log-level = "{{ .BaseConfig.LogLevel }}"
+# The mnemonic of the oracle account is sealed in the oracle-mnemonic-file by 'oracled keys import'.
+# The plain oracle-mnemonic is refused unless allow-plaintext-mnemonic is true, because it is not protected by the enclave.
 oracle-mnemonic = "{{ .BaseConfig.OracleMnemonic }}"
+oracle-mnemonic-file = "{{ .BaseConfig.OracleMnemonicFile }}"
+allow-plaintext-mnemonic = "{{ .BaseConfig.AllowPlaintextMnemonic }}"
 oracle-acc-num = "{{ .BaseConfig.OracleAccNum }}"
 oracle-acc-index = "{{ .BaseConfig.OracleAccIndex }}"
+
+# Source of the events from Panacea: "websocket" or "polling".
+# "websocket" subscribes the events via the websocket of the Panacea RPC.
+# "polling" polls new blocks via the Panacea RPC at the event poll-interval, for the networks which block long-lived websocket connections.
+subscriber = "{{ .BaseConfig.Subscriber }}"
+
 data-dir = "{{ .BaseConfig.DataDir }}"
 
+# Accept the legacy ciphertexts without an envelope header, which are bound to neither a deal, data nor purpose.
+# It is deprecated and only kept for the clients which are not upgraded yet. Every legacy ciphertext accepted is logged.
+# It will be false by default in the next release, and the legacy ciphertexts will be refused in the release after that.
+allow-legacy-ciphertext = "{{ .BaseConfig.AllowLegacyCiphertext }}"
+
 oracle-priv-key-file = "{{ .BaseConfig.OraclePrivKeyFile }}"
 oracle-pub-key-file = "{{ .BaseConfig.OraclePubKeyFile }}"
 node-priv-key-file = "{{ .BaseConfig.NodePrivKeyFile }}"
 
+# Use the software SGX simulation instead of SGX hardware. It provides NO security guarantee.
+# It is only for development and CI, and cannot be used with a mainnet chain ID.
+sgx-simulation = "{{ .BaseConfig.SgxSimulation }}"
+
+# A file of the key used to seal data and sign reports in the SGX simulation
+sgx-simulation-key-file = "{{ .BaseConfig.SgxSimulationKeyFile }}"
+
 ###############################################################################
 ###                         Panacea Configuration                           ###
 ###############################################################################
@@ -38,9 +60,34 @@ node-priv-key-file = "{{ .BaseConfig.NodePrivKeyFile }}"
 chain-id = "{{ .Panacea.ChainID }}"
 grpc-addr = "{{ .Panacea.GRPCAddr }}"
 rpc-addr = "{{ .Panacea.RPCAddr }}"
+
+# The gas limit of a tx is estimated by simulation and multiplied by gas-multiplier.
+# If gas-multiplier is 0, txs are not simulated and default-gas-limit is used.
 default-gas-limit = "{{ .Panacea.DefaultGasLimit }}"
+gas-multiplier = "{{ .Panacea.GasMultiplier }}"
+
+# The fee of a tx is the gas limit multiplied by min-gas-prices (e.g. "5umed").
+# If min-gas-prices is empty, the minimum gas prices of the node at grpc-addr are used.
+# If no gas price is found, default-fee-amount is used.
+# The fee is paid in fee-denom, or in the first denom of the gas prices if fee-denom is empty.
+min-gas-prices = "{{ .Panacea.MinGasPrices }}"
+fee-denom = "{{ .Panacea.FeeDenom }}"
 default-fee-amount = "{{ .Panacea.DefaultFeeAmount }}"
 
+# A tx whose fee exceeds max-fee-amount is not broadcast. Leave it empty for no limit.
+max-fee-amount = "{{ .Panacea.MaxFeeAmount }}"
+
+# A broadcast tx which is not included in a block in this time is regarded as failed
+tx-confirm-timeout = "{{ .Panacea.TxConfirmTimeout }}"
+
+# If authz-granter is set, the account of oracle-mnemonic is used as a low-privilege key, and the granter is the oracle in Panacea.
+# The txs of the oracle are signed by the key, and executed on behalf of the granter by MsgExec.
+# The granter should grant the authorizations of the oracle msgs to the key by authz.
+authz-granter = "{{ .Panacea.AuthzGranter }}"
+
+# If fee-granter is set, the fees of the txs are paid by the fee-granter, which should grant a fee allowance to the key by feegrant.
+fee-granter = "{{ .Panacea.FeeGranter }}"
+
 # A primary RPC address for light client verification
 
 light-client-primary-addr = "{{ .Panacea.LightClientPrimaryAddr }}"
@@ -131,6 +178,170 @@ max-request-body-size = "{{ .API.MaxRequestBodySize }}"
 
 # Maximum duration to transfer files to a consumer service
 timeout = "{{ .Consumer.Timeout }}"
+
+###############################################################################
+###                         Threshold Configuration                         ###
+###############################################################################
+
+[threshold]
+
+# If enabled, each data is encrypted with a random secret key instead of a key derived from the oracle private key.
+# The secret key is split into shares for the oracles with the same unique ID, each encrypted to the node key of its oracle,
+# and the shares are stored in front of the data. An oracle releases only its own share,
+# so a consumer has to combine shares from at least 'threshold' oracles to recover the secret key.
+enabled = "{{ .Threshold.Enabled }}"
+
+# Minimum number of shares required to recover a secret key
+threshold = "{{ .Threshold.Threshold }}"
+
+# Maximum number of shares that a secret key is split into.
+# The shares are assigned to the oracles in the order of their addresses.
+total-shares = "{{ .Threshold.TotalShares }}"
+
+###############################################################################
+###                          Sealing Configuration                          ###
+###############################################################################
+
+[sealing]
+
+# Seal policy for each kind of sealed data: "unique-key" or "product-key".
+# Data sealed with "unique-key" can be unsealed only by the same enclave binary.
+# Data sealed with "product-key" can be unsealed by enclaves of the same signer and product ID
+# with an equal or higher security version, so it survives enclave upgrades.
+oracle-priv-key = "{{ .Sealing.OraclePrivKey }}"
+node-priv-key = "{{ .Sealing.NodePrivKey }}"
+oracle-mnemonic = "{{ .Sealing.OracleMnemonic }}"
+light-client-db = "{{ .Sealing.LightClientDB }}"
+event-checkpoint-db = "{{ .Sealing.EventCheckpointDB }}"
+event-dead-letter-db = "{{ .Sealing.EventDeadLetterDB }}"
+approval-db = "{{ .Sealing.ApprovalDB }}"
+
+###############################################################################
+###                        Attestation Configuration                        ###
+###############################################################################
+
+[attestation]
+
+# A policy that remote reports of other oracles should satisfy.
+# It is applied when approving oracle registrations and upgrades, and in the verify-report command.
+
+# Hex-encoded signer ID (MRSIGNER). If empty, any signer ID is allowed.
+signer-id = "{{ .Attestation.SignerID }}"
+
+# Product ID (ISVPRODID). If 0, any product ID is allowed.
+product-id = "{{ .Attestation.ProductID }}"
+
+# Minimum security version (ISVSVN)
+min-security-version = "{{ .Attestation.MinSecurityVersion }}"
+
+# Whether reports of debug enclaves are allowed. The memory of a debug enclave can be read by the host.
+# It is always allowed in the SGX simulation, whose reports are all of debug enclaves.
+allow-debug = "{{ .Attestation.AllowDebug }}"
+
+# Allowed TCB statuses (comma-separated): UpToDate, OutOfDate, Revoked, ConfigurationNeeded, OutOfDateConfigurationNeeded,
+# SWHardeningNeeded, ConfigurationAndSWHardeningNeeded, Unknown
+allowed-tcb-statuses = "{{ StringsJoin .Attestation.AllowedTCBStatuses "," }}"
+
+###############################################################################
+###                           RA-TLS Configuration                          ###
+###############################################################################
+
+[ra-tls]
+
+# If enabled, the gRPC and API servers serve TLS with a certificate generated in the enclave.
+# The certificate has a remote report over its public key, so clients can verify that they talk to the enclave.
+enabled = "{{ .RATLS.Enabled }}"
+
+# Validity period of the certificate. The certificate is replaced with a new one with a fresh remote report
+# at the half of its validity period, so clients don't rely on a stale report. It should be between 2m and 24h.
+validity = "{{ .RATLS.Validity }}"
+
+###############################################################################
+###                           Event Configuration                           ###
+###############################################################################
+
+[event]
+
+# Number of workers which handle events concurrently.
+# Events of the same kind are handled in order, or in order per target oracle for oracle registrations and upgrades.
+workers = "{{ .Event.Workers }}"
+
+# Maximum number of events waiting for the workers. The subscriber stops reading new events while the queue is full.
+queue-size = "{{ .Event.QueueSize }}"
+
+# An event handler is cancelled and regarded as failed after this time.
+# Events of the same key fail until the timed-out handler returns, and are retried from the dead-letter queue.
+handler-timeout = "{{ .Event.HandlerTimeout }}"
+
+# Events failed to be handled are kept in the dead-letter queue, and retried up to max-retries times.
+# The retry interval is doubled after each failure, up to 1 hour.
+# The events which are not retried anymore can be retried by 'oracled events retry' after fixing the cause.
+max-retries = "{{ .Event.MaxRetries }}"
+retry-interval = "{{ .Event.RetryInterval }}"
+
+# Interval to approve the oracle registrations and upgrades which are still not approved, in case that their events were missed.
+# They are also reconciled when the oracle starts.
+reconcile-interval = "{{ .Event.ReconcileInterval }}"
+
+# Interval to poll new blocks if the subscriber is "polling"
+poll-interval = "{{ .Event.PollInterval }}"
+
+###############################################################################
+###                          Approval Configuration                         ###
+###############################################################################
+
+[approval]
+
+# A policy that oracle registrations and upgrades should satisfy to be approved by this oracle,
+# in addition to the remote report and the attestation policy.
+
+# "auto" approves the requests satisfying the policy.
+# "manual" queues them until they are approved or rejected by 'oracled approvals approve|reject',
+# which can be run while the oracle is running.
+mode = "{{ .Approval.Mode }}"
+
+# Oracle addresses (comma-separated) which are always denied.
+# If the allowlist is not empty, only the oracle addresses in the allowlist are approved.
+allowlist = "{{ StringsJoin .Approval.Allowlist "," }}"
+denylist = "{{ StringsJoin .Approval.Denylist "," }}"
+
+# Minimum amount of tokens (e.g. 1000000umed) that the oracle account should delegate to the bonded validators.
+# It should be in the bond denom. If empty, it is not checked.
+min-stake = "{{ .Approval.MinStake }}"
+
+# Minimum time since the oracle account received tokens first via the bank module, or since the earliest block kept by the node
+# for a genesis account. If 0, it is not checked.
+min-account-age = "{{ .Approval.MinAccountAge }}"
+
+# Maximum number of automatic approvals in each period. If 0, it is not limited.
+# The requests over the limit are approved in later periods.
+max-approvals = "{{ .Approval.MaxApprovals }}"
+period = "{{ .Approval.Period }}"
+
+# All oracles with the same unique ID receive each request, but one of them is chosen as a leader by the request
+# and approves it right away. The others wait for follower-delay times their rank, and approve it only if it is still not approved.
+# The wait is capped at half of the event handler-timeout, so that the request is approved in time even if many oracles are offline.
+# If 0, all oracles approve the request right away.
+follower-delay = "{{ .Approval.FollowerDelay }}"
+
+###############################################################################
+###                          Query Cache Configuration                      ###
+###############################################################################
+
+[query-cache]
+
+# The results of the verified queries to Panacea are cached by their store keys and heights,
+# so that the data changed rarely are not queried with proofs for every request.
+# Maximum number of the cached results. If 0, nothing is cached.
+max-entries = "{{ .QueryCache.MaxEntries }}"
+
+# Maximum age of the cached results for each kind of query. If 0, the kind of query is not cached.
+# The cached deals and oracles are also dropped when the events which change them arrive,
+# and the cached params are dropped when a governance proposal is passed.
+account-max-age = "{{ .QueryCache.AccountMaxAge }}"
+deal-max-age = "{{ .QueryCache.DealMaxAge }}"
+oracle-max-age = "{{ .QueryCache.OracleMaxAge }}"
+params-max-age = "{{ .QueryCache.ParamsMaxAge }}"
 `
 
 var configTemplate *template.Template
@@ -1,8 +1,8 @@
 package consumer_service
 
 import (
-	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -13,8 +13,10 @@ import (
 	"github.com/medibloc/panacea-oracle/panacea"
 )
 
+// FileStorage stores the consumer data, which is a stream envelope read by crypto.StreamReader.
 type FileStorage interface {
-	Add(endpoint string, dealID uint64, dataHash string, data []byte) error
+	// Add posts the data read from the reader to the consumer service without buffering it as a whole.
+	Add(endpoint string, dealID uint64, dataHash string, data io.Reader) error
 }
 
 var _ FileStorage = &ConsumerServiceFileStorage{}
@@ -33,7 +35,7 @@ func NewConsumerService(oraclePrivKey *btcec.PrivateKey, oracleAcc *panacea.Orac
 	}
 }
 
-func (s *ConsumerServiceFileStorage) Add(endpoint string, dealID uint64, dataHash string, data []byte) error {
+func (s *ConsumerServiceFileStorage) Add(endpoint string, dealID uint64, dataHash string, data io.Reader) error {
 	// dataUrl is /v0/deals/{dealId}/data/{dataHash}
 	dataUrl := endpoint + "/v0/deals/" + strconv.FormatUint(dealID, 10) + "/data/" + dataHash
 	token, err := generateJWT(s.oraclePrivKey, s.oracleAcc, 10*time.Second)
@@ -47,10 +49,8 @@ func (s *ConsumerServiceFileStorage) Add(endpoint string, dealID uint64, dataHas
 	return nil
 }
 
-func (s *ConsumerServiceFileStorage) postData(data []byte, dataUrl string, jwt []byte) error {
-	buff := bytes.NewBuffer(data)
-
-	request, err := http.NewRequest("POST", dataUrl, buff)
+func (s *ConsumerServiceFileStorage) postData(data io.Reader, dataUrl string, jwt []byte) error {
+	request, err := http.NewRequest("POST", dataUrl, data)
 	if err != nil {
 		return err
 	}
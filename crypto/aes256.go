@@ -54,6 +54,9 @@ func Decrypt(secretKey []byte, additional []byte, ciphertext []byte) ([]byte, er
 	}
 
 	nonceSize := aesgcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
 	nonce, pureCiphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
 
 	// decrypt ciphertext with second key
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ECIES follows the default scheme of the widely used eciesjs and eciespy libraries,
+// so that a sender can encrypt data with an ephemeral key using those libraries.
+//
+//   - curve: secp256k1
+//   - KDF: HKDF-SHA256 over the uncompressed ephemeral public key and the uncompressed shared point
+//   - cipher: AES-256-GCM with a 16-byte nonce
+//   - payload: ephemeral public key(65) | nonce(16) | tag(16) | ciphertext
+//
+// In an envelope, the payload follows the header: magic(2) | version(1) | algorithm(1) | key epoch(4).
+
+const (
+	AlgorithmECIES byte = 3
+
+	eciesHeaderLen   = 2 + 1 + 1 + 4
+	eciesPubKeyLen   = 65
+	eciesNonceLen    = 16
+	eciesTagLen      = 16
+	eciesOverheadLen = eciesPubKeyLen + eciesNonceLen + eciesTagLen
+)
+
+// IsECIESEnvelope returns whether the ciphertext starts with a header of an ECIES envelope.
+func IsECIESEnvelope(ciphertext []byte) bool {
+	return len(ciphertext) >= eciesHeaderLen+eciesOverheadLen &&
+		ciphertext[0] == envelopeMagic[0] &&
+		ciphertext[1] == envelopeMagic[1] &&
+		ciphertext[2] == EnvelopeVersion1 &&
+		ciphertext[3] == AlgorithmECIES
+}
+
+// EncryptECIES encrypts data to the public key with an ephemeral key and returns it as an ECIES envelope.
+func EncryptECIES(pubKey *btcec.PublicKey, keyEpoch uint32, data []byte) ([]byte, error) {
+	header := EnvelopeHeader{
+		Version:   EnvelopeVersion1,
+		Algorithm: AlgorithmECIES,
+		KeyEpoch:  keyEpoch,
+	}
+
+	payload, err := EncryptECIESPayload(pubKey, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header.Bytes(), payload...), nil
+}
+
+// DecryptECIES decrypts the ECIES envelope with the private key.
+func DecryptECIES(privKey *btcec.PrivateKey, ciphertext []byte) ([]byte, error) {
+	if !IsECIESEnvelope(ciphertext) {
+		return nil, fmt.Errorf("not an ECIES envelope")
+	}
+
+	return DecryptECIESPayload(privKey, ciphertext[eciesHeaderLen:])
+}
+
+// EncryptECIESPayload encrypts data to the public key and returns a payload without the envelope header.
+func EncryptECIESPayload(pubKey *btcec.PublicKey, data []byte) ([]byte, error) {
+	ephemeralKey, err := NewPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	ephemeralPubKeyBz := ephemeralKey.PubKey().SerializeUncompressed()
+
+	aesGCM, err := newECIESCipher(ephemeralKey, pubKey, ephemeralPubKeyBz)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, eciesNonceLen)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := aesGCM.Seal(nil, nonce, data, nil)
+	ciphertext, tag := sealed[:len(data)], sealed[len(data):]
+
+	payload := make([]byte, 0, eciesOverheadLen+len(data))
+	payload = append(payload, ephemeralPubKeyBz...)
+	payload = append(payload, nonce...)
+	payload = append(payload, tag...)
+	return append(payload, ciphertext...), nil
+}
+
+// DecryptECIESPayload decrypts a payload without the envelope header.
+func DecryptECIESPayload(privKey *btcec.PrivateKey, payload []byte) ([]byte, error) {
+	if len(payload) < eciesOverheadLen {
+		return nil, fmt.Errorf("ECIES payload is too short")
+	}
+
+	ephemeralPubKeyBz := payload[:eciesPubKeyLen]
+	nonce := payload[eciesPubKeyLen : eciesPubKeyLen+eciesNonceLen]
+	tag := payload[eciesPubKeyLen+eciesNonceLen : eciesOverheadLen]
+	ciphertext := payload[eciesOverheadLen:]
+
+	ephemeralPubKey, err := btcec.ParsePubKey(ephemeralPubKeyBz, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ephemeral public key: %w", err)
+	}
+
+	aesGCM, err := newECIESCipher(privKey, ephemeralPubKey, ephemeralPubKeyBz)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, 0, len(ciphertext)+eciesTagLen)
+	sealed = append(sealed, ciphertext...)
+	sealed = append(sealed, tag...)
+
+	return aesGCM.Open(nil, nonce, sealed, nil)
+}
+
+func newECIESCipher(privKey *btcec.PrivateKey, pubKey *btcec.PublicKey, ephemeralPubKeyBz []byte) (cipher.AEAD, error) {
+	x, y := btcec.S256().ScalarMult(pubKey.X, pubKey.Y, privKey.D.Bytes())
+	sharedPoint := btcec.PublicKey{Curve: btcec.S256(), X: x, Y: y}
+
+	master := append(append([]byte{}, ephemeralPubKeyBz...), sharedPoint.SerializeUncompressed()...)
+	secretKey := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, master, nil, nil), secretKey); err != nil {
+		return nil, fmt.Errorf("failed to derive ECIES key: %w", err)
+	}
+
+	block, err := aes.NewCipher(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCMWithNonceSize(block, eciesNonceLen)
+}
@@ -0,0 +1,47 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncryptDecryptECIES tests that an ECIES envelope is decrypted only with the recipient private key.
+func TestEncryptDecryptECIES(t *testing.T) {
+	privKey, err := crypto.NewPrivKey()
+	require.NoError(t, err)
+
+	data := []byte("This is temporary data")
+
+	envelope, err := crypto.EncryptECIES(privKey.PubKey(), crypto.DefaultKeyEpoch, data)
+	require.NoError(t, err)
+	require.True(t, crypto.IsECIESEnvelope(envelope))
+	require.False(t, crypto.IsStreamEnvelope(envelope))
+
+	require.Equal(t, crypto.AlgorithmECIES, envelope[3])
+
+	decrypted, err := crypto.DecryptECIES(privKey, envelope)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// each encryption uses a new ephemeral key
+	otherEnvelope, err := crypto.EncryptECIES(privKey.PubKey(), crypto.DefaultKeyEpoch, data)
+	require.NoError(t, err)
+	require.NotEqual(t, envelope, otherEnvelope)
+
+	otherPrivKey, err := crypto.NewPrivKey()
+	require.NoError(t, err)
+	_, err = crypto.DecryptECIES(otherPrivKey, envelope)
+	require.Error(t, err)
+
+	tampered := append([]byte{}, envelope...)
+	tampered[len(tampered)-1] ^= 0x01
+	_, err = crypto.DecryptECIES(privKey, tampered)
+	require.Error(t, err)
+
+	// a legacy ciphertext is not an ECIES envelope
+	legacy, err := crypto.Encrypt(crypto.KDFSHA256([]byte("secret")), nil, data)
+	require.NoError(t, err)
+	require.False(t, crypto.IsECIESEnvelope(legacy))
+}
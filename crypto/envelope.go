@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// An envelope is a versioned ciphertext format:
+//
+//	magic(2) | version(1) | algorithm(1) | key epoch(4) | nonce(12) | ciphertext with tag
+//
+// The header is authenticated together with the AdditionalData,
+// so that a ciphertext can be neither modified nor moved to another deal, data or purpose.
+// A ciphertext without the header is a legacy one which is a bare nonce and ciphertext.
+// It is accepted only by DecryptEnvelopeOrLegacy while the legacy ciphertexts are allowed.
+
+const (
+	EnvelopeVersion1 byte = 1
+
+	AlgorithmAES256GCM byte = 1
+
+	// DefaultKeyEpoch is the epoch of a key which has never been rotated.
+	DefaultKeyEpoch uint32 = 0
+
+	envelopeHeaderLen = 2 + 1 + 1 + 4 + gcmNonceSize
+	gcmNonceSize      = 12
+)
+
+var envelopeMagic = []byte{0x50, 0x4f} // "PO"
+
+const (
+	PurposeProviderData   = "provider-data"
+	PurposeConsumerData   = "consumer-data"
+	PurposeSecretKey      = "secret-key"
+	PurposeSecretKeyShare = "secret-key-share"
+	PurposeOraclePrivKey  = "oracle-priv-key"
+)
+
+// AdditionalData is the context which a ciphertext is bound to.
+type AdditionalData struct {
+	DealID   uint64
+	DataHash string
+	Purpose  string
+}
+
+// Bytes returns an unambiguous encoding of the AdditionalData.
+func (a AdditionalData) Bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("panacea-oracle/aad/v1")
+	_ = binary.Write(&buf, binary.BigEndian, a.DealID)
+	writeLengthPrefixed(&buf, []byte(a.DataHash))
+	writeLengthPrefixed(&buf, []byte(a.Purpose))
+	return buf.Bytes()
+}
+
+func writeLengthPrefixed(buf *bytes.Buffer, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+type EnvelopeHeader struct {
+	Version   byte
+	Algorithm byte
+	KeyEpoch  uint32
+	Nonce     []byte
+}
+
+func (h EnvelopeHeader) Bytes() []byte {
+	bz := make([]byte, 0, envelopeHeaderLen)
+	bz = append(bz, envelopeMagic...)
+	bz = append(bz, h.Version, h.Algorithm)
+	bz = binary.BigEndian.AppendUint32(bz, h.KeyEpoch)
+	return append(bz, h.Nonce...)
+}
+
+// ParseEnvelopeHeader parses the header of the envelope.
+// It returns an error if the ciphertext is not an envelope of a known version and algorithm.
+func ParseEnvelopeHeader(ciphertext []byte) (*EnvelopeHeader, error) {
+	if len(ciphertext) < envelopeHeaderLen || !bytes.Equal(ciphertext[:2], envelopeMagic) {
+		return nil, fmt.Errorf("not an envelope")
+	}
+
+	header := &EnvelopeHeader{
+		Version:   ciphertext[2],
+		Algorithm: ciphertext[3],
+		KeyEpoch:  binary.BigEndian.Uint32(ciphertext[4:8]),
+		Nonce:     ciphertext[8:envelopeHeaderLen],
+	}
+
+	if header.Version != EnvelopeVersion1 {
+		return nil, fmt.Errorf("unsupported envelope version: %d", header.Version)
+	}
+	if header.Algorithm != AlgorithmAES256GCM {
+		return nil, fmt.Errorf("unsupported envelope algorithm: %d", header.Algorithm)
+	}
+
+	return header, nil
+}
+
+// EncryptEnvelope encrypts data with AES256-GCM and returns it as an envelope bound to the additional data.
+func EncryptEnvelope(secretKey []byte, keyEpoch uint32, additional AdditionalData, data []byte) ([]byte, error) {
+	aesGCM, err := newAES256GCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	header := EnvelopeHeader{
+		Version:   EnvelopeVersion1,
+		Algorithm: AlgorithmAES256GCM,
+		KeyEpoch:  keyEpoch,
+		Nonce:     nonce,
+	}
+	headerBz := header.Bytes()
+
+	return aesGCM.Seal(headerBz, nonce, data, append(headerBz, additional.Bytes()...)), nil
+}
+
+// DecryptEnvelope decrypts the envelope bound to the additional data.
+// A stream envelope is decrypted as a whole.
+func DecryptEnvelope(secretKey []byte, additional AdditionalData, ciphertext []byte) ([]byte, error) {
+	if IsStreamEnvelope(ciphertext) {
+		return DecryptStream(secretKey, additional, ciphertext)
+	}
+
+	header, err := ParseEnvelopeHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	aesGCM, err := newAES256GCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aesGCM.Open(nil, header.Nonce, ciphertext[envelopeHeaderLen:], append(header.Bytes(), additional.Bytes()...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open envelope: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// DecryptEnvelopeOrLegacy decrypts the envelope, or a legacy ciphertext which has no header and was encrypted without additional data
+// if allowLegacy is true. It also returns whether the ciphertext is decrypted as a legacy one, so that the caller can report it.
+// Legacy ciphertexts are deprecated, since they are bound to neither a deal, data nor purpose.
+func DecryptEnvelopeOrLegacy(secretKey []byte, additional AdditionalData, ciphertext []byte, allowLegacy bool) ([]byte, bool, error) {
+	plaintext, err := DecryptEnvelope(secretKey, additional, ciphertext)
+	if err == nil || !allowLegacy {
+		return plaintext, false, err
+	}
+
+	// A legacy ciphertext may start with the magic bytes by chance.
+	legacyPlaintext, legacyErr := Decrypt(secretKey, nil, ciphertext)
+	if legacyErr != nil {
+		return nil, false, err
+	}
+	return legacyPlaintext, true, nil
+}
+
+func newAES256GCM(secretKey []byte) (cipher.AEAD, error) {
+	if len(secretKey) != 32 {
+		return nil, fmt.Errorf("secret key is not for AES-256: total %d bits", 8*len(secretKey))
+	}
+
+	block, err := aes.NewCipher(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
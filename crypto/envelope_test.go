@@ -0,0 +1,82 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncryptDecryptEnvelope tests that an envelope is decrypted only with the same additional data.
+func TestEncryptDecryptEnvelope(t *testing.T) {
+	secretKey := crypto.KDFSHA256([]byte("secret"))
+	data := []byte("This is temporary data")
+
+	additional := crypto.AdditionalData{
+		DealID:   1,
+		DataHash: "data-hash",
+		Purpose:  crypto.PurposeConsumerData,
+	}
+
+	envelope, err := crypto.EncryptEnvelope(secretKey, 3, additional, data)
+	require.NoError(t, err)
+
+	header, err := crypto.ParseEnvelopeHeader(envelope)
+	require.NoError(t, err)
+	require.Equal(t, crypto.EnvelopeVersion1, header.Version)
+	require.Equal(t, crypto.AlgorithmAES256GCM, header.Algorithm)
+	require.Equal(t, uint32(3), header.KeyEpoch)
+
+	decrypted, err := crypto.DecryptEnvelope(secretKey, additional, envelope)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	otherDeal := additional
+	otherDeal.DealID = 2
+	_, err = crypto.DecryptEnvelope(secretKey, otherDeal, envelope)
+	require.Error(t, err)
+
+	otherPurpose := additional
+	otherPurpose.Purpose = crypto.PurposeProviderData
+	_, err = crypto.DecryptEnvelope(secretKey, otherPurpose, envelope)
+	require.Error(t, err)
+
+	// the header is also authenticated
+	tampered := append([]byte{}, envelope...)
+	tampered[4] ^= 0x01
+	_, err = crypto.DecryptEnvelope(secretKey, additional, tampered)
+	require.Error(t, err)
+}
+
+// TestDecryptEnvelopeLegacy tests that a legacy ciphertext without a header can be decrypted only if it is allowed.
+func TestDecryptEnvelopeLegacy(t *testing.T) {
+	secretKey := crypto.KDFSHA256([]byte("secret"))
+	data := []byte("This is temporary data")
+	additional := crypto.AdditionalData{Purpose: crypto.PurposeProviderData}
+
+	legacy, err := crypto.Encrypt(secretKey, nil, data)
+	require.NoError(t, err)
+
+	_, err = crypto.ParseEnvelopeHeader(legacy[:4])
+	require.Error(t, err)
+
+	_, err = crypto.DecryptEnvelope(secretKey, additional, legacy)
+	require.Error(t, err)
+
+	_, isLegacy, err := crypto.DecryptEnvelopeOrLegacy(secretKey, additional, legacy, false)
+	require.Error(t, err)
+	require.False(t, isLegacy)
+
+	decrypted, isLegacy, err := crypto.DecryptEnvelopeOrLegacy(secretKey, additional, legacy, true)
+	require.NoError(t, err)
+	require.True(t, isLegacy)
+	require.Equal(t, data, decrypted)
+
+	// an envelope is not reported as a legacy one
+	envelope, err := crypto.EncryptEnvelope(secretKey, crypto.DefaultKeyEpoch, additional, data)
+	require.NoError(t, err)
+	decrypted, isLegacy, err = crypto.DecryptEnvelopeOrLegacy(secretKey, additional, envelope, true)
+	require.NoError(t, err)
+	require.False(t, isLegacy)
+	require.Equal(t, data, decrypted)
+}
@@ -0,0 +1,165 @@
+package crypto
+
+import (
+	"fmt"
+	"io"
+)
+
+// Shamir's secret sharing over GF(2^8).
+// Each byte of the secret is shared independently with its own polynomial of degree (threshold - 1).
+// A share is the evaluated bytes followed by a single byte of the x-coordinate.
+
+// SplitSecret splits the secret into `total` shares, any `threshold` of which can reconstruct the secret.
+// Polynomial coefficients are read from the random reader, so a deterministic reader produces deterministic shares.
+func SplitSecret(secret []byte, threshold, total int, random io.Reader) ([][]byte, error) {
+	if err := validateThreshold(threshold, total); err != nil {
+		return nil, err
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret is empty")
+	}
+
+	// coefficients[i] holds the non-constant coefficients of the polynomial for secret[i]
+	coefficients := make([][]byte, len(secret))
+	for i := range secret {
+		coefficients[i] = make([]byte, threshold-1)
+		if _, err := io.ReadFull(random, coefficients[i]); err != nil {
+			return nil, fmt.Errorf("failed to read polynomial coefficients: %w", err)
+		}
+	}
+
+	shares := make([][]byte, total)
+	for x := 1; x <= total; x++ {
+		share := make([]byte, len(secret)+1)
+		for i := range secret {
+			share[i] = evaluatePolynomial(secret[i], coefficients[i], byte(x))
+		}
+		share[len(secret)] = byte(x)
+		shares[x-1] = share
+	}
+
+	return shares, nil
+}
+
+// CombineShares reconstructs the secret from the shares.
+// All shares must be generated from the same secret, and at least `threshold` of them must be given.
+func CombineShares(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("at least 2 shares are required")
+	}
+
+	shareLen := len(shares[0])
+	if shareLen < 2 {
+		return nil, fmt.Errorf("share is too short")
+	}
+
+	xs := make([]byte, len(shares))
+	seen := make(map[byte]bool)
+	for i, share := range shares {
+		if len(share) != shareLen {
+			return nil, fmt.Errorf("all shares must have the same length")
+		}
+		x := share[shareLen-1]
+		if x == 0 {
+			return nil, fmt.Errorf("invalid share x-coordinate: 0")
+		}
+		if seen[x] {
+			return nil, fmt.Errorf("duplicate share x-coordinate: %d", x)
+		}
+		seen[x] = true
+		xs[i] = x
+	}
+
+	secret := make([]byte, shareLen-1)
+	ys := make([]byte, len(shares))
+	for i := range secret {
+		for j, share := range shares {
+			ys[j] = share[i]
+		}
+		secret[i] = interpolateAtZero(xs, ys)
+	}
+
+	return secret, nil
+}
+
+// ShareIndex returns the x-coordinate of the share.
+func ShareIndex(share []byte) byte {
+	if len(share) == 0 {
+		return 0
+	}
+	return share[len(share)-1]
+}
+
+func validateThreshold(threshold, total int) error {
+	if threshold < 2 {
+		return fmt.Errorf("threshold must be at least 2: %d", threshold)
+	}
+	if total < threshold {
+		return fmt.Errorf("total shares(%d) must not be less than threshold(%d)", total, threshold)
+	}
+	if total > 255 {
+		return fmt.Errorf("total shares must not exceed 255: %d", total)
+	}
+	return nil
+}
+
+// evaluatePolynomial evaluates the polynomial at x using Horner's method.
+func evaluatePolynomial(intercept byte, coefficients []byte, x byte) byte {
+	var out byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		out = gfAdd(gfMul(out, x), coefficients[i])
+	}
+	return gfAdd(gfMul(out, x), intercept)
+}
+
+// interpolateAtZero returns f(0) of the polynomial passing through the points using Lagrange interpolation.
+func interpolateAtZero(xs, ys []byte) byte {
+	var result byte
+	for i := range xs {
+		basis := byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			// basis *= x_j / (x_j - x_i). subtraction is XOR in GF(2^8)
+			basis = gfMul(basis, gfDiv(xs[j], gfAdd(xs[j], xs[i])))
+		}
+		result = gfAdd(result, gfMul(ys[i], basis))
+	}
+	return result
+}
+
+func gfAdd(a, b byte) byte {
+	return a ^ b
+}
+
+// gfMul multiplies in GF(2^8) with the AES reduction polynomial (x^8 + x^4 + x^3 + x + 1).
+func gfMul(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 == 1 {
+			p ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// gfDiv divides a by b. b must not be zero.
+func gfDiv(a, b byte) byte {
+	return gfMul(a, gfInverse(b))
+}
+
+// gfInverse returns the multiplicative inverse using a^254 = a^-1.
+func gfInverse(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 254; i++ {
+		result = gfMul(result, a)
+	}
+	return result
+}
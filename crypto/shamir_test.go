@@ -0,0 +1,58 @@
+package crypto_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSplitAndCombineSecret tests that any threshold-sized subset of shares reconstructs the secret.
+func TestSplitAndCombineSecret(t *testing.T) {
+	secret := crypto.KDFSHA256([]byte("secret"))
+
+	shares, err := crypto.SplitSecret(secret, 3, 5, rand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	for i, share := range shares {
+		require.Equal(t, byte(i+1), crypto.ShareIndex(share))
+	}
+
+	combined, err := crypto.CombineShares([][]byte{shares[0], shares[2], shares[4]})
+	require.NoError(t, err)
+	require.Equal(t, secret, combined)
+
+	combined, err = crypto.CombineShares([][]byte{shares[3], shares[1], shares[0], shares[4]})
+	require.NoError(t, err)
+	require.Equal(t, secret, combined)
+
+	// fewer shares than the threshold do not reveal the secret
+	combined, err = crypto.CombineShares([][]byte{shares[0], shares[1]})
+	require.NoError(t, err)
+	require.NotEqual(t, secret, combined)
+}
+
+// TestSplitSecretInvalidThreshold tests that invalid threshold parameters are rejected.
+func TestSplitSecretInvalidThreshold(t *testing.T) {
+	secret := []byte("secret")
+
+	_, err := crypto.SplitSecret(secret, 1, 3, rand.Reader)
+	require.ErrorContains(t, err, "threshold must be at least 2")
+
+	_, err = crypto.SplitSecret(secret, 4, 3, rand.Reader)
+	require.ErrorContains(t, err, "must not be less than threshold")
+
+	_, err = crypto.SplitSecret(secret, 2, 256, rand.Reader)
+	require.ErrorContains(t, err, "must not exceed 255")
+}
+
+// TestCombineSharesDuplicated tests that the same share cannot be used twice.
+func TestCombineSharesDuplicated(t *testing.T) {
+	shares, err := crypto.SplitSecret([]byte("secret"), 2, 3, rand.Reader)
+	require.NoError(t, err)
+
+	_, err = crypto.CombineShares([][]byte{shares[0], shares[0]})
+	require.ErrorContains(t, err, "duplicate share")
+}
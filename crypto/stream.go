@@ -0,0 +1,324 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A stream is an envelope whose plaintext is split into fixed-size segments
+// which are sealed independently with AES256-GCM (STREAM construction).
+//
+//	magic(2) | version(1) | algorithm(1) | key epoch(4) | segment size(4) | nonce prefix(7) | segment...
+//
+// The nonce of each segment is the nonce prefix, a 4-byte big-endian segment counter and a 1-byte last-segment flag.
+// So, segments cannot be reordered, dropped or truncated without detection.
+// Every segment is authenticated with the header and the AdditionalData.
+//
+// The oracle reads a stream from a provider segment by segment, and writes the consumer data as a stream,
+// which is stored by the consumer service as it is, without being buffered.
+
+const (
+	AlgorithmAES256GCMStream byte = 2
+
+	// DefaultSegmentSize is the plaintext size of each segment except the last one.
+	DefaultSegmentSize = 64 * 1024
+
+	streamHeaderLen      = 2 + 1 + 1 + 4 + 4 + streamNoncePrefixLen
+	streamNoncePrefixLen = 7
+	maxSegmentSize       = 16 * 1024 * 1024
+	gcmTagSize           = 16
+)
+
+type streamHeader struct {
+	keyEpoch    uint32
+	segmentSize uint32
+	noncePrefix []byte
+}
+
+func (h streamHeader) Bytes() []byte {
+	bz := make([]byte, 0, streamHeaderLen)
+	bz = append(bz, envelopeMagic...)
+	bz = append(bz, EnvelopeVersion1, AlgorithmAES256GCMStream)
+	bz = binary.BigEndian.AppendUint32(bz, h.keyEpoch)
+	bz = binary.BigEndian.AppendUint32(bz, h.segmentSize)
+	return append(bz, h.noncePrefix...)
+}
+
+func parseStreamHeader(bz []byte) (*streamHeader, error) {
+	if len(bz) < streamHeaderLen || !bytes.Equal(bz[:2], envelopeMagic) {
+		return nil, fmt.Errorf("not a stream envelope")
+	}
+	if bz[2] != EnvelopeVersion1 {
+		return nil, fmt.Errorf("unsupported envelope version: %d", bz[2])
+	}
+	if bz[3] != AlgorithmAES256GCMStream {
+		return nil, fmt.Errorf("unsupported stream algorithm: %d", bz[3])
+	}
+
+	header := &streamHeader{
+		keyEpoch:    binary.BigEndian.Uint32(bz[4:8]),
+		segmentSize: binary.BigEndian.Uint32(bz[8:12]),
+		noncePrefix: bz[12:streamHeaderLen],
+	}
+	if header.segmentSize == 0 || header.segmentSize > maxSegmentSize {
+		return nil, fmt.Errorf("invalid segment size: %d", header.segmentSize)
+	}
+
+	return header, nil
+}
+
+// IsStreamEnvelope returns whether the ciphertext starts with a header of a stream envelope.
+func IsStreamEnvelope(ciphertext []byte) bool {
+	_, err := parseStreamHeader(ciphertext)
+	return err == nil
+}
+
+type streamCipher struct {
+	aead       cipher.AEAD
+	header     streamHeader
+	additional []byte
+	counter    uint32
+}
+
+func (c *streamCipher) nonce(last bool) ([]byte, error) {
+	if c.counter == ^uint32(0) {
+		return nil, fmt.Errorf("too many segments in the stream")
+	}
+
+	nonce := make([]byte, 0, gcmNonceSize)
+	nonce = append(nonce, c.header.noncePrefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, c.counter)
+	if last {
+		return append(nonce, 1), nil
+	}
+	return append(nonce, 0), nil
+}
+
+// StreamWriter encrypts data written to it and writes the stream envelope to the underlying writer.
+// Close must be called to write the last segment.
+type StreamWriter struct {
+	streamCipher
+	w      io.Writer
+	buf    []byte
+	closed bool
+}
+
+var _ io.WriteCloser = &StreamWriter{}
+
+// NewStreamWriter writes the stream header to w and returns a writer which encrypts data into segments.
+func NewStreamWriter(w io.Writer, secretKey []byte, keyEpoch uint32, additional AdditionalData, segmentSize int) (*StreamWriter, error) {
+	if segmentSize <= 0 || segmentSize > maxSegmentSize {
+		return nil, fmt.Errorf("invalid segment size: %d", segmentSize)
+	}
+
+	aead, err := newAES256GCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	noncePrefix := make([]byte, streamNoncePrefixLen)
+	if _, err := io.ReadFull(rand.Reader, noncePrefix); err != nil {
+		return nil, err
+	}
+
+	header := streamHeader{
+		keyEpoch:    keyEpoch,
+		segmentSize: uint32(segmentSize),
+		noncePrefix: noncePrefix,
+	}
+	headerBz := header.Bytes()
+
+	if _, err := w.Write(headerBz); err != nil {
+		return nil, fmt.Errorf("failed to write stream header: %w", err)
+	}
+
+	return &StreamWriter{
+		streamCipher: streamCipher{
+			aead:       aead,
+			header:     header,
+			additional: append(headerBz, additional.Bytes()...),
+		},
+		w:   w,
+		buf: make([]byte, 0, segmentSize),
+	}, nil
+}
+
+func (sw *StreamWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, fmt.Errorf("write to closed stream")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// A full segment is flushed only when more data comes, because the last segment must be flagged.
+		if len(sw.buf) == cap(sw.buf) {
+			if err := sw.flush(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(sw.buf[len(sw.buf):cap(sw.buf)], p)
+		sw.buf = sw.buf[:len(sw.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close writes the last segment. It does not close the underlying writer.
+func (sw *StreamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	return sw.flush(true)
+}
+
+func (sw *StreamWriter) flush(last bool) error {
+	nonce, err := sw.nonce(last)
+	if err != nil {
+		return err
+	}
+
+	segment := sw.aead.Seal(nil, nonce, sw.buf, sw.additional)
+	if _, err := sw.w.Write(segment); err != nil {
+		return fmt.Errorf("failed to write segment: %w", err)
+	}
+
+	sw.counter++
+	sw.buf = sw.buf[:0]
+	return nil
+}
+
+// StreamReader decrypts the stream envelope read from the underlying reader.
+// Read returns an error if any segment is not authentic or the stream is truncated.
+type StreamReader struct {
+	streamCipher
+	r io.Reader
+
+	segment   []byte // buffer for a sealed segment and a byte ahead of it
+	plaintext []byte
+	pending   []byte
+	done      bool
+}
+
+var _ io.Reader = &StreamReader{}
+
+// NewStreamReader reads the stream header from r and returns a reader which decrypts segments.
+func NewStreamReader(r io.Reader, secretKey []byte, additional AdditionalData) (*StreamReader, error) {
+	headerBz := make([]byte, streamHeaderLen)
+	if _, err := io.ReadFull(r, headerBz); err != nil {
+		return nil, fmt.Errorf("failed to read stream header: %w", err)
+	}
+
+	header, err := parseStreamHeader(headerBz)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAES256GCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamReader{
+		streamCipher: streamCipher{
+			aead:       aead,
+			header:     *header,
+			additional: append(headerBz, additional.Bytes()...),
+		},
+		r:       r,
+		segment: make([]byte, 0, int(header.segmentSize)+gcmTagSize+1),
+	}, nil
+}
+
+// KeyEpoch returns the key epoch in the stream header.
+func (sr *StreamReader) KeyEpoch() uint32 {
+	return sr.header.keyEpoch
+}
+
+func (sr *StreamReader) Read(p []byte) (int, error) {
+	for len(sr.plaintext) == 0 {
+		if sr.done {
+			return 0, io.EOF
+		}
+		if err := sr.readSegment(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, sr.plaintext)
+	sr.plaintext = sr.plaintext[n:]
+	return n, nil
+}
+
+// readSegment reads a sealed segment and one more byte to find out whether the segment is the last one.
+func (sr *StreamReader) readSegment() error {
+	sealedSize := int(sr.header.segmentSize) + gcmTagSize
+
+	buf := sr.segment[:cap(sr.segment)]
+	n := copy(buf, sr.pending)
+	m, err := io.ReadFull(sr.r, buf[n:])
+	n += m
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read segment: %w", err)
+	}
+
+	last := n <= sealedSize
+	sealed := buf[:n]
+	sr.pending = nil
+	if !last {
+		sealed = buf[:sealedSize]
+		sr.pending = append([]byte{}, buf[sealedSize:n]...)
+	}
+
+	if len(sealed) < gcmTagSize {
+		return fmt.Errorf("stream is truncated")
+	}
+
+	nonce, err := sr.nonce(last)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := sr.aead.Open(nil, nonce, sealed, sr.additional)
+	if err != nil {
+		return fmt.Errorf("failed to open segment %d: %w", sr.counter, err)
+	}
+
+	sr.counter++
+	sr.plaintext = plaintext
+	sr.done = last
+	return nil
+}
+
+// EncryptStream encrypts the whole data into a stream envelope.
+func EncryptStream(secretKey []byte, keyEpoch uint32, additional AdditionalData, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	sw, err := NewStreamWriter(&buf, secretKey, keyEpoch, additional, DefaultSegmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := sw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecryptStream decrypts the whole stream envelope.
+func DecryptStream(secretKey []byte, additional AdditionalData, ciphertext []byte) ([]byte, error) {
+	sr, err := NewStreamReader(bytes.NewReader(ciphertext), secretKey, additional)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(sr)
+}
@@ -0,0 +1,90 @@
+package crypto_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptStream(t *testing.T, secretKey []byte, additional crypto.AdditionalData, data []byte, segmentSize int) []byte {
+	var buf bytes.Buffer
+	sw, err := crypto.NewStreamWriter(&buf, secretKey, crypto.DefaultKeyEpoch, additional, segmentSize)
+	require.NoError(t, err)
+
+	// write in small pieces to cross segment boundaries
+	for i := 0; i < len(data); i += 7 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		_, err := sw.Write(data[i:end])
+		require.NoError(t, err)
+	}
+	require.NoError(t, sw.Close())
+
+	return buf.Bytes()
+}
+
+// TestStreamEncryptDecrypt tests encryption/decryption of streams with various sizes.
+func TestStreamEncryptDecrypt(t *testing.T) {
+	secretKey := crypto.KDFSHA256([]byte("secret"))
+	additional := crypto.AdditionalData{DealID: 1, DataHash: "data-hash", Purpose: crypto.PurposeConsumerData}
+	segmentSize := 16
+
+	for _, size := range []int{0, 1, 15, 16, 17, 32, 100} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		ciphertext := encryptStream(t, secretKey, additional, data, segmentSize)
+		require.True(t, crypto.IsStreamEnvelope(ciphertext))
+
+		sr, err := crypto.NewStreamReader(bytes.NewReader(ciphertext), secretKey, additional)
+		require.NoError(t, err)
+		decrypted, err := io.ReadAll(sr)
+		require.NoError(t, err)
+		require.Equal(t, data, decrypted, "size %d", size)
+
+		decrypted, err = crypto.DecryptEnvelope(secretKey, additional, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, data, decrypted, "size %d", size)
+	}
+}
+
+// TestStreamTampered tests that truncated, reordered or rebound streams are rejected.
+func TestStreamTampered(t *testing.T) {
+	secretKey := crypto.KDFSHA256([]byte("secret"))
+	additional := crypto.AdditionalData{DealID: 1, DataHash: "data-hash", Purpose: crypto.PurposeConsumerData}
+	segmentSize := 16
+	sealedSize := segmentSize + 16
+	headerLen := 19
+
+	data := make([]byte, 3*segmentSize+5)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	ciphertext := encryptStream(t, secretKey, additional, data, segmentSize)
+
+	// truncated at a segment boundary
+	truncated := ciphertext[:headerLen+2*sealedSize]
+	_, err = crypto.DecryptStream(secretKey, additional, truncated)
+	require.Error(t, err)
+
+	// the first two segments are swapped
+	reordered := append([]byte{}, ciphertext[:headerLen]...)
+	reordered = append(reordered, ciphertext[headerLen+sealedSize:headerLen+2*sealedSize]...)
+	reordered = append(reordered, ciphertext[headerLen:headerLen+sealedSize]...)
+	reordered = append(reordered, ciphertext[headerLen+2*sealedSize:]...)
+	_, err = crypto.DecryptStream(secretKey, additional, reordered)
+	require.Error(t, err)
+
+	// bound to other additional data
+	otherAdditional := additional
+	otherAdditional.DataHash = "other-data-hash"
+	_, err = crypto.DecryptStream(secretKey, otherAdditional, ciphertext)
+	require.Error(t, err)
+}
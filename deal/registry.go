@@ -0,0 +1,314 @@
+package deal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Registry keeps the compiled data schemas and presentation definitions of deals,
+// and tracks the data being delivered to each deal.
+// A deal is closed when it is deactivated or completed, so that no more data is accepted for it.
+// The entry of a closed deal is removed once its deliveries are flushed, and only its ID is kept.
+type Registry struct {
+	mtx     sync.Mutex
+	deals   map[uint64]*dealEntry
+	closed  map[uint64]struct{}
+	schemas map[string]*schemaEntry
+}
+
+type dealEntry struct {
+	prepared               bool
+	schemaURIs             []string
+	presentationDefinition *presexch.PresentationDefinition
+
+	deliveries sync.WaitGroup
+}
+
+// schemaEntry is a compiled schema shared by the deals which refer to it.
+type schemaEntry struct {
+	schema *gojsonschema.Schema
+	refs   int
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		deals:   make(map[uint64]*dealEntry),
+		closed:  make(map[uint64]struct{}),
+		schemas: make(map[string]*schemaEntry),
+	}
+}
+
+// Prepare compiles the data schemas and the presentation definition of the deal in advance.
+// It does nothing if the deal has been prepared or closed already, and closes the deal if it is not active.
+func (r *Registry) Prepare(deal *datadealtypes.Deal) error {
+	r.mtx.Lock()
+	if r.isPrepared(deal.Id) || r.isClosed(deal.Id) {
+		r.mtx.Unlock()
+		return nil
+	}
+	if deal.Status != datadealtypes.DEAL_STATUS_ACTIVE {
+		r.closed[deal.Id] = struct{}{}
+		r.mtx.Unlock()
+		return nil
+	}
+
+	missing := make([]string, 0, len(deal.DataSchema))
+	for _, uri := range deal.DataSchema {
+		if _, ok := r.schemas[uri]; !ok {
+			missing = append(missing, uri)
+		}
+	}
+	r.mtx.Unlock()
+
+	// schemas are loaded without the lock, since they may be fetched via network.
+	compiled := make(map[string]*gojsonschema.Schema, len(missing))
+	for _, uri := range missing {
+		schema, err := newReferenceSchema(uri)
+		if err != nil {
+			return fmt.Errorf("failed to compile data schema %s of deal %d: %w", uri, deal.Id, err)
+		}
+		compiled[uri] = schema
+	}
+
+	var pd *presexch.PresentationDefinition
+	if deal.PresentationDefinition != nil {
+		var err error
+		pd, err = newPresentationDefinition(deal.PresentationDefinition)
+		if err != nil {
+			return fmt.Errorf("invalid presentation definition of deal %d: %w", deal.Id, err)
+		}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// the deal may have been prepared or closed while compiling.
+	if r.isPrepared(deal.Id) || r.isClosed(deal.Id) {
+		return nil
+	}
+
+	for _, uri := range deal.DataSchema {
+		cached, ok := r.schemas[uri]
+		if !ok {
+			cached = &schemaEntry{schema: compiled[uri]}
+			r.schemas[uri] = cached
+		}
+		cached.refs++
+	}
+	entry := r.entry(deal.Id)
+	entry.schemaURIs = deal.DataSchema
+	entry.presentationDefinition = pd
+	entry.prepared = true
+
+	return nil
+}
+
+// ValidateDataSchema validates the data against all data schemas of the deal.
+// The deal is prepared first if it hasn't been, for example if it was created before the oracle started.
+func (r *Registry) ValidateDataSchema(deal *datadealtypes.Deal, data []byte) error {
+	if err := r.Prepare(deal); err != nil {
+		return err
+	}
+
+	for _, uri := range deal.DataSchema {
+		schema, err := r.schema(uri)
+		if err != nil {
+			return err
+		}
+		if err := validateJSONSchema(schema, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schema returns the compiled schema of the URI.
+// If it is not cached because the deal has been closed, it is compiled without being cached.
+func (r *Registry) schema(uri string) (*gojsonschema.Schema, error) {
+	r.mtx.Lock()
+	cached, ok := r.schemas[uri]
+	r.mtx.Unlock()
+	if ok {
+		return cached.schema, nil
+	}
+
+	schema, err := newReferenceSchema(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile data schema %s: %w", uri, err)
+	}
+	return schema, nil
+}
+
+// PresentationDefinition returns the compiled presentation definition of the deal, or nil if the deal doesn't have one.
+// The deal is prepared first if it hasn't been. If the deal has been closed, the definition is compiled without being cached.
+func (r *Registry) PresentationDefinition(deal *datadealtypes.Deal) (*presexch.PresentationDefinition, error) {
+	if deal.PresentationDefinition == nil {
+		return nil, nil
+	}
+	if err := r.Prepare(deal); err != nil {
+		return nil, err
+	}
+
+	r.mtx.Lock()
+	entry, ok := r.deals[deal.Id]
+	r.mtx.Unlock()
+	if ok && entry.prepared {
+		return entry.presentationDefinition, nil
+	}
+
+	pd, err := newPresentationDefinition(deal.PresentationDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presentation definition of deal %d: %w", deal.Id, err)
+	}
+	return pd, nil
+}
+
+// BeginDelivery starts to accept data for the deal. The returned function must be called once the data is delivered.
+// It returns an error if the deal is closed.
+func (r *Registry) BeginDelivery(dealID uint64) (func(), error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.isClosed(dealID) {
+		return nil, fmt.Errorf("deal %d is not accepting data anymore", dealID)
+	}
+
+	entry := r.entry(dealID)
+	entry.deliveries.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(entry.deliveries.Done)
+	}, nil
+}
+
+// Close stops accepting data for the deal, waits for the data being delivered to the deal,
+// and removes the entry of the deal with the schemas and the presentation definition cached for it.
+// If ctx is done before all deliveries are finished, the entry is not removed and an error is returned.
+// Then, Close can be called again.
+func (r *Registry) Close(ctx context.Context, dealID uint64) error {
+	r.mtx.Lock()
+	r.closed[dealID] = struct{}{}
+	entry, ok := r.deals[dealID]
+	r.mtx.Unlock()
+	if !ok {
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		entry.deliveries.Wait()
+		close(flushed)
+	}()
+
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to flush the data being delivered to deal %d: %w", dealID, ctx.Err())
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// the entry may have been removed by another Close while waiting.
+	if r.deals[dealID] != entry {
+		return nil
+	}
+
+	for _, uri := range entry.schemaURIs {
+		if cached, ok := r.schemas[uri]; ok {
+			cached.refs--
+			if cached.refs <= 0 {
+				delete(r.schemas, uri)
+			}
+		}
+	}
+	delete(r.deals, dealID)
+
+	return nil
+}
+
+// IsClosed returns true if the deal doesn't accept data anymore.
+func (r *Registry) IsClosed(dealID uint64) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.isClosed(dealID)
+}
+
+// CachedDeals returns the number of the deals which have their entries.
+func (r *Registry) CachedDeals() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.deals)
+}
+
+// CachedSchemas returns the number of the compiled schemas in the cache.
+func (r *Registry) CachedSchemas() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.schemas)
+}
+
+// isPrepared returns true if the deal has been prepared. The lock must be held.
+func (r *Registry) isPrepared(dealID uint64) bool {
+	entry, ok := r.deals[dealID]
+	return ok && entry.prepared
+}
+
+// isClosed returns true if the deal has been closed. The lock must be held.
+func (r *Registry) isClosed(dealID uint64) bool {
+	_, ok := r.closed[dealID]
+	return ok
+}
+
+// entry returns the entry of the deal, creating it if not exists. The lock must be held.
+func (r *Registry) entry(dealID uint64) *dealEntry {
+	entry, ok := r.deals[dealID]
+	if !ok {
+		entry = &dealEntry{}
+		r.deals[dealID] = entry
+	}
+	return entry
+}
+
+func newReferenceSchema(schemaURI string) (*gojsonschema.Schema, error) {
+	return gojsonschema.NewSchema(gojsonschema.NewReferenceLoader(schemaURI))
+}
+
+func validateJSONSchema(schema *gojsonschema.Schema, data []byte) error {
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("failed to validate JSON schema: %w", err)
+	}
+
+	if !result.Valid() {
+		var sb strings.Builder
+		for _, err := range result.Errors() {
+			sb.WriteString("\n\t")
+			sb.WriteString(err.String())
+		}
+		return fmt.Errorf("JSON doc doesn't conform to the desired JSON schema: %s", sb.String())
+	}
+
+	return nil
+}
+
+func newPresentationDefinition(pdBytes []byte) (*presexch.PresentationDefinition, error) {
+	var pd presexch.PresentationDefinition
+	if err := json.Unmarshal(pdBytes, &pd); err != nil {
+		return nil, err
+	}
+	if err := pd.ValidateSchema(); err != nil {
+		return nil, err
+	}
+	return &pd, nil
+}
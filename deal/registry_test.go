@@ -0,0 +1,151 @@
+package deal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/medibloc/panacea-oracle/deal"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"type": "object",
+	"properties": { "name": { "type": "string" } },
+	"required": ["name"]
+}`
+
+func newTestDeal(t *testing.T, id uint64) *datadealtypes.Deal {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(path, []byte(testSchema), 0600))
+
+	return &datadealtypes.Deal{
+		Id:         id,
+		DataSchema: []string{"file://" + path},
+		Status:     datadealtypes.DEAL_STATUS_ACTIVE,
+	}
+}
+
+func TestPrepareAndValidate(t *testing.T) {
+	registry := deal.NewRegistry()
+	d := newTestDeal(t, 1)
+
+	require.NoError(t, registry.Prepare(d))
+	require.Equal(t, 1, registry.CachedSchemas())
+
+	// the schema is not fetched again once it is compiled
+	require.NoError(t, os.Remove(d.DataSchema[0][len("file://"):]))
+
+	require.NoError(t, registry.ValidateDataSchema(d, []byte(`{"name": "alice"}`)))
+	require.ErrorContains(t, registry.ValidateDataSchema(d, []byte(`{"age": 1}`)), "doesn't conform")
+}
+
+func TestPrepareInvalid(t *testing.T) {
+	registry := deal.NewRegistry()
+
+	d := newTestDeal(t, 1)
+	d.DataSchema = []string{"file:///not/exist.json"}
+	require.Error(t, registry.Prepare(d))
+	require.Equal(t, 0, registry.CachedSchemas())
+
+	d = newTestDeal(t, 2)
+	d.DataSchema = nil
+	d.PresentationDefinition = []byte(`{"id": 1}`)
+	require.ErrorContains(t, registry.Prepare(d), "invalid presentation definition")
+}
+
+func TestPresentationDefinition(t *testing.T) {
+	registry := deal.NewRegistry()
+	d := newTestDeal(t, 1)
+	d.PresentationDefinition = []byte(`{"id": "pd1", "input_descriptors": [{"id": "desc1"}]}`)
+
+	pd, err := registry.PresentationDefinition(d)
+	require.NoError(t, err)
+	require.Equal(t, "pd1", pd.ID)
+
+	// the compiled definition is cached
+	cached, err := registry.PresentationDefinition(d)
+	require.NoError(t, err)
+	require.Same(t, pd, cached)
+
+	// it is compiled without being cached once the deal is closed
+	require.NoError(t, registry.Close(context.Background(), d.Id))
+	compiled, err := registry.PresentationDefinition(d)
+	require.NoError(t, err)
+	require.NotSame(t, pd, compiled)
+	require.Equal(t, 0, registry.CachedDeals())
+}
+
+func TestPrepareInactiveDeal(t *testing.T) {
+	registry := deal.NewRegistry()
+	d := newTestDeal(t, 1)
+	d.Status = datadealtypes.DEAL_STATUS_INACTIVE
+
+	require.NoError(t, registry.Prepare(d))
+	require.True(t, registry.IsClosed(d.Id))
+	require.Equal(t, 0, registry.CachedSchemas())
+
+	_, err := registry.BeginDelivery(d.Id)
+	require.Error(t, err)
+}
+
+func TestCloseFlushesDeliveries(t *testing.T) {
+	registry := deal.NewRegistry()
+	d := newTestDeal(t, 1)
+	require.NoError(t, registry.Prepare(d))
+
+	done, err := registry.BeginDelivery(d.Id)
+	require.NoError(t, err)
+
+	// Close doesn't finish while the data is being delivered
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, registry.Close(ctx, d.Id), context.DeadlineExceeded)
+	require.True(t, registry.IsClosed(d.Id))
+	require.Equal(t, 1, registry.CachedSchemas())
+
+	// no more data is accepted
+	_, err = registry.BeginDelivery(d.Id)
+	require.Error(t, err)
+
+	closed := make(chan error)
+	go func() {
+		closed <- registry.Close(context.Background(), d.Id)
+	}()
+
+	done()
+	done() // no effect
+
+	require.NoError(t, <-closed)
+	require.Equal(t, 0, registry.CachedSchemas())
+
+	// the entry of the deal is removed, but the deal is still closed
+	require.Equal(t, 0, registry.CachedDeals())
+	require.True(t, registry.IsClosed(d.Id))
+	_, err = registry.BeginDelivery(d.Id)
+	require.Error(t, err)
+
+	// the deal isn't prepared again once it is closed
+	require.NoError(t, registry.Prepare(d))
+	require.Equal(t, 0, registry.CachedSchemas())
+}
+
+func TestSharedSchema(t *testing.T) {
+	registry := deal.NewRegistry()
+	d1 := newTestDeal(t, 1)
+	d2 := newTestDeal(t, 2)
+	d2.DataSchema = d1.DataSchema
+
+	require.NoError(t, registry.Prepare(d1))
+	require.NoError(t, registry.Prepare(d2))
+	require.Equal(t, 1, registry.CachedSchemas())
+
+	require.NoError(t, registry.Close(context.Background(), d1.Id))
+	require.Equal(t, 1, registry.CachedSchemas())
+
+	require.NoError(t, registry.Close(context.Background(), d2.Id))
+	require.Equal(t, 0, registry.CachedSchemas())
+}
@@ -0,0 +1,164 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	catchUpTimeout  = 30 * time.Second
+	txSearchPerPage = 100
+)
+
+// TxSearcher searches committed txs. The RPC client of Tendermint implements it.
+type TxSearcher interface {
+	Status(ctx context.Context) (*ctypes.ResultStatus, error)
+	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error)
+}
+
+// LightBlockGetter returns a light block verified by the light client. The panacea.QueryClient implements it.
+type LightBlockGetter interface {
+	GetLightBlock(height int64) (*tmtypes.LightBlock, error)
+}
+
+// catchUp replays the events emitted after their checkpoints up to the latest block.
+// If an event has no checkpoint, its checkpoint is set to the latest block without replay.
+func (s *PanaceaSubscriber) catchUp() error {
+	ctx, cancel := context.WithTimeout(context.Background(), catchUpTimeout)
+	defer cancel()
+
+	latestHeight, err := s.latestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	return s.catchUpTo(ctx, latestHeight)
+}
+
+// catchUpTo replays the events emitted after their checkpoints up to the given height.
+func (s *PanaceaSubscriber) catchUpTo(ctx context.Context, latestHeight int64) error {
+	for query, e := range s.registeredEvents() {
+		checkpoint := s.checkpoint(e.Name())
+		if checkpoint.Height == 0 {
+			if err := s.saveCheckpoint(e.Name(), Checkpoint{Height: latestHeight}); err != nil {
+				return err
+			}
+			continue
+		}
+		if checkpoint.Height > latestHeight {
+			continue
+		}
+
+		txs, err := s.searchTxs(ctx, query, checkpoint.Height, latestHeight)
+		if err != nil {
+			return fmt.Errorf("failed to search txs of %s: %w", e.Name(), err)
+		}
+
+		for _, tx := range txs {
+			if err := s.confirmTx(tx); err != nil {
+				return fmt.Errorf("failed to confirm tx %s of %s: %w", tx.Hash, e.Name(), err)
+			}
+			if err := s.enqueue(query, resultEventFromTx(query, tx)); err != nil {
+				return err
+			}
+		}
+
+		if len(txs) > 0 {
+			log.Infof("replay %d txs of %s from height %d to %d", len(txs), e.Name(), checkpoint.Height, latestHeight)
+		}
+	}
+
+	return nil
+}
+
+func (s *PanaceaSubscriber) latestHeight(ctx context.Context) (int64, error) {
+	status, err := s.searcher.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the latest block height: %w", err)
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// searchTxs searches the txs matched with the query in the range of heights, in ascending order with proofs.
+func (s *PanaceaSubscriber) searchTxs(ctx context.Context, query string, fromHeight, toHeight int64) ([]*ctypes.ResultTx, error) {
+	rangeQuery := fmt.Sprintf("%s AND tx.height >= %d AND tx.height <= %d", query, fromHeight, toHeight)
+
+	var txs []*ctypes.ResultTx
+	perPage := txSearchPerPage
+	for page := 1; ; page++ {
+		res, err := s.searcher.TxSearch(ctx, rangeQuery, true, &page, &perPage, "asc")
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, res.Txs...)
+		if len(res.Txs) == 0 || len(txs) >= res.TotalCount {
+			return txs, nil
+		}
+	}
+}
+
+// confirmTx checks that the tx is included in the block verified by the light client.
+func (s *PanaceaSubscriber) confirmTx(tx *ctypes.ResultTx) error {
+	lightBlock, err := s.lightClient.GetLightBlock(tx.Height)
+	if err != nil {
+		return fmt.Errorf("failed to get light block at height %d: %w", tx.Height, err)
+	}
+
+	if err := tx.Proof.Validate(lightBlock.DataHash); err != nil {
+		return fmt.Errorf("invalid tx proof: %w", err)
+	}
+	if !bytes.Equal(tx.Proof.Data, tx.Tx) || !bytes.Equal(tx.Hash, tx.Tx.Hash()) {
+		return fmt.Errorf("tx is different from the proven one")
+	}
+
+	return nil
+}
+
+// resultEventFromTx converts the tx into the event which is delivered through the websocket.
+func resultEventFromTx(query string, tx *ctypes.ResultTx) ctypes.ResultEvent {
+	events := map[string][]string{
+		tmtypes.EventTypeKey: {tmtypes.EventTx},
+		tmtypes.TxHashKey:    {tx.Hash.String()},
+		tmtypes.TxHeightKey:  {strconv.FormatInt(tx.Height, 10)},
+	}
+	for _, event := range tx.TxResult.Events {
+		for _, attr := range event.Attributes {
+			key := event.Type + "." + string(attr.Key)
+			events[key] = append(events[key], string(attr.Value))
+		}
+	}
+
+	return ctypes.ResultEvent{
+		Query: query,
+		Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+			Height: tx.Height,
+			Index:  tx.Index,
+			Tx:     tx.Tx,
+			Result: tx.TxResult,
+		}},
+		Events: events,
+	}
+}
+
+// txPosition returns the height and the hash of the tx which emitted the event.
+func txPosition(event ctypes.ResultEvent) (int64, string, error) {
+	heights, hashes := event.Events[tmtypes.TxHeightKey], event.Events[tmtypes.TxHashKey]
+	if len(heights) == 0 || len(hashes) == 0 {
+		return 0, "", fmt.Errorf("no tx height or hash in the event")
+	}
+
+	height, err := strconv.ParseInt(heights[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid tx height in the event: %w", err)
+	}
+
+	return height, hashes[0], nil
+}
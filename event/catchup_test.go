@@ -0,0 +1,225 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// fakeChain has a block of txs at each height, and serves tx search and light blocks.
+type fakeChain struct {
+	blocks map[int64]tmtypes.Txs
+	latest int64
+}
+
+func (c *fakeChain) Status(_ context.Context) (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *fakeChain) TxSearch(_ context.Context, query string, prove bool, _, _ *int, _ string) (*ctypes.ResultTxSearch, error) {
+	var from, to int64
+	if _, err := fmt.Sscanf(query[strings.Index(query, "tx.height >="):], "tx.height >= %d AND tx.height <= %d", &from, &to); err != nil {
+		return nil, err
+	}
+
+	var txs []*ctypes.ResultTx
+	for height := from; height <= to; height++ {
+		for i, tx := range c.blocks[height] {
+			resultTx := &ctypes.ResultTx{
+				Hash:     tx.Hash(),
+				Height:   height,
+				Index:    uint32(i),
+				Tx:       tx,
+				TxResult: abci.ResponseDeliverTx{},
+			}
+			if prove {
+				resultTx.Proof = c.blocks[height].Proof(i)
+			}
+			txs = append(txs, resultTx)
+		}
+	}
+	return &ctypes.ResultTxSearch{Txs: txs, TotalCount: len(txs)}, nil
+}
+
+func (c *fakeChain) GetLightBlock(height int64) (*tmtypes.LightBlock, error) {
+	return &tmtypes.LightBlock{
+		SignedHeader: &tmtypes.SignedHeader{
+			Header: &tmtypes.Header{Height: height, DataHash: c.blocks[height].Hash()},
+		},
+	}, nil
+}
+
+func TestSubscriberCatchUp(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{
+			5: {tmtypes.Tx("tx1"), tmtypes.Tx("tx2")},
+			7: {tmtypes.Tx("tx3")},
+		},
+		latest: 8,
+	}
+
+	// tx1 has been processed before the oracle stopped
+	store := NewDBCheckpointStore(dbm.NewMemDB())
+	require.NoError(t, store.SetCheckpoint("test", Checkpoint{Height: 5, TxHashes: []string{fmt.Sprintf("%X", tmtypes.Tx("tx1").Hash())}}))
+
+	subscriber, err := NewSubscriber(node.wsAddr(), newTestDispatcher(t), nil, store, chain)
+	require.NoError(t, err)
+	subscriber.searcher = chain
+	subscriber.minBackoff = 10 * time.Millisecond
+	defer subscriber.Close()
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 10)}
+	require.NoError(t, subscriber.Run(e))
+
+	require.Equal(t, "tx2", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, "tx3", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+
+	require.Eventually(t, func() bool {
+		checkpoint, err := store.GetCheckpoint("test")
+		require.NoError(t, err)
+		return checkpoint.Height == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the replayed tx delivered through the websocket again is not handled twice
+	require.Eventually(t, func() bool { return subscriber.Status().Connected() }, 5*time.Second, 10*time.Millisecond)
+	tx3 := resultEventFromTx(testQuery, &ctypes.ResultTx{Hash: tmtypes.Tx("tx3").Hash(), Height: 7, Tx: tmtypes.Tx("tx3")})
+	node.publishEvent(t, tx3)
+	node.publish(t, testQuery)
+	require.Empty(t, waitFor(t, e.received).Events)
+}
+
+// TestSubscriberCatchUpAfterReconnect tests that the events missed while disconnected are replayed after reconnection.
+func TestSubscriberCatchUpAfterReconnect(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{
+			5: {tmtypes.Tx("tx1")},
+		},
+		latest: 5,
+	}
+	store := NewDBCheckpointStore(dbm.NewMemDB())
+	require.NoError(t, store.SetCheckpoint("test", Checkpoint{Height: 4}))
+
+	subscriber, err := NewSubscriber(node.wsAddr(), newTestDispatcher(t), nil, store, chain)
+	require.NoError(t, err)
+	subscriber.searcher = chain
+	subscriber.minBackoff = 10 * time.Millisecond
+	defer subscriber.Close()
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 10)}
+	require.NoError(t, subscriber.Run(e))
+
+	require.Equal(t, "tx1", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, testQuery, waitFor(t, node.subscribed))
+	require.Eventually(t, func() bool { return subscriber.Status().Connected() }, 5*time.Second, 10*time.Millisecond)
+
+	// tx2 is committed while disconnected
+	node.dropConnections()
+	chain.blocks[6] = tmtypes.Txs{tmtypes.Tx("tx2")}
+	chain.latest = 6
+
+	require.Equal(t, "tx2", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+}
+
+func TestConfirmTx(t *testing.T) {
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{3: {tmtypes.Tx("tx1"), tmtypes.Tx("tx2")}},
+		latest: 3,
+	}
+	res, err := chain.TxSearch(context.Background(), "tx.height >= 3 AND tx.height <= 3", true, nil, nil, "asc")
+	require.NoError(t, err)
+
+	subscriber := &PanaceaSubscriber{lightClient: chain}
+	require.NoError(t, subscriber.confirmTx(res.Txs[1]))
+
+	// the light client has a different block at the height
+	subscriber.lightClient = &fakeChain{blocks: map[int64]tmtypes.Txs{3: {tmtypes.Tx("tx3")}}}
+	require.ErrorContains(t, subscriber.confirmTx(res.Txs[1]), "invalid tx proof")
+
+	// the tx is replaced with another one
+	res.Txs[1].Tx = tmtypes.Tx("tx3")
+	subscriber.lightClient = chain
+	require.ErrorContains(t, subscriber.confirmTx(res.Txs[1]), "different")
+}
+
+// TestCheckpointAfterConcurrentHandling tests that the checkpoint doesn't skip the tx which is still being handled.
+func TestCheckpointAfterConcurrentHandling(t *testing.T) {
+	store := NewDBCheckpointStore(dbm.NewMemDB())
+	subscriber := &PanaceaSubscriber{checkpoints: store, progress: make(map[string]*eventProgress)}
+
+	tx1, ok := subscriber.begin("test", 5, "a")
+	require.True(t, ok)
+	tx2, ok := subscriber.begin("test", 6, "b")
+	require.True(t, ok)
+	_, ok = subscriber.begin("test", 6, "b")
+	require.False(t, ok)
+
+	require.NoError(t, subscriber.finish("test", tx2))
+	checkpoint, err := store.GetCheckpoint("test")
+	require.NoError(t, err)
+	require.Equal(t, Checkpoint{}, checkpoint)
+
+	require.NoError(t, subscriber.finish("test", tx1))
+	checkpoint, err = store.GetCheckpoint("test")
+	require.NoError(t, err)
+	require.Equal(t, Checkpoint{Height: 6, TxHashes: []string{"b"}}, checkpoint)
+
+	_, ok = subscriber.begin("test", 5, "a")
+	require.False(t, ok)
+}
+
+// TestCheckpointAfterFailure tests that the checkpoint doesn't skip the failed tx which is not kept in the dead-letter queue.
+func TestCheckpointAfterFailure(t *testing.T) {
+	store := NewDBCheckpointStore(dbm.NewMemDB())
+	subscriber := newSubscriber("", nil, newTestDispatcher(t), nil, store, nil)
+	defer subscriber.Close()
+
+	e := flakyEvent{calls: new(int32), handled: make(chan struct{})}
+	require.NoError(t, subscriber.register([]Event{e}))
+
+	tx1 := resultEventFromTx(testQuery, &ctypes.ResultTx{Hash: tmtypes.Tx("tx1").Hash(), Height: 5, Tx: tmtypes.Tx("tx1")})
+	require.NoError(t, subscriber.enqueue(testQuery, tx1))
+	require.Eventually(t, func() bool {
+		subscriber.checkpointMtx.Lock()
+		defer subscriber.checkpointMtx.Unlock()
+		pending := subscriber.eventProgress(e.Name()).pending
+		return len(pending) == 1 && pending[0].failed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	checkpoint, err := store.GetCheckpoint(e.Name())
+	require.NoError(t, err)
+	require.Equal(t, Checkpoint{}, checkpoint)
+
+	// the failed tx is dispatched again when it is replayed
+	require.NoError(t, subscriber.enqueue(testQuery, tx1))
+	waitFor(t, e.handled)
+	require.Eventually(t, func() bool {
+		checkpoint, err := store.GetCheckpoint(e.Name())
+		require.NoError(t, err)
+		return checkpoint.Height == 5
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCheckpoint(t *testing.T) {
+	checkpoint := Checkpoint{}.Next(3, "a").Next(3, "b")
+	require.Equal(t, Checkpoint{Height: 3, TxHashes: []string{"a", "b"}}, checkpoint)
+	require.True(t, checkpoint.Processed(2, "c"))
+	require.True(t, checkpoint.Processed(3, "a"))
+	require.False(t, checkpoint.Processed(3, "c"))
+	require.False(t, checkpoint.Processed(4, "a"))
+
+	require.Equal(t, Checkpoint{Height: 4, TxHashes: []string{"c"}}, checkpoint.Next(4, "c"))
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// CheckpointDBName is the name of the sealed DB which has the checkpoints of events.
+const CheckpointDBName = "event-checkpoint"
+
+var checkpointKeyPrefix = []byte("checkpoint/")
+
+// Checkpoint is the last processed height of an event and the txs processed at that height.
+// The txs at the height are replayed except the processed ones, because a block may have several txs of the event.
+type Checkpoint struct {
+	Height   int64    `json:"height"`
+	TxHashes []string `json:"tx_hashes,omitempty"`
+}
+
+// Processed returns whether the tx at the height has been processed.
+func (c Checkpoint) Processed(height int64, txHash string) bool {
+	if height != c.Height {
+		return height < c.Height
+	}
+	for _, hash := range c.TxHashes {
+		if hash == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the checkpoint after the tx at the height is processed.
+func (c Checkpoint) Next(height int64, txHash string) Checkpoint {
+	if height > c.Height {
+		return Checkpoint{Height: height, TxHashes: []string{txHash}}
+	}
+	return Checkpoint{Height: c.Height, TxHashes: append(append([]string{}, c.TxHashes...), txHash)}
+}
+
+// CheckpointStore stores the checkpoint of each event.
+type CheckpointStore interface {
+	// GetCheckpoint returns an empty checkpoint if there is no checkpoint of the event.
+	GetCheckpoint(eventName string) (Checkpoint, error)
+	SetCheckpoint(eventName string, checkpoint Checkpoint) error
+}
+
+var _ CheckpointStore = &DBCheckpointStore{}
+
+// DBCheckpointStore stores checkpoints in a DB, which should be sealed.
+type DBCheckpointStore struct {
+	db dbm.DB
+}
+
+func NewDBCheckpointStore(db dbm.DB) *DBCheckpointStore {
+	return &DBCheckpointStore{db: db}
+}
+
+func (s *DBCheckpointStore) GetCheckpoint(eventName string) (Checkpoint, error) {
+	bz, err := s.db.Get(checkpointKey(eventName))
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("failed to get checkpoint of %s: %w", eventName, err)
+	}
+	if bz == nil {
+		return Checkpoint{}, nil
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(bz, &checkpoint); err != nil {
+		return Checkpoint{}, fmt.Errorf("invalid checkpoint of %s: %w", eventName, err)
+	}
+
+	return checkpoint, nil
+}
+
+func (s *DBCheckpointStore) SetCheckpoint(eventName string, checkpoint Checkpoint) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.SetSync(checkpointKey(eventName), bz); err != nil {
+		return fmt.Errorf("failed to set checkpoint of %s: %w", eventName, err)
+	}
+	return nil
+}
+
+func checkpointKey(eventName string) []byte {
+	return append(append([]byte{}, checkpointKeyPrefix...), eventName...)
+}
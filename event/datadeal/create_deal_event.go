@@ -0,0 +1,75 @@
+package datadeal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/service"
+	log "github.com/sirupsen/logrus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+var _ event.KeyedEvent = (*CreateDealEvent)(nil)
+
+// CreateDealEvent prepares the data schemas and the presentation definition of a new deal,
+// so that the first data of the deal is validated without fetching them.
+type CreateDealEvent struct {
+	svc service.Service
+}
+
+func NewCreateDealEvent(s service.Service) CreateDealEvent {
+	return CreateDealEvent{s}
+}
+
+func (e CreateDealEvent) Name() string {
+	return "CreateDealEvent"
+}
+
+func (e CreateDealEvent) GetEventQuery() string {
+	return "message.action = 'CreateDeal'"
+}
+
+func (e CreateDealEvent) EventKey(event ctypes.ResultEvent) string {
+	return dealKey(createdDealIDs(event))
+}
+
+func (e CreateDealEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
+	dealIDs, err := createdDealIDs(event)
+	if err != nil {
+		return err
+	}
+
+	for _, dealID := range dealIDs {
+		deal, err := e.svc.QueryClient().GetDeal(ctx, dealID)
+		if err != nil {
+			return fmt.Errorf("failed to get deal(%d): %w", dealID, err)
+		}
+
+		if err := e.svc.DealRegistry().Prepare(deal); err != nil {
+			return fmt.Errorf("failed to prepare deal(%d): %w", dealID, err)
+		}
+
+		log.Infof("deal(%d) is prepared", dealID)
+	}
+
+	return nil
+}
+
+// createdDealIDs returns the IDs of the deals created by the tx.
+func createdDealIDs(event ctypes.ResultEvent) ([]uint64, error) {
+	responses, err := unpackMsgResponses(event, &datadealtypes.MsgCreateDeal{}, func() proto.Message {
+		return &datadealtypes.MsgCreateDealResponse{}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dealIDs := make([]uint64, len(responses))
+	for i, response := range responses {
+		dealIDs[i] = response.(*datadealtypes.MsgCreateDealResponse).DealId
+	}
+	return dealIDs, nil
+}
@@ -0,0 +1,96 @@
+package datadeal
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/service"
+	log "github.com/sirupsen/logrus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+var _ event.KeyedEvent = (*DeactivateDealEvent)(nil)
+
+// DeactivateDealEvent closes a deactivated deal.
+// The oracle stops accepting data for the deal, flushes the data being delivered, and releases the cache of the deal.
+type DeactivateDealEvent struct {
+	svc service.Service
+}
+
+func NewDeactivateDealEvent(s service.Service) DeactivateDealEvent {
+	return DeactivateDealEvent{s}
+}
+
+func (e DeactivateDealEvent) Name() string {
+	return "DeactivateDealEvent"
+}
+
+func (e DeactivateDealEvent) GetEventQuery() string {
+	return "message.action = 'DeactivateDeal'"
+}
+
+func (e DeactivateDealEvent) EventKey(event ctypes.ResultEvent) string {
+	return dealKey(deactivatedDealIDs(event))
+}
+
+func (e DeactivateDealEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
+	data, err := txData(event)
+	if err != nil {
+		return err
+	}
+
+	dealIDs, err := deactivatedDealIDs(event)
+	if err != nil {
+		return err
+	}
+
+	for _, dealID := range dealIDs {
+		// the deal is changed by the tx, so the deal cached before it is queried again.
+		e.svc.QueryClient().InvalidateDeal(dealID, data.Height)
+
+		// the status is verified, since the event itself is not.
+		deal, err := e.svc.QueryClient().GetDeal(ctx, dealID)
+		if err != nil {
+			return fmt.Errorf("failed to get deal(%d): %w", dealID, err)
+		}
+
+		if deal.Status == datadealtypes.DEAL_STATUS_ACTIVE {
+			return fmt.Errorf("deal(%d) is still active", dealID)
+		}
+
+		if err := closeDeal(ctx, e.svc, deal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deactivatedDealIDs returns the IDs of the deals deactivated by the tx.
+func deactivatedDealIDs(event ctypes.ResultEvent) ([]uint64, error) {
+	msgs, err := unpackMsgs(event, func() sdk.Msg {
+		return &datadealtypes.MsgDeactivateDeal{}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dealIDs := make([]uint64, len(msgs))
+	for i, msg := range msgs {
+		dealIDs[i] = msg.(*datadealtypes.MsgDeactivateDeal).DealId
+	}
+	return dealIDs, nil
+}
+
+// closeDeal stops accepting data for the deal, flushes the data being delivered, and releases the cache of the deal.
+func closeDeal(ctx context.Context, svc service.Service, deal *datadealtypes.Deal) error {
+	if err := svc.DealRegistry().Close(ctx, deal.Id); err != nil {
+		return fmt.Errorf("failed to close deal(%d): %w", deal.Id, err)
+	}
+
+	log.Infof("deal(%d) is closed. status(%s)", deal.Id, deal.Status)
+	return nil
+}
@@ -0,0 +1,152 @@
+package datadeal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/medibloc/panacea-oracle/event/datadeal"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/stretchr/testify/suite"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type dealEventTestSuite struct {
+	mocks.MockTestSuite
+
+	deal *datadealtypes.Deal
+}
+
+func TestDealEventTestSuite(t *testing.T) {
+	suite.Run(t, &dealEventTestSuite{})
+}
+
+func (suite *dealEventTestSuite) BeforeTest(_, _ string) {
+	suite.Initialize()
+
+	schemaPath := filepath.Join(suite.T().TempDir(), "schema.json")
+	suite.Require().NoError(os.WriteFile(schemaPath, []byte(`{"type": "object"}`), 0600))
+
+	suite.deal = &datadealtypes.Deal{
+		Id:         3,
+		DataSchema: []string{"file://" + schemaPath},
+		Status:     datadealtypes.DEAL_STATUS_ACTIVE,
+	}
+	suite.QueryClient.Deal = suite.deal
+}
+
+// newTxEvent returns an event of the tx which has the msgs and the result data.
+func (suite *dealEventTestSuite) newTxEvent(msgs []sdk.Msg, responses ...proto.Message) coretypes.ResultEvent {
+	anys := make([]*codectypes.Any, len(msgs))
+	msgData := make([]*sdk.MsgData, len(msgs))
+	for i, msg := range msgs {
+		anyMsg, err := codectypes.NewAnyWithValue(msg)
+		suite.Require().NoError(err)
+		anys[i] = anyMsg
+
+		msgData[i] = &sdk.MsgData{MsgType: sdk.MsgTypeURL(msg)}
+		if i < len(responses) {
+			msgData[i].Data, err = proto.Marshal(responses[i])
+			suite.Require().NoError(err)
+		}
+	}
+
+	bodyBz, err := proto.Marshal(&txtypes.TxBody{Messages: anys})
+	suite.Require().NoError(err)
+	txBz, err := proto.Marshal(&txtypes.TxRaw{BodyBytes: bodyBz})
+	suite.Require().NoError(err)
+	dataBz, err := proto.Marshal(&sdk.TxMsgData{Data: msgData})
+	suite.Require().NoError(err)
+
+	return coretypes.ResultEvent{
+		Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+			Height: 1,
+			Tx:     txBz,
+			Result: abci.ResponseDeliverTx{Data: dataBz},
+		}},
+	}
+}
+
+func (suite *dealEventTestSuite) TestNameAndGetEventQuery() {
+	create := datadeal.NewCreateDealEvent(suite.Svc)
+	suite.Require().Equal("CreateDealEvent", create.Name())
+	suite.Require().Equal("message.action = 'CreateDeal'", create.GetEventQuery())
+
+	deactivate := datadeal.NewDeactivateDealEvent(suite.Svc)
+	suite.Require().Equal("DeactivateDealEvent", deactivate.Name())
+	suite.Require().Equal("message.action = 'DeactivateDeal'", deactivate.GetEventQuery())
+
+	consent := datadeal.NewSubmitConsentEvent(suite.Svc)
+	suite.Require().Equal("SubmitConsentEvent", consent.Name())
+	suite.Require().Equal("message.action = 'SubmitConsent'", consent.GetEventQuery())
+}
+
+func (suite *dealEventTestSuite) TestCreateDealEvent() {
+	e := datadeal.NewCreateDealEvent(suite.Svc)
+	event := suite.newTxEvent(
+		[]sdk.Msg{&datadealtypes.MsgCreateDeal{DataSchema: suite.deal.DataSchema}},
+		&datadealtypes.MsgCreateDealResponse{DealId: suite.deal.Id},
+	)
+
+	suite.Require().Equal("3", e.EventKey(event))
+	suite.Require().NoError(e.EventHandler(context.Background(), event))
+	suite.Require().Equal(1, suite.Svc.DealRegistry().CachedSchemas())
+	suite.Require().False(suite.Svc.DealRegistry().IsClosed(suite.deal.Id))
+}
+
+func (suite *dealEventTestSuite) TestDeactivateDealEvent() {
+	registry := suite.Svc.DealRegistry()
+	suite.Require().NoError(registry.Prepare(suite.deal))
+
+	e := datadeal.NewDeactivateDealEvent(suite.Svc)
+	event := suite.newTxEvent([]sdk.Msg{&datadealtypes.MsgDeactivateDeal{DealId: suite.deal.Id}})
+	suite.Require().Equal("3", e.EventKey(event))
+
+	// the event is not trusted until the deal is deactivated on chain
+	suite.Require().ErrorContains(e.EventHandler(context.Background(), event), "still active")
+	suite.Require().False(registry.IsClosed(suite.deal.Id))
+
+	suite.deal.Status = datadealtypes.DEAL_STATUS_INACTIVE
+
+	done, err := registry.BeginDelivery(suite.deal.Id)
+	suite.Require().NoError(err)
+
+	// the data being delivered is flushed before the cache is released
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	suite.Require().Error(e.EventHandler(ctx, event))
+	suite.Require().True(registry.IsClosed(suite.deal.Id))
+	suite.Require().Equal(1, registry.CachedSchemas())
+
+	done()
+	suite.Require().NoError(e.EventHandler(context.Background(), event))
+	suite.Require().Equal(0, registry.CachedSchemas())
+}
+
+func (suite *dealEventTestSuite) TestSubmitConsentEvent() {
+	registry := suite.Svc.DealRegistry()
+	suite.Require().NoError(registry.Prepare(suite.deal))
+
+	e := datadeal.NewSubmitConsentEvent(suite.Svc)
+	event := suite.newTxEvent([]sdk.Msg{&datadealtypes.MsgSubmitConsent{
+		Consent: &datadealtypes.Consent{DealId: suite.deal.Id},
+	}})
+
+	// the deal is not completed yet
+	suite.Require().NoError(e.EventHandler(context.Background(), event))
+	suite.Require().False(registry.IsClosed(suite.deal.Id))
+
+	suite.deal.Status = datadealtypes.DEAL_STATUS_COMPLETED
+	suite.Require().NoError(e.EventHandler(context.Background(), event))
+	suite.Require().True(registry.IsClosed(suite.deal.Id))
+	suite.Require().Equal(0, registry.CachedSchemas())
+}
@@ -0,0 +1,85 @@
+package datadeal
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/service"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+var _ event.KeyedEvent = (*SubmitConsentEvent)(nil)
+
+// SubmitConsentEvent closes a deal which is completed by a consent.
+type SubmitConsentEvent struct {
+	svc service.Service
+}
+
+func NewSubmitConsentEvent(s service.Service) SubmitConsentEvent {
+	return SubmitConsentEvent{s}
+}
+
+func (e SubmitConsentEvent) Name() string {
+	return "SubmitConsentEvent"
+}
+
+func (e SubmitConsentEvent) GetEventQuery() string {
+	return "message.action = 'SubmitConsent'"
+}
+
+func (e SubmitConsentEvent) EventKey(event ctypes.ResultEvent) string {
+	return dealKey(consentedDealIDs(event))
+}
+
+func (e SubmitConsentEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
+	data, err := txData(event)
+	if err != nil {
+		return err
+	}
+
+	dealIDs, err := consentedDealIDs(event)
+	if err != nil {
+		return err
+	}
+
+	for _, dealID := range dealIDs {
+		// the deal is changed by the tx, so the deal cached before it is queried again.
+		e.svc.QueryClient().InvalidateDeal(dealID, data.Height)
+
+		deal, err := e.svc.QueryClient().GetDeal(ctx, dealID)
+		if err != nil {
+			return fmt.Errorf("failed to get deal(%d): %w", dealID, err)
+		}
+
+		if deal.Status != datadealtypes.DEAL_STATUS_COMPLETED {
+			continue
+		}
+
+		if err := closeDeal(ctx, e.svc, deal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// consentedDealIDs returns the IDs of the deals to which the consents of the tx are submitted.
+func consentedDealIDs(event ctypes.ResultEvent) ([]uint64, error) {
+	msgs, err := unpackMsgs(event, func() sdk.Msg {
+		return &datadealtypes.MsgSubmitConsent{}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dealIDs := make([]uint64, 0, len(msgs))
+	for _, msg := range msgs {
+		if consent := msg.(*datadealtypes.MsgSubmitConsent).Consent; consent != nil {
+			dealIDs = append(dealIDs, consent.DealId)
+		}
+	}
+	return dealIDs, nil
+}
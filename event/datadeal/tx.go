@@ -0,0 +1,99 @@
+package datadeal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// The datadeal module doesn't emit its own events, so the deal IDs are taken from the tx which emitted the event.
+
+// txData returns the tx and its result data of the event.
+func txData(event ctypes.ResultEvent) (tmtypes.EventDataTx, error) {
+	data, ok := event.Data.(tmtypes.EventDataTx)
+	if !ok {
+		return tmtypes.EventDataTx{}, fmt.Errorf("unexpected event data type: %T", event.Data)
+	}
+	return data, nil
+}
+
+// unpackMsgs returns the messages of the tx which have the type of the ones created by newMsg.
+func unpackMsgs(event ctypes.ResultEvent, newMsg func() sdk.Msg) ([]sdk.Msg, error) {
+	data, err := txData(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var txRaw txtypes.TxRaw
+	if err := proto.Unmarshal(data.Tx, &txRaw); err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+
+	var body txtypes.TxBody
+	if err := proto.Unmarshal(txRaw.BodyBytes, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode tx body: %w", err)
+	}
+
+	typeURL := sdk.MsgTypeURL(newMsg())
+
+	var msgs []sdk.Msg
+	for _, anyMsg := range body.Messages {
+		if anyMsg.TypeUrl != typeURL {
+			continue
+		}
+		msg := newMsg()
+		if err := proto.Unmarshal(anyMsg.Value, msg); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", typeURL, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// unpackMsgResponses returns the responses of the messages which have the type of msg.
+// newResponse should create an empty response of the message type.
+func unpackMsgResponses(event ctypes.ResultEvent, msg sdk.Msg, newResponse func() proto.Message) ([]proto.Message, error) {
+	data, err := txData(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgData sdk.TxMsgData
+	if err := proto.Unmarshal(data.Result.Data, &msgData); err != nil {
+		return nil, fmt.Errorf("failed to decode tx result data: %w", err)
+	}
+
+	typeURL := sdk.MsgTypeURL(msg)
+
+	var responses []proto.Message
+	for _, d := range msgData.Data {
+		if d.MsgType != typeURL {
+			continue
+		}
+		response := newResponse()
+		if err := proto.Unmarshal(d.Data, response); err != nil {
+			return nil, fmt.Errorf("failed to decode the response of %s: %w", typeURL, err)
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// dealKey returns the key of the deals, so that the events of different deals are handled concurrently.
+func dealKey(dealIDs []uint64, err error) string {
+	if err != nil {
+		return ""
+	}
+
+	keys := make([]string, len(dealIDs))
+	for i, dealID := range dealIDs {
+		keys[i] = strconv.FormatUint(dealID, 10)
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,304 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// DeadLetterDBName is the name of the sealed DB which has the events failed to be handled.
+const DeadLetterDBName = "event-dead-letter"
+
+const maxRetryInterval = time.Hour
+
+var failedEventKeyPrefix = []byte("failed/")
+
+var errEventDropped = errors.New("the failed event is dropped")
+
+// FailedEvent is an event whose handler returned an error.
+// NextRetryAt is zero if the event is not retried automatically anymore.
+type FailedEvent struct {
+	ID            string          `json:"id"`
+	EventName     string          `json:"event_name"`
+	Event         json.RawMessage `json:"event"`
+	Error         string          `json:"error"`
+	Attempts      int             `json:"attempts"`
+	FirstFailedAt time.Time       `json:"first_failed_at"`
+	LastFailedAt  time.Time       `json:"last_failed_at"`
+	NextRetryAt   time.Time       `json:"next_retry_at"`
+}
+
+// ResultEvent decodes the event delivered from Panacea.
+func (f FailedEvent) ResultEvent() (ctypes.ResultEvent, error) {
+	var result ctypes.ResultEvent
+	if err := tmjson.Unmarshal(f.Event, &result); err != nil {
+		return ctypes.ResultEvent{}, fmt.Errorf("invalid event %s: %w", f.ID, err)
+	}
+	return result, nil
+}
+
+// DeadLetterStore stores failed events.
+type DeadLetterStore interface {
+	// GetFailedEvent returns nil if there is no such event.
+	GetFailedEvent(id string) (*FailedEvent, error)
+	SetFailedEvent(event FailedEvent) error
+	DeleteFailedEvent(id string) error
+	// ListFailedEvents returns the events in the order of the first failure.
+	ListFailedEvents() ([]FailedEvent, error)
+}
+
+var _ DeadLetterStore = &DBDeadLetterStore{}
+
+// DBDeadLetterStore stores failed events in a DB, which should be sealed.
+type DBDeadLetterStore struct {
+	db dbm.DB
+}
+
+func NewDBDeadLetterStore(db dbm.DB) *DBDeadLetterStore {
+	return &DBDeadLetterStore{db: db}
+}
+
+func (s *DBDeadLetterStore) GetFailedEvent(id string) (*FailedEvent, error) {
+	bz, err := s.db.Get(failedEventKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed event %s: %w", id, err)
+	}
+	if bz == nil {
+		return nil, nil
+	}
+
+	var event FailedEvent
+	if err := json.Unmarshal(bz, &event); err != nil {
+		return nil, fmt.Errorf("invalid failed event %s: %w", id, err)
+	}
+	return &event, nil
+}
+
+func (s *DBDeadLetterStore) SetFailedEvent(event FailedEvent) error {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.SetSync(failedEventKey(event.ID), bz); err != nil {
+		return fmt.Errorf("failed to set failed event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+func (s *DBDeadLetterStore) DeleteFailedEvent(id string) error {
+	if err := s.db.DeleteSync(failedEventKey(id)); err != nil {
+		return fmt.Errorf("failed to delete failed event %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *DBDeadLetterStore) ListFailedEvents() ([]FailedEvent, error) {
+	it, err := dbm.IteratePrefix(s.db, failedEventKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var events []FailedEvent
+	for ; it.Valid(); it.Next() {
+		var event FailedEvent
+		if err := json.Unmarshal(it.Value(), &event); err != nil {
+			return nil, fmt.Errorf("invalid failed event %s: %w", it.Key(), err)
+		}
+		events = append(events, event)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].FirstFailedAt.Before(events[j].FirstFailedAt)
+	})
+	return events, nil
+}
+
+func failedEventKey(id string) []byte {
+	return append(append([]byte{}, failedEventKeyPrefix...), id...)
+}
+
+// DeadLetterManager lists, retries and drops the failed events.
+// It is the dead-letter queue of the running oracle, or an admin client connected to it.
+type DeadLetterManager interface {
+	ListFailedEvents() ([]FailedEvent, error)
+	// RetryFailedEvents schedules the events of the IDs, or all the events, to be retried right away.
+	RetryFailedEvents(ids []string, all bool) ([]FailedEvent, error)
+	// DropFailedEvents removes the events of the IDs, or all the events, without retry.
+	DropFailedEvents(ids []string, all bool) ([]FailedEvent, error)
+}
+
+var _ DeadLetterManager = &DeadLetterQueue{}
+
+// DeadLetterQueue keeps failed events and schedules their retries.
+// A failed event is retried up to maxRetries times, with the interval doubled after each failure.
+type DeadLetterQueue struct {
+	mtx        sync.Mutex
+	store      DeadLetterStore
+	maxRetries int
+	interval   time.Duration
+}
+
+func NewDeadLetterQueue(store DeadLetterStore, maxRetries int, interval time.Duration) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		store:      store,
+		maxRetries: maxRetries,
+		interval:   interval,
+	}
+}
+
+// Add stores the event which failed for the first time.
+func (q *DeadLetterQueue) Add(eventName string, result ctypes.ResultEvent, handleErr error) (FailedEvent, error) {
+	bz, err := tmjson.Marshal(result)
+	if err != nil {
+		return FailedEvent{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	id := fmt.Sprintf("%s/%d", eventName, time.Now().UnixNano())
+	if height, txHash, err := txPosition(result); err == nil {
+		id = fmt.Sprintf("%s/%d/%s", eventName, height, txHash)
+	}
+
+	event := FailedEvent{
+		ID:            id,
+		EventName:     eventName,
+		Event:         bz,
+		FirstFailedAt: time.Now(),
+	}
+	return q.Fail(event, handleErr)
+}
+
+// Fail stores the error of the last attempt and schedules the next retry.
+// If the event has been dropped while it is retried, it returns errEventDropped.
+func (q *DeadLetterQueue) Fail(event FailedEvent, handleErr error) (FailedEvent, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if event.Attempts > 0 {
+		stored, err := q.store.GetFailedEvent(event.ID)
+		if err != nil {
+			return FailedEvent{}, err
+		}
+		if stored == nil {
+			return FailedEvent{}, errEventDropped
+		}
+	}
+
+	event.Error = handleErr.Error()
+	event.Attempts++
+	event.LastFailedAt = time.Now()
+	event.NextRetryAt = time.Time{}
+
+	if retries := event.Attempts - 1; retries < q.maxRetries {
+		interval := q.interval << retries
+		if interval <= 0 || interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+		event.NextRetryAt = event.LastFailedAt.Add(interval)
+	}
+
+	if err := q.store.SetFailedEvent(event); err != nil {
+		return FailedEvent{}, err
+	}
+	return event, nil
+}
+
+// Succeed removes the event which is handled by a retry.
+func (q *DeadLetterQueue) Succeed(event FailedEvent) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	return q.store.DeleteFailedEvent(event.ID)
+}
+
+func (q *DeadLetterQueue) ListFailedEvents() ([]FailedEvent, error) {
+	return q.store.ListFailedEvents()
+}
+
+// RetryFailedEvents schedules the events to be retried right away.
+// Each of the events is retried once more, even if it has been retried as many as max retries.
+func (q *DeadLetterQueue) RetryFailedEvents(ids []string, all bool) ([]FailedEvent, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	events, err := q.failedEvents(ids, all)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range events {
+		events[i].NextRetryAt = time.Now()
+		if err := q.store.SetFailedEvent(events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+func (q *DeadLetterQueue) DropFailedEvents(ids []string, all bool) ([]FailedEvent, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	events, err := q.failedEvents(ids, all)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if err := q.store.DeleteFailedEvent(event.ID); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// failedEvents returns the events of the IDs, or all the events if all is true.
+// It should be called with mtx locked.
+func (q *DeadLetterQueue) failedEvents(ids []string, all bool) ([]FailedEvent, error) {
+	if all == (len(ids) > 0) {
+		return nil, errors.New("either event IDs or all should be given")
+	}
+	if all {
+		return q.store.ListFailedEvents()
+	}
+
+	events := make([]FailedEvent, 0, len(ids))
+	for _, id := range ids {
+		event, err := q.store.GetFailedEvent(id)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			return nil, fmt.Errorf("no failed event: %s", id)
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// Due returns the events which should be retried at the time.
+func (q *DeadLetterQueue) Due(now time.Time) ([]FailedEvent, error) {
+	events, err := q.store.ListFailedEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	var due []FailedEvent
+	for _, event := range events {
+		if !event.NextRetryAt.IsZero() && !event.NextRetryAt.After(now) {
+			due = append(due, event)
+		}
+	}
+	return due, nil
+}
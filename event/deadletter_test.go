@@ -0,0 +1,151 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	store := NewDBDeadLetterStore(dbm.NewMemDB())
+	queue := NewDeadLetterQueue(store, 2, time.Minute)
+
+	tx := tmtypes.Tx("tx1")
+	result := resultEventFromTx(testQuery, &ctypes.ResultTx{Hash: tx.Hash(), Height: 3, Tx: tx, TxResult: abci.ResponseDeliverTx{}})
+
+	failed, err := queue.Add("test", result, errors.New("broadcast failed"))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("test/3/%X", tx.Hash()), failed.ID)
+	require.Equal(t, 1, failed.Attempts)
+	require.Equal(t, "broadcast failed", failed.Error)
+	require.Equal(t, failed.LastFailedAt.Add(time.Minute), failed.NextRetryAt)
+
+	decoded, err := failed.ResultEvent()
+	require.NoError(t, err)
+	require.Equal(t, result.Events, decoded.Events)
+	require.Equal(t, []byte(tx), []byte(decoded.Data.(tmtypes.EventDataTx).Tx))
+
+	due, err := queue.Due(time.Now())
+	require.NoError(t, err)
+	require.Empty(t, due)
+	due, err = queue.Due(failed.NextRetryAt)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	// the interval is doubled after each failure
+	failed, err = queue.Fail(failed, errors.New("broadcast failed again"))
+	require.NoError(t, err)
+	require.Equal(t, 2, failed.Attempts)
+	require.Equal(t, failed.LastFailedAt.Add(2*time.Minute), failed.NextRetryAt)
+
+	// no more retry after the max retries
+	failed, err = queue.Fail(failed, errors.New("broadcast failed again"))
+	require.NoError(t, err)
+	require.Equal(t, 3, failed.Attempts)
+	require.True(t, failed.NextRetryAt.IsZero())
+	due, err = queue.Due(time.Now().Add(24 * time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	stored, err := store.GetFailedEvent(failed.ID)
+	require.NoError(t, err)
+	require.Equal(t, failed.Attempts, stored.Attempts)
+
+	require.NoError(t, queue.Succeed(failed))
+	events, err := store.ListFailedEvents()
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestDeadLetterQueueRetryAndDrop(t *testing.T) {
+	store := NewDBDeadLetterStore(dbm.NewMemDB())
+	queue := NewDeadLetterQueue(store, 0, time.Minute)
+
+	first, err := queue.Add("test", ctypes.ResultEvent{}, errors.New("failed"))
+	require.NoError(t, err)
+	require.True(t, first.NextRetryAt.IsZero())
+	_, err = queue.Add("other", ctypes.ResultEvent{}, errors.New("failed"))
+	require.NoError(t, err)
+
+	_, err = queue.RetryFailedEvents(nil, false)
+	require.Error(t, err)
+	_, err = queue.RetryFailedEvents([]string{"unknown"}, false)
+	require.ErrorContains(t, err, "no failed event")
+
+	// the event is retried once more after the max retries
+	retried, err := queue.RetryFailedEvents([]string{first.ID}, false)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	due, err := queue.Due(time.Now())
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, first.ID, due[0].ID)
+
+	// the event dropped while it is retried is not stored again
+	dropped, err := queue.DropFailedEvents(nil, true)
+	require.NoError(t, err)
+	require.Len(t, dropped, 2)
+	_, err = queue.Fail(due[0], errors.New("failed again"))
+	require.ErrorIs(t, err, errEventDropped)
+
+	events, err := queue.ListFailedEvents()
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+// flakyEvent fails to handle the first event.
+type flakyEvent struct {
+	calls   *int32
+	handled chan struct{}
+}
+
+func (e flakyEvent) Name() string {
+	return "flaky"
+}
+
+func (e flakyEvent) GetEventQuery() string {
+	return testQuery
+}
+
+func (e flakyEvent) EventHandler(_ context.Context, _ ctypes.ResultEvent) error {
+	if atomic.AddInt32(e.calls, 1) == 1 {
+		return errors.New("transient error")
+	}
+	close(e.handled)
+	return nil
+}
+
+func TestSubscriberRetryFailedEvent(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	store := NewDBDeadLetterStore(dbm.NewMemDB())
+	subscriber, err := NewSubscriber(node.wsAddr(), newTestDispatcher(t), NewDeadLetterQueue(store, 3, 10*time.Millisecond), nil, nil)
+	require.NoError(t, err)
+	subscriber.retryCheckInterval = 10 * time.Millisecond
+	defer subscriber.Close()
+
+	e := flakyEvent{calls: new(int32), handled: make(chan struct{})}
+	require.NoError(t, subscriber.Run(e))
+	waitFor(t, node.subscribed)
+	require.Eventually(t, func() bool { return subscriber.Status().Connected() }, 5*time.Second, 10*time.Millisecond)
+
+	node.publish(t, testQuery)
+	waitFor(t, e.handled)
+
+	require.Eventually(t, func() bool {
+		events, err := store.ListFailedEvents()
+		require.NoError(t, err)
+		return len(events) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 2, atomic.LoadInt32(e.calls))
+}
@@ -0,0 +1,264 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+var (
+	errHandlerTimeout = errors.New("event handler timed out")
+	errHandlerRunning = errors.New("the timed-out handler of the same key is still running")
+)
+
+// DispatcherStats is a snapshot of the queue and the handlers of the dispatcher.
+type DispatcherStats struct {
+	Workers       int
+	QueueDepth    int
+	QueueCapacity int
+	InFlight      int
+	Handlers      []HandlerStats // sorted by event name
+}
+
+// HandlerStats has the results and the latencies of a handler.
+type HandlerStats struct {
+	Event       string
+	Handled     uint64
+	Failed      uint64
+	TimedOut    uint64
+	LastLatency time.Duration
+	AvgLatency  time.Duration
+	MaxLatency  time.Duration
+
+	totalLatency time.Duration
+}
+
+type task struct {
+	event  Event
+	result ctypes.ResultEvent
+	done   func(error)
+}
+
+// Dispatcher runs event handlers with a bounded number of workers.
+// Events with the same key are handled by the same worker, so they are handled in order.
+// The key is the event name, or the event name with the key from KeyedEvent.
+type Dispatcher struct {
+	timeout time.Duration
+	queues  []chan task
+
+	mtx      sync.Mutex
+	inFlight int
+	handlers map[string]*HandlerStats
+	running  map[string]int // the number of timed-out handlers still running by key
+
+	quit chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// NewDispatcher starts the workers which share queueSize.
+// The context of a handler is cancelled after the timeout, and the worker moves on to the next event.
+// Until the timed-out handler returns, the events with the same key fail without being handled,
+// so that an event is never handled while its previous handler is still running.
+func NewDispatcher(workers, queueSize int, timeout time.Duration) (*Dispatcher, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("the number of workers should be positive: %d", workers)
+	}
+	if queueSize < workers {
+		return nil, fmt.Errorf("queue size(%d) should not be less than the number of workers(%d)", queueSize, workers)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("handler timeout should be positive: %s", timeout)
+	}
+
+	d := &Dispatcher{
+		timeout:  timeout,
+		queues:   make([]chan task, workers),
+		handlers: make(map[string]*HandlerStats),
+		running:  make(map[string]int),
+		quit:     make(chan struct{}),
+	}
+
+	for i := range d.queues {
+		d.queues[i] = make(chan task, queueSize/workers)
+		d.wg.Add(1)
+		go d.work(d.queues[i])
+	}
+
+	return d, nil
+}
+
+// Dispatch puts the event to the queue of its key. It blocks while the queue is full.
+// done is called with the result of the handler, unless the dispatcher is closed before handling the event.
+func (d *Dispatcher) Dispatch(e Event, result ctypes.ResultEvent, done func(error)) error {
+	select {
+	case d.queueOf(e, result) <- task{event: e, result: result, done: done}:
+		return nil
+	case <-d.quit:
+		return fmt.Errorf("dispatcher is closed")
+	}
+}
+
+// queueOf returns the queue of the worker which is assigned to the key of the event.
+func (d *Dispatcher) queueOf(e Event, result ctypes.ResultEvent) chan task {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(eventKey(e, result)))
+	return d.queues[h.Sum32()%uint32(len(d.queues))]
+}
+
+// eventKey returns the event name, or the event name with the key from KeyedEvent.
+func eventKey(e Event, result ctypes.ResultEvent) string {
+	key := e.Name()
+	if keyed, ok := e.(KeyedEvent); ok {
+		if k := keyed.EventKey(result); k != "" {
+			key = key + "/" + k
+		}
+	}
+	return key
+}
+
+func (d *Dispatcher) work(queue <-chan task) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case t := <-queue:
+			d.mtx.Lock()
+			d.inFlight++
+			d.mtx.Unlock()
+
+			start := time.Now()
+			err := d.handle(t.event, t.result)
+			d.record(t.event.Name(), time.Since(start), err)
+
+			if err != nil {
+				log.Errorf("failed to handle event '%s': %v", t.event.GetEventQuery(), err)
+			}
+			if t.done != nil {
+				t.done(err)
+			}
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// handle runs the handler with a context which is cancelled after the timeout.
+// If the handler doesn't return by the timeout, it is left running in background and the key is marked as running,
+// so that the worker is not held up by a handler which doesn't honor the context.
+func (d *Dispatcher) handle(e Event, result ctypes.ResultEvent) error {
+	key := eventKey(e, result)
+	if d.isRunning(key) {
+		return errHandlerRunning
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+
+	log.Infof("received event a %s", e.Name())
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer cancel()
+		errCh <- e.EventHandler(ctx, result)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s: %v", errHandlerTimeout, d.timeout, err)
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	d.setRunning(key, 1)
+	go func() {
+		err := <-errCh
+		d.setRunning(key, -1)
+		log.Warnf("the timed-out handler of event %s returned: %v", key, err)
+	}()
+
+	return fmt.Errorf("%w after %s", errHandlerTimeout, d.timeout)
+}
+
+func (d *Dispatcher) isRunning(key string) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.running[key] > 0
+}
+
+func (d *Dispatcher) setRunning(key string, delta int) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.running[key] += delta
+	if d.running[key] <= 0 {
+		delete(d.running, key)
+	}
+}
+
+func (d *Dispatcher) record(name string, latency time.Duration, err error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.inFlight--
+
+	stats, ok := d.handlers[name]
+	if !ok {
+		stats = &HandlerStats{Event: name}
+		d.handlers[name] = stats
+	}
+
+	stats.Handled++
+	if err != nil {
+		stats.Failed++
+	}
+	if errors.Is(err, errHandlerTimeout) {
+		stats.TimedOut++
+	}
+	stats.LastLatency = latency
+	stats.totalLatency += latency
+	stats.AvgLatency = stats.totalLatency / time.Duration(stats.Handled)
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+}
+
+// Stats returns the current queue depth and the statistics of handlers.
+func (d *Dispatcher) Stats() DispatcherStats {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	stats := DispatcherStats{
+		Workers:  len(d.queues),
+		InFlight: d.inFlight,
+		Handlers: make([]HandlerStats, 0, len(d.handlers)),
+	}
+	for _, queue := range d.queues {
+		stats.QueueDepth += len(queue)
+		stats.QueueCapacity += cap(queue)
+	}
+	for _, handler := range d.handlers {
+		stats.Handlers = append(stats.Handlers, *handler)
+	}
+	sort.Slice(stats.Handlers, func(i, j int) bool {
+		return stats.Handlers[i].Event < stats.Handlers[j].Event
+	})
+
+	return stats
+}
+
+// Close stops the workers after the events being handled. The queued events are discarded.
+func (d *Dispatcher) Close() {
+	d.once.Do(func() {
+		close(d.quit)
+	})
+	d.wg.Wait()
+}
@@ -0,0 +1,191 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// keyedEvent records the order of handled events, and blocks the handler of the key until it is released.
+type keyedEvent struct {
+	mtx     sync.Mutex
+	handled map[string][]string
+
+	blocked map[string]chan struct{}
+}
+
+func (e *keyedEvent) Name() string {
+	return "keyed"
+}
+
+func (e *keyedEvent) GetEventQuery() string {
+	return testQuery
+}
+
+func (e *keyedEvent) EventKey(event ctypes.ResultEvent) string {
+	return event.Events["key"][0]
+}
+
+func (e *keyedEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
+	key := e.EventKey(event)
+	if blocked, ok := e.blocked[key]; ok {
+		select {
+		case <-blocked:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.handled[key] = append(e.handled[key], event.Events["value"][0])
+	return nil
+}
+
+func (e *keyedEvent) handledOf(key string) []string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]string{}, e.handled[key]...)
+}
+
+func keyedResult(key, value string) ctypes.ResultEvent {
+	return ctypes.ResultEvent{Events: map[string][]string{"key": {key}, "value": {value}}}
+}
+
+func TestDispatcherOrderPerKey(t *testing.T) {
+	dispatcher, err := NewDispatcher(4, 40, 5*time.Second)
+	require.NoError(t, err)
+	defer dispatcher.Close()
+
+	e := &keyedEvent{
+		handled: make(map[string][]string),
+		blocked: map[string]chan struct{}{"slow": make(chan struct{})},
+	}
+
+	var wg sync.WaitGroup
+	done := func(error) { wg.Done() }
+
+	wg.Add(1)
+	require.NoError(t, dispatcher.Dispatch(e, keyedResult("slow", "1"), done))
+	for _, value := range []string{"1", "2", "3"} {
+		wg.Add(1)
+		require.NoError(t, dispatcher.Dispatch(e, keyedResult("fast", value), done))
+	}
+
+	// the events of the other key are not held up by the slow handler, unless they share the worker
+	if dispatcher.queueOf(e, keyedResult("slow", "")) != dispatcher.queueOf(e, keyedResult("fast", "")) {
+		require.Eventually(t, func() bool { return len(e.handledOf("fast")) == 3 }, 5*time.Second, 10*time.Millisecond)
+		require.Empty(t, e.handledOf("slow"))
+	}
+
+	close(e.blocked["slow"])
+	wg.Wait()
+
+	require.Equal(t, []string{"1", "2", "3"}, e.handledOf("fast"))
+	require.Equal(t, []string{"1"}, e.handledOf("slow"))
+
+	stats := dispatcher.Stats()
+	require.Equal(t, 4, stats.Workers)
+	require.Equal(t, 40, stats.QueueCapacity)
+	require.Equal(t, 0, stats.QueueDepth)
+	require.Len(t, stats.Handlers, 1)
+	require.Equal(t, "keyed", stats.Handlers[0].Event)
+	require.EqualValues(t, 4, stats.Handlers[0].Handled)
+	require.EqualValues(t, 0, stats.Handlers[0].Failed)
+	require.GreaterOrEqual(t, stats.Handlers[0].MaxLatency, stats.Handlers[0].AvgLatency)
+}
+
+func TestDispatcherHandlerTimeout(t *testing.T) {
+	dispatcher, err := NewDispatcher(1, 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	defer dispatcher.Close()
+
+	e := &keyedEvent{
+		handled: make(map[string][]string),
+		blocked: map[string]chan struct{}{"stuck": make(chan struct{})},
+	}
+
+	errCh := make(chan error, 2)
+	done := func(err error) { errCh <- err }
+	require.NoError(t, dispatcher.Dispatch(e, keyedResult("stuck", "1"), done))
+	require.NoError(t, dispatcher.Dispatch(e, keyedResult("other", "1"), done))
+
+	require.ErrorIs(t, waitFor(t, errCh), errHandlerTimeout)
+	require.NoError(t, waitFor(t, errCh))
+
+	stats := dispatcher.Stats()
+	require.EqualValues(t, 2, stats.Handlers[0].Handled)
+	require.EqualValues(t, 1, stats.Handlers[0].Failed)
+	require.EqualValues(t, 1, stats.Handlers[0].TimedOut)
+}
+
+// slowEvent is handled after its delay regardless of the context.
+type slowEvent struct {
+	delay    time.Duration
+	finished chan struct{}
+}
+
+func (e slowEvent) Name() string {
+	return "slow"
+}
+
+func (e slowEvent) GetEventQuery() string {
+	return testQuery
+}
+
+func (e slowEvent) EventHandler(ctx context.Context, _ ctypes.ResultEvent) error {
+	time.Sleep(e.delay)
+	close(e.finished)
+	return ctx.Err()
+}
+
+// TestDispatcherHandlerIgnoringContext tests that a handler which ignores its context doesn't hold up the worker,
+// and that the events of its key fail until it returns.
+func TestDispatcherHandlerIgnoringContext(t *testing.T) {
+	dispatcher, err := NewDispatcher(1, 2, 10*time.Millisecond)
+	require.NoError(t, err)
+	defer dispatcher.Close()
+
+	slow := slowEvent{delay: 300 * time.Millisecond, finished: make(chan struct{})}
+	other := &keyedEvent{handled: make(map[string][]string)}
+
+	errCh := make(chan error, 2)
+	require.NoError(t, dispatcher.Dispatch(slow, ctypes.ResultEvent{}, func(err error) {
+		select {
+		case <-slow.finished:
+			errCh <- errors.New("reported after the handler returns")
+		default:
+			errCh <- err
+		}
+	}))
+	require.NoError(t, dispatcher.Dispatch(other, keyedResult("other", "1"), func(err error) { errCh <- err }))
+
+	require.ErrorIs(t, waitFor(t, errCh), errHandlerTimeout)
+	require.NoError(t, waitFor(t, errCh))
+	require.Equal(t, []string{"1"}, other.handledOf("other"))
+
+	// the event of the same key is not handled while the timed-out handler is running
+	require.NoError(t, dispatcher.Dispatch(slow, ctypes.ResultEvent{}, func(err error) { errCh <- err }))
+	require.ErrorIs(t, waitFor(t, errCh), errHandlerRunning)
+
+	<-slow.finished
+	require.Eventually(t, func() bool { return !dispatcher.isRunning("slow") }, 5*time.Second, 10*time.Millisecond)
+
+	retried := slowEvent{finished: make(chan struct{})}
+	require.NoError(t, dispatcher.Dispatch(retried, ctypes.ResultEvent{}, func(err error) { errCh <- err }))
+	require.NoError(t, waitFor(t, errCh))
+}
+
+func TestNewDispatcherInvalid(t *testing.T) {
+	_, err := NewDispatcher(0, 10, time.Second)
+	require.Error(t, err)
+	_, err = NewDispatcher(4, 2, time.Second)
+	require.Error(t, err)
+	_, err = NewDispatcher(4, 10, 0)
+	require.Error(t, err)
+}
@@ -11,3 +11,17 @@ type Event interface {
 	GetEventQuery() string
 	EventHandler(context.Context, ctypes.ResultEvent) error
 }
+
+// KeyedEvent is an event whose handlers are ordered per key, instead of per event.
+// Events with different keys may be handled concurrently.
+type KeyedEvent interface {
+	Event
+	EventKey(ctypes.ResultEvent) string
+}
+
+// Source delivers the events from Panacea to their handlers.
+type Source interface {
+	Run(events ...Event) error
+	Status() SubscriberStatus
+	Close() error
+}
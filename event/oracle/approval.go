@@ -0,0 +1,30 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/service"
+	log "github.com/sirupsen/logrus"
+)
+
+// evaluatePolicy returns false without error if the request is denied or deferred by the approval policy.
+// If true is returned, the policy should be notified of the result of the approval by donePolicy.
+func evaluatePolicy(ctx context.Context, svc service.Service, request approval.Request) (bool, error) {
+	err := svc.ApprovalPolicy().Evaluate(ctx, request)
+	if errors.Is(err, approval.ErrDenied) || errors.Is(err, approval.ErrDeferred) {
+		log.Infof("oracle %s is not approved. unique ID(%s), target address(%s): %v", request.Kind, request.UniqueID, request.OracleAddress, err)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func donePolicy(svc service.Service, request approval.Request, approved bool) {
+	if err := svc.ApprovalPolicy().Done(request, approved); err != nil {
+		log.Warnf("failed to record the approval of oracle %s. unique ID(%s), target address(%s): %v", request.Kind, request.UniqueID, request.OracleAddress, err)
+	}
+}
@@ -26,8 +26,10 @@ func (e ApproveOracleRegistrationEvent) Name() string {
 	return "ApproveOracleRegistrationEvent"
 }
 
+// GetEventQuery queries the event of the oracle module instead of the msg action,
+// so that the approvals executed by MsgExec of authz are also caught.
 func (e ApproveOracleRegistrationEvent) GetEventQuery() string {
-	return fmt.Sprintf("message.action = 'ApproveOracleRegistration' and %s.%s = '%s' and %s.%s = '%s'",
+	return fmt.Sprintf("%s.%s = '%s' and %s.%s = '%s'",
 		oracletypes.EventTypeApproveOracleRegistration,
 		oracletypes.AttributeKeyOracleAddress,
 		e.service.OracleAcc().GetAddress(),
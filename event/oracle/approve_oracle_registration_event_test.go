@@ -11,6 +11,7 @@ import (
 	"github.com/medibloc/panacea-oracle/crypto"
 	"github.com/medibloc/panacea-oracle/event/oracle"
 	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/medibloc/panacea-oracle/sgx"
 	"github.com/stretchr/testify/suite"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 )
@@ -44,7 +45,7 @@ func (suite *approveOracleRegistrationTestSuite) TestNameAndGetEventQuery() {
 	e := oracle.NewApproveOracleRegistrationEvent(suite.Svc, suite.errChan)
 
 	suite.Require().Equal("ApproveOracleRegistrationEvent", e.Name())
-	suite.Require().Contains(e.GetEventQuery(), "message.action = 'ApproveOracleRegistration'")
+	suite.Require().NotContains(e.GetEventQuery(), "message.action")
 	suite.Require().Contains(
 		e.GetEventQuery(),
 		fmt.Sprintf("%s.%s = '%s'",
@@ -71,7 +72,7 @@ func (suite *approveOracleRegistrationTestSuite) TestEventHandler() {
 	e := oracle.NewApproveOracleRegistrationEvent(svc, errChan)
 	conf := suite.Config
 
-	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), conf.NodePrivKeyFile)
+	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), conf.NodePrivKeyFile, sgx.SealPolicyUniqueKey)
 	suite.Require().NoError(err)
 
 	sharedKey := crypto.DeriveSharedKey(suite.OraclePrivKey, suite.NodePrivKey.PubKey(), crypto.KDFSHA256)
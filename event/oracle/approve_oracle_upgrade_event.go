@@ -26,8 +26,10 @@ func (e ApproveOracleUpgradeEvent) Name() string {
 	return "ApproveOracleUpgradeEvent"
 }
 
+// GetEventQuery queries the event of the oracle module instead of the msg action,
+// so that the approvals executed by MsgExec of authz are also caught.
 func (e ApproveOracleUpgradeEvent) GetEventQuery() string {
-	return fmt.Sprintf("message.action = 'ApproveOracleUpgrade' and %s.%s = '%s' and %s.%s = '%s'",
+	return fmt.Sprintf("%s.%s = '%s' and %s.%s = '%s'",
 		oracletypes.EventTypeApproveOracleUpgrade,
 		oracletypes.AttributeKeyOracleAddress,
 		e.service.OracleAcc().GetAddress(),
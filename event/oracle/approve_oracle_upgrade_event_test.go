@@ -11,6 +11,7 @@ import (
 	"github.com/medibloc/panacea-oracle/crypto"
 	"github.com/medibloc/panacea-oracle/event/oracle"
 	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/medibloc/panacea-oracle/sgx"
 	"github.com/stretchr/testify/suite"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 )
@@ -47,7 +48,7 @@ func (suite *approveOracleUpgradeTestSuite) TestNameAndGetEventQuery() {
 	e := oracle.NewApproveOracleUpgradeEvent(suite.Svc, suite.errChan)
 
 	suite.Require().Equal("ApproveOracleUpgradeEvent", e.Name())
-	suite.Require().Contains(e.GetEventQuery(), "message.action = 'ApproveOracleUpgrade'")
+	suite.Require().NotContains(e.GetEventQuery(), "message.action")
 	suite.Require().Contains(
 		e.GetEventQuery(),
 		fmt.Sprintf("%s.%s = '%s'",
@@ -74,7 +75,7 @@ func (suite *approveOracleUpgradeTestSuite) TestEventHandler() {
 	e := oracle.NewApproveOracleUpgradeEvent(svc, errChan)
 	conf := suite.Config
 
-	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), conf.NodePrivKeyFile)
+	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), conf.NodePrivKeyFile, sgx.SealPolicyUniqueKey)
 	suite.Require().NoError(err)
 
 	nodePubKey := suite.NodePrivKey.PubKey()
@@ -16,7 +16,7 @@ func encryptOraclePrivKey(oraclePrivKey, nodePubKey []byte) ([]byte, error) {
 	}
 
 	sharedKey := crypto.DeriveSharedKey(privKey, pubKey, crypto.KDFSHA256)
-	return crypto.Encrypt(sharedKey, nil, oraclePrivKey)
+	return crypto.EncryptEnvelope(sharedKey, crypto.DefaultKeyEpoch, crypto.AdditionalData{Purpose: crypto.PurposeOraclePrivKey}, oraclePrivKey)
 }
 
 func signApprovalMsg(approvalMsg proto.Marshaler, oraclePrivKey []byte) ([]byte, error) {
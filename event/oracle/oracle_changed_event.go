@@ -0,0 +1,92 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/service"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+var _ event.KeyedEvent = (*OracleChangedEvent)(nil)
+
+// OracleChangedEvent drops the cached oracles changed by a tx, so that they are queried again.
+type OracleChangedEvent struct {
+	svc        service.Service
+	name       string
+	query      string
+	addressKey string // the event attribute of the changed oracle addresses
+	upgrade    bool
+}
+
+// NewUpdateOracleInfoEvent catches the oracles whose endpoint or commission rate is updated.
+func NewUpdateOracleInfoEvent(s service.Service) OracleChangedEvent {
+	return OracleChangedEvent{
+		svc:        s,
+		name:       "UpdateOracleInfoEvent",
+		query:      "message.action = 'UpdateOracleInfo'",
+		addressKey: "message.sender",
+	}
+}
+
+// NewOracleRegistrationApprovedEvent catches the oracles added by the approvals of their registrations.
+func NewOracleRegistrationApprovedEvent(s service.Service) OracleChangedEvent {
+	return newOracleApprovedEvent(s, "OracleRegistrationApprovedEvent", oracletypes.EventTypeApproveOracleRegistration, false)
+}
+
+// NewOracleUpgradeApprovedEvent catches the oracles whose unique IDs are upgraded by the approvals of their upgrades.
+func NewOracleUpgradeApprovedEvent(s service.Service) OracleChangedEvent {
+	return newOracleApprovedEvent(s, "OracleUpgradeApprovedEvent", oracletypes.EventTypeApproveOracleUpgrade, true)
+}
+
+func newOracleApprovedEvent(s service.Service, name, eventType string, upgrade bool) OracleChangedEvent {
+	addressKey := eventType + "." + oracletypes.AttributeKeyOracleAddress
+	return OracleChangedEvent{
+		svc:        s,
+		name:       name,
+		query:      fmt.Sprintf("%s EXISTS", addressKey),
+		addressKey: addressKey,
+		upgrade:    upgrade,
+	}
+}
+
+func (e OracleChangedEvent) Name() string {
+	return e.name
+}
+
+func (e OracleChangedEvent) GetEventQuery() string {
+	return e.query
+}
+
+// EventKey returns the changed oracle addresses, so that the events of different oracles are handled concurrently.
+func (e OracleChangedEvent) EventKey(event ctypes.ResultEvent) string {
+	return strings.Join(event.Events[e.addressKey], ",")
+}
+
+func (e OracleChangedEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
+	data, ok := event.Data.(tmtypes.EventDataTx)
+	if !ok {
+		return fmt.Errorf("unexpected event data type: %T", event.Data)
+	}
+
+	height := data.Height
+	if e.upgrade {
+		// the approved oracles are upgraded at the upgrade height, if they are approved before it.
+		upgradeInfo, err := e.svc.QueryClient().GetOracleUpgradeInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get oracle upgrade info: %w", err)
+		}
+		if upgradeInfo != nil && upgradeInfo.Height > height {
+			height = upgradeInfo.Height
+		}
+	}
+
+	for _, address := range event.Events[e.addressKey] {
+		e.svc.QueryClient().InvalidateOracle(address, height)
+	}
+	return nil
+}
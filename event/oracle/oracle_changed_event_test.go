@@ -0,0 +1,73 @@
+package oracle_test
+
+import (
+	"context"
+	"testing"
+
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/event/oracle"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/stretchr/testify/suite"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type oracleChangedEventTestSuite struct {
+	mocks.MockTestSuite
+}
+
+func TestOracleChangedEventTestSuite(t *testing.T) {
+	suite.Run(t, &oracleChangedEventTestSuite{})
+}
+
+func (suite *oracleChangedEventTestSuite) BeforeTest(_, _ string) {
+	suite.Initialize()
+	suite.QueryClient.InvalidatedOracles = make(map[string]int64)
+}
+
+func (suite *oracleChangedEventTestSuite) TestNameAndGetEventQuery() {
+	e := oracle.NewUpdateOracleInfoEvent(suite.Svc)
+	suite.Require().Equal("UpdateOracleInfoEvent", e.Name())
+	suite.Require().Equal("message.action = 'UpdateOracleInfo'", e.GetEventQuery())
+
+	e = oracle.NewOracleRegistrationApprovedEvent(suite.Svc)
+	suite.Require().Equal("OracleRegistrationApprovedEvent", e.Name())
+	suite.Require().Equal("approve_oracle_registration.oracle_address EXISTS", e.GetEventQuery())
+
+	e = oracle.NewOracleUpgradeApprovedEvent(suite.Svc)
+	suite.Require().Equal("OracleUpgradeApprovedEvent", e.Name())
+	suite.Require().Equal("approve_oracle_upgrade.oracle_address EXISTS", e.GetEventQuery())
+}
+
+func (suite *oracleChangedEventTestSuite) TestUpdateOracleInfo() {
+	e := oracle.NewUpdateOracleInfoEvent(suite.Svc)
+	resultEvent := coretypes.ResultEvent{
+		Data:   tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 10}},
+		Events: map[string][]string{"message.sender": {"panacea1first", "panacea1second"}},
+	}
+
+	suite.Require().Equal("panacea1first,panacea1second", e.EventKey(resultEvent))
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Equal(map[string]int64{"panacea1first": 10, "panacea1second": 10}, suite.QueryClient.InvalidatedOracles)
+}
+
+// TestUpgradeApproved tests that the oracle approved before the upgrade height is invalidated at the upgrade height.
+func (suite *oracleChangedEventTestSuite) TestUpgradeApproved() {
+	e := oracle.NewOracleUpgradeApprovedEvent(suite.Svc)
+	suite.QueryClient.OracleUpgradeInfo = &oracletypes.OracleUpgradeInfo{UniqueId: suite.UniqueID, Height: 20}
+
+	addressKey := oracletypes.EventTypeApproveOracleUpgrade + "." + oracletypes.AttributeKeyOracleAddress
+	resultEvent := coretypes.ResultEvent{
+		Data:   tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 10}},
+		Events: map[string][]string{addressKey: {"panacea1target"}},
+	}
+
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Equal(int64(20), suite.QueryClient.InvalidatedOracles["panacea1target"])
+
+	// the oracle approved after the upgrade height is upgraded right away
+	resultEvent.Data = tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 30}}
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Equal(int64(30), suite.QueryClient.InvalidatedOracles["panacea1target"])
+}
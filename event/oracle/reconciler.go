@@ -0,0 +1,216 @@
+package oracle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/event"
+	"github.com/medibloc/panacea-oracle/service"
+	log "github.com/sirupsen/logrus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// Reconciler approves the oracle registrations and upgrades which are still not approved,
+// in case that their events have been missed.
+// The candidates are listed via gRPC, but each of them is verified by the light client before approval.
+// The approvals run through the dispatcher with the same keys as the events, so they are ordered with the events of the same oracle.
+type Reconciler struct {
+	svc      service.Service
+	register RegisterOracleEvent
+	upgrade  UpgradeOracleEvent
+	interval time.Duration
+
+	quit chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// ReconcileResult is the number of registrations and upgrades approved or failed by a reconciliation.
+type ReconcileResult struct {
+	Approved int
+	Failed   int
+}
+
+func NewReconciler(svc service.Service, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		svc:      svc,
+		register: NewRegisterOracleEvent(svc),
+		upgrade:  NewUpgradeOracleEvent(svc),
+		interval: interval,
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start reconciles right away, and then periodically in background.
+func (r *Reconciler) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.reconcile()
+
+			select {
+			case <-ticker.C:
+			case <-r.quit:
+				return
+			}
+		}
+	}()
+}
+
+func (r *Reconciler) Close() {
+	r.once.Do(func() {
+		close(r.quit)
+	})
+	r.wg.Wait()
+}
+
+func (r *Reconciler) reconcile() {
+	ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+	defer cancel()
+
+	registrations := r.ReconcileRegistrations(ctx)
+	upgrades := r.ReconcileUpgrades(ctx)
+
+	log.Infof("reconciliation is done. registrations: approved(%d) failed(%d), upgrades: approved(%d) failed(%d)",
+		registrations.Approved, registrations.Failed, upgrades.Approved, upgrades.Failed)
+}
+
+// ReconcileRegistrations approves the registrations with the same unique ID as this oracle, which are not approved yet.
+func (r *Reconciler) ReconcileRegistrations(ctx context.Context) ReconcileResult {
+	uniqueID := r.svc.EnclaveInfo().UniqueIDHex()
+	registrations, err := r.svc.GRPCClient().GetOracleRegistrations(uniqueID)
+	if err != nil {
+		log.Errorf("failed to list oracle registrations: %v", err)
+		return ReconcileResult{}
+	}
+
+	var candidates []reconcileCandidate
+	for _, registration := range registrations {
+		if len(registration.EncryptedOraclePrivKey) > 0 {
+			continue
+		}
+
+		register := r.register
+		uniqueID, targetAddress := registration.UniqueId, registration.OracleAddress
+		candidates = append(candidates, reconcileCandidate{
+			event:  r.register,
+			result: approvalTargetEvent(oracletypes.EventTypeRegistration, uniqueID, targetAddress),
+			approve: func(ctx context.Context) (bool, error) {
+				return register.approve(ctx, uniqueID, targetAddress)
+			},
+		})
+	}
+
+	return r.dispatch(ctx, "oracle registration", candidates)
+}
+
+// ReconcileUpgrades approves the upgrades to the unique ID of the current upgrade info, which are not approved yet.
+func (r *Reconciler) ReconcileUpgrades(ctx context.Context) ReconcileResult {
+	upgradeInfo, err := r.svc.QueryClient().GetOracleUpgradeInfo(ctx)
+	if err != nil || upgradeInfo == nil {
+		log.Debugf("no oracle upgrade info to reconcile: %v", err)
+		return ReconcileResult{}
+	}
+
+	upgrades, err := r.svc.GRPCClient().GetOracleUpgrades(upgradeInfo.UniqueId)
+	if err != nil {
+		log.Errorf("failed to list oracle upgrades: %v", err)
+		return ReconcileResult{}
+	}
+
+	var candidates []reconcileCandidate
+	for _, upgrade := range upgrades {
+		if len(upgrade.EncryptedOraclePrivKey) > 0 {
+			continue
+		}
+
+		upgradeEvent := r.upgrade
+		uniqueID, targetAddress := upgrade.UniqueId, upgrade.OracleAddress
+		candidates = append(candidates, reconcileCandidate{
+			event:  r.upgrade,
+			result: approvalTargetEvent(oracletypes.EventTypeUpgrade, uniqueID, targetAddress),
+			approve: func(ctx context.Context) (bool, error) {
+				return upgradeEvent.approve(ctx, uniqueID, targetAddress)
+			},
+		})
+	}
+
+	return r.dispatch(ctx, "oracle upgrade", candidates)
+}
+
+// reconcileCandidate is a registration or an upgrade to be approved by the reconciler.
+type reconcileCandidate struct {
+	event   event.KeyedEvent
+	result  ctypes.ResultEvent
+	approve func(context.Context) (bool, error)
+}
+
+// reconcileEvent has the same name and key as the event of its candidate,
+// so that it is handled in order with the events of the same target oracle.
+type reconcileEvent struct {
+	event.KeyedEvent
+	approve  func(context.Context) (bool, error)
+	approved *bool
+}
+
+func (e reconcileEvent) EventHandler(ctx context.Context, _ ctypes.ResultEvent) error {
+	approved, err := e.approve(ctx)
+	*e.approved = approved
+	return err
+}
+
+// approvalTargetEvent returns an event which has the attributes of the target oracle, like the event emitted by Panacea.
+func approvalTargetEvent(eventType, uniqueID, targetAddress string) ctypes.ResultEvent {
+	return ctypes.ResultEvent{
+		Events: map[string][]string{
+			eventType + "." + oracletypes.AttributeKeyUniqueID:      {uniqueID},
+			eventType + "." + oracletypes.AttributeKeyOracleAddress: {targetAddress},
+		},
+	}
+}
+
+// dispatch approves the candidates through the dispatcher of the events, and waits for their results.
+func (r *Reconciler) dispatch(ctx context.Context, kind string, candidates []reconcileCandidate) ReconcileResult {
+	var result ReconcileResult
+
+	approved := make([]bool, len(candidates))
+	errChs := make([]chan error, len(candidates))
+	for i, candidate := range candidates {
+		errCh := make(chan error, 1)
+		e := reconcileEvent{KeyedEvent: candidate.event, approve: candidate.approve, approved: &approved[i]}
+		if err := r.svc.Dispatcher().Dispatch(e, candidate.result, func(err error) { errCh <- err }); err != nil {
+			log.Errorf("failed to dispatch %s to reconcile: %v", kind, err)
+			result.Failed++
+			continue
+		}
+		errChs[i] = errCh
+	}
+
+	for i, errCh := range errChs {
+		if errCh == nil {
+			continue
+		}
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				log.Errorf("failed to reconcile %s: %v", kind, err)
+				result.Failed++
+			} else if approved[i] {
+				result.Approved++
+			}
+		case <-ctx.Done():
+			log.Errorf("failed to reconcile %s: %v", kind, ctx.Err())
+			result.Failed++
+		}
+	}
+
+	return result
+}
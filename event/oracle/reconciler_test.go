@@ -0,0 +1,127 @@
+package oracle_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/event/oracle"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/medibloc/panacea-oracle/panacea"
+	"github.com/stretchr/testify/suite"
+)
+
+type reconcilerTestSuite struct {
+	mocks.MockTestSuite
+
+	targetOracleAcc *panacea.OracleAccount
+}
+
+func TestReconcilerTestSuite(t *testing.T) {
+	suite.Run(t, &reconcilerTestSuite{})
+}
+
+func (suite *reconcilerTestSuite) BeforeTest(_, _ string) {
+	suite.Initialize()
+
+	targetMnemonic, _ := crypto.NewMnemonic()
+	suite.targetOracleAcc, _ = panacea.NewOracleAccount(targetMnemonic, 0, 0)
+
+	suite.Svc.SetBroadcastTxResponse(0, "", nil)
+}
+
+// TestReconcileRegistrations tests that only the registrations not approved yet are approved.
+func (suite *reconcilerTestSuite) TestReconcileRegistrations() {
+	suite.GrpcClient.OracleRegistrations = []*oracletypes.OracleRegistration{
+		{UniqueId: suite.UniqueID, OracleAddress: suite.targetOracleAcc.GetAddress()},
+		{UniqueId: suite.UniqueID, OracleAddress: suite.OracleAcc.GetAddress(), EncryptedOraclePrivKey: []byte("approved")},
+	}
+	suite.QueryClient.OracleRegistration = &oracletypes.OracleRegistration{
+		UniqueId:      suite.UniqueID,
+		OracleAddress: suite.targetOracleAcc.GetAddress(),
+		NodePubKey:    suite.NodePrivKey.PubKey().SerializeCompressed(),
+	}
+
+	reconciler := oracle.NewReconciler(suite.Svc, time.Minute)
+	result := reconciler.ReconcileRegistrations(context.Background())
+	suite.Require().Equal(oracle.ReconcileResult{Approved: 1}, result)
+
+	txMsgs := suite.Svc.BroadCastTxMsgs()
+	suite.Require().Len(txMsgs, 1)
+	approvalMsg := txMsgs[0].(*oracletypes.MsgApproveOracleRegistration).ApprovalSharingOracleKey
+	suite.Require().Equal(suite.targetOracleAcc.GetAddress(), approvalMsg.TargetOracleAddress)
+}
+
+// TestReconcileRegistrationsApprovedMeanwhile tests that the registration approved by another oracle is skipped,
+// even if it is not approved in the listed one.
+func (suite *reconcilerTestSuite) TestReconcileRegistrationsApprovedMeanwhile() {
+	suite.GrpcClient.OracleRegistrations = []*oracletypes.OracleRegistration{
+		{UniqueId: suite.UniqueID, OracleAddress: suite.targetOracleAcc.GetAddress()},
+	}
+	suite.QueryClient.OracleRegistration = &oracletypes.OracleRegistration{
+		UniqueId:               suite.UniqueID,
+		OracleAddress:          suite.targetOracleAcc.GetAddress(),
+		EncryptedOraclePrivKey: []byte("approved"),
+	}
+
+	reconciler := oracle.NewReconciler(suite.Svc, time.Minute)
+	result := reconciler.ReconcileRegistrations(context.Background())
+	suite.Require().Equal(oracle.ReconcileResult{}, result)
+	suite.Require().Empty(suite.Svc.BroadCastTxMsgs())
+}
+
+// TestReconcileRegistrationsInvalid tests that the registration which fails the verification is not approved.
+func (suite *reconcilerTestSuite) TestReconcileRegistrationsInvalid() {
+	suite.GrpcClient.OracleRegistrations = []*oracletypes.OracleRegistration{
+		{UniqueId: suite.UniqueID, OracleAddress: suite.targetOracleAcc.GetAddress()},
+	}
+	suite.QueryClient.OracleRegistration = &oracletypes.OracleRegistration{
+		UniqueId:      suite.UniqueID,
+		OracleAddress: suite.targetOracleAcc.GetAddress(),
+		NodePubKey:    suite.NodePrivKey.PubKey().SerializeCompressed(),
+	}
+	suite.QueryClient.VerifyTrustedBlockInfoError = fmt.Errorf("invalid block")
+
+	reconciler := oracle.NewReconciler(suite.Svc, time.Minute)
+	result := reconciler.ReconcileRegistrations(context.Background())
+	suite.Require().Equal(oracle.ReconcileResult{Failed: 1}, result)
+	suite.Require().Empty(suite.Svc.BroadCastTxMsgs())
+}
+
+// TestReconcileUpgrades tests that the upgrades to the unique ID of the upgrade info are approved.
+func (suite *reconcilerTestSuite) TestReconcileUpgrades() {
+	suite.GrpcClient.OracleUpgrades = []*oracletypes.OracleUpgrade{
+		{UniqueId: suite.UniqueID, OracleAddress: suite.targetOracleAcc.GetAddress()},
+	}
+	suite.QueryClient.Oracle = &oracletypes.Oracle{}
+	suite.QueryClient.OracleUpgradeInfo = &oracletypes.OracleUpgradeInfo{UniqueId: suite.UniqueID}
+	suite.QueryClient.OracleUpgrade = &oracletypes.OracleUpgrade{
+		UniqueId:      suite.UniqueID,
+		OracleAddress: suite.targetOracleAcc.GetAddress(),
+		NodePubKey:    suite.NodePrivKey.PubKey().SerializeCompressed(),
+	}
+
+	reconciler := oracle.NewReconciler(suite.Svc, time.Minute)
+	result := reconciler.ReconcileUpgrades(context.Background())
+	suite.Require().Equal(oracle.ReconcileResult{Approved: 1}, result)
+
+	txMsgs := suite.Svc.BroadCastTxMsgs()
+	suite.Require().Len(txMsgs, 1)
+	approvalMsg := txMsgs[0].(*oracletypes.MsgApproveOracleUpgrade).ApprovalSharingOracleKey
+	suite.Require().Equal(suite.targetOracleAcc.GetAddress(), approvalMsg.TargetOracleAddress)
+}
+
+// TestReconcileUpgradesNoUpgradeInfo tests that no upgrade is approved if there is no upgrade info.
+func (suite *reconcilerTestSuite) TestReconcileUpgradesNoUpgradeInfo() {
+	suite.GrpcClient.OracleUpgrades = []*oracletypes.OracleUpgrade{
+		{UniqueId: suite.UniqueID, OracleAddress: suite.targetOracleAcc.GetAddress()},
+	}
+
+	reconciler := oracle.NewReconciler(suite.Svc, time.Minute)
+	result := reconciler.ReconcileUpgrades(context.Background())
+	suite.Require().Equal(oracle.ReconcileResult{}, result)
+	suite.Require().Empty(suite.Svc.BroadCastTxMsgs())
+}
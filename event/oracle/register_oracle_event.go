@@ -6,13 +6,14 @@ import (
 	"fmt"
 
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/service"
 	log "github.com/sirupsen/logrus"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-var _ event.Event = (*RegisterOracleEvent)(nil)
+var _ event.KeyedEvent = (*RegisterOracleEvent)(nil)
 
 type RegisterOracleEvent struct {
 	svc service.Service
@@ -26,29 +27,68 @@ func (e RegisterOracleEvent) Name() string {
 	return "RegisterOracleEvent"
 }
 
+// GetEventQuery queries the event of the oracle module instead of the msg action,
+// so that the registrations executed by MsgExec of authz are also caught.
 func (e RegisterOracleEvent) GetEventQuery() string {
-	return "message.action = 'RegisterOracle'"
+	return fmt.Sprintf("%s.%s EXISTS", oracletypes.EventTypeRegistration, oracletypes.AttributeKeyUniqueID)
+}
+
+// EventKey returns the target oracle address, so that the events of different oracles are handled concurrently.
+func (e RegisterOracleEvent) EventKey(event ctypes.ResultEvent) string {
+	addresses := event.Events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyOracleAddress]
+	if len(addresses) == 0 {
+		return ""
+	}
+	return addresses[0]
 }
 
 func (e RegisterOracleEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
 	uniqueID := event.Events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyUniqueID][0]
 	targetAddress := event.Events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyOracleAddress][0]
 
+	_, err := e.approve(ctx, uniqueID, targetAddress)
+	return err
+}
+
+// approve verifies the oracle registration and broadcasts its approval.
+// It returns false without error if the registration has been approved already or by another oracle while waiting for the turn,
+// or if it is not approved by the approval policy for now.
+func (e RegisterOracleEvent) approve(ctx context.Context, uniqueID, targetAddress string) (bool, error) {
 	// get oracle registration
 	oracleRegistration, err := e.svc.QueryClient().GetOracleRegistration(ctx, uniqueID, targetAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get oracle registration. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+		return false, fmt.Errorf("failed to get oracle registration. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+
+	if len(oracleRegistration.EncryptedOraclePrivKey) > 0 {
+		log.Infof("oracle registration is already approved. unique ID(%s), target address(%s)", uniqueID, targetAddress)
+		return false, nil
 	}
 
 	// verify oracle registration
 	if err := e.verifyOracleRegistration(oracleRegistration, uniqueID); err != nil {
-		return fmt.Errorf("failed to verify oracle registration. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+		return false, fmt.Errorf("failed to verify oracle registration. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+
+	// evaluate the policy first, so that this oracle doesn't wait for its turn only to deny or defer the request
+	request := approval.NewRequest(approval.KindRegistration, uniqueID, targetAddress)
+	if ok, err := evaluatePolicy(ctx, e.svc, request); !ok {
+		return false, err
+	}
+
+	// wait for the turn of this oracle, unless this oracle is the leader
+	if ok, err := e.svc.ApprovalCoordinator().Wait(ctx, request, func(ctx context.Context) (bool, error) {
+		return e.isApproved(ctx, uniqueID, targetAddress)
+	}); !ok {
+		donePolicy(e.svc, request, false)
+		return false, err
 	}
 
 	// generate Msg/ApproveOracleRegistration
 	msgApproveOracleRegistration, err := e.generateApproveOracleRegistrationMsg(oracleRegistration, uniqueID, targetAddress)
 	if err != nil {
-		return fmt.Errorf("failed to generate MsgApproveOracleRegistration: %w", err)
+		donePolicy(e.svc, request, false)
+		return false, fmt.Errorf("failed to generate MsgApproveOracleRegistration: %w", err)
 	}
 
 	log.Infof("new oracle registration approval info. unique ID(%s), approver address(%s), target address(%s)",
@@ -57,14 +97,24 @@ func (e RegisterOracleEvent) EventHandler(ctx context.Context, event ctypes.Resu
 		msgApproveOracleRegistration.ApprovalSharingOracleKey.TargetOracleAddress,
 	)
 
-	txHeight, txHash, err := e.svc.BroadcastTx(msgApproveOracleRegistration)
+	txHeight, txHash, err := e.svc.BroadcastTx(ctx, msgApproveOracleRegistration)
+	donePolicy(e.svc, request, err == nil)
 	if err != nil {
-		return fmt.Errorf("failed to ApproveOracleRegistration transaction for new oracle registration: %w", err)
+		return false, fmt.Errorf("failed to ApproveOracleRegistration transaction for new oracle registration: %w", err)
 	}
 
 	log.Infof("succeeded to ApproveOracleRegistration transaction for new oracle registration. height(%d), hash(%s)", txHeight, txHash)
 
-	return nil
+	return true, nil
+}
+
+// isApproved returns true if the oracle registration has been approved by any oracle.
+func (e RegisterOracleEvent) isApproved(ctx context.Context, uniqueID, targetAddress string) (bool, error) {
+	oracleRegistration, err := e.svc.QueryClient().GetOracleRegistration(ctx, uniqueID, targetAddress)
+	if err != nil {
+		return false, fmt.Errorf("failed to get oracle registration. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+	return len(oracleRegistration.EncryptedOraclePrivKey) > 0, nil
 }
 
 func (e RegisterOracleEvent) verifyOracleRegistration(oracleRegistration *oracletypes.OracleRegistration, uniqueID string) error {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/crypto"
 	"github.com/medibloc/panacea-oracle/event/oracle"
 	"github.com/medibloc/panacea-oracle/mocks"
@@ -38,7 +39,7 @@ func (suite *registerOracleEventTestSuite) TestNameAndGetEventQuery() {
 	e := oracle.NewRegisterOracleEvent(suite.Svc)
 
 	suite.Require().Equal("RegisterOracleEvent", e.Name())
-	suite.Require().Contains(e.GetEventQuery(), "message.action = 'RegisterOracle'")
+	suite.Require().Equal("oracle_registration.unique_id EXISTS", e.GetEventQuery())
 }
 
 // TestEventHandler tests that the EventHandler function behavior succeeds.
@@ -72,7 +73,7 @@ func (suite *registerOracleEventTestSuite) TestEventHandler() {
 	// Decrypt OraclePrivateKey encrypted with NodePrivateKey and OraclePublicKey
 	sharedKey := crypto.DeriveSharedKey(suite.NodePrivKey, suite.OraclePrivKey.PubKey(), crypto.KDFSHA256)
 	encryptedOraclePrivKey := approvalMsg.EncryptedOraclePrivKey
-	decryptedOraclePrivKey, err := crypto.Decrypt(sharedKey, nil, encryptedOraclePrivKey)
+	decryptedOraclePrivKey, err := crypto.DecryptEnvelope(sharedKey, crypto.AdditionalData{Purpose: crypto.PurposeOraclePrivKey}, encryptedOraclePrivKey)
 	suite.Require().NoError(err)
 	suite.Require().Equal(suite.OraclePrivKey.Serialize(), decryptedOraclePrivKey)
 }
@@ -154,3 +155,67 @@ func (suite *registerOracleEventTestSuite) TestEventHandlerFailedBroadcast() {
 	err := e.EventHandler(context.Background(), resultEvent)
 	suite.Require().ErrorContains(err, "failed to ApproveOracleRegistration transaction for new oracle registration")
 }
+
+// TestEventHandlerManualApproval tests that the registration is approved only after it is approved manually.
+func (suite *registerOracleEventTestSuite) TestEventHandlerManualApproval() {
+	approvalConf := suite.Config.Approval
+	approvalConf.Mode = "manual"
+	suite.Require().NoError(suite.Svc.SetApprovalConfig(approvalConf))
+
+	e := oracle.NewRegisterOracleEvent(suite.Svc)
+
+	events := make(map[string][]string)
+	events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyUniqueID] = []string{suite.UniqueID}
+	events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyOracleAddress] = []string{suite.targetOracleAcc.GetAddress()}
+
+	resultEvent := coretypes.ResultEvent{
+		Events: events,
+	}
+
+	suite.QueryClient.OracleRegistration.NodePubKey = suite.NodePrivKey.PubKey().SerializeCompressed()
+	suite.Svc.SetBroadcastTxResponse(0, "", nil)
+
+	// the registration is queued without error
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Len(suite.Svc.BroadCastTxMsgs(), 0)
+
+	store := suite.Svc.ApprovalStore()
+	requests, err := store.ListRequests()
+	suite.Require().NoError(err)
+	suite.Require().Len(requests, 1)
+	suite.Require().Equal(approval.KindRegistration, requests[0].Kind)
+	suite.Require().Equal(suite.targetOracleAcc.GetAddress(), requests[0].OracleAddress)
+
+	requests[0].Status = approval.StatusApproved
+	suite.Require().NoError(store.SetRequest(requests[0]))
+
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Len(suite.Svc.BroadCastTxMsgs(), 1)
+
+	requests, err = store.ListRequests()
+	suite.Require().NoError(err)
+	suite.Require().Len(requests, 0)
+}
+
+// TestEventHandlerDenied tests that the registration denied by the approval policy is not approved.
+func (suite *registerOracleEventTestSuite) TestEventHandlerDenied() {
+	approvalConf := suite.Config.Approval
+	approvalConf.Denylist = []string{suite.targetOracleAcc.GetAddress()}
+	suite.Require().NoError(suite.Svc.SetApprovalConfig(approvalConf))
+
+	e := oracle.NewRegisterOracleEvent(suite.Svc)
+
+	events := make(map[string][]string)
+	events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyUniqueID] = []string{suite.UniqueID}
+	events[oracletypes.EventTypeRegistration+"."+oracletypes.AttributeKeyOracleAddress] = []string{suite.targetOracleAcc.GetAddress()}
+
+	resultEvent := coretypes.ResultEvent{
+		Events: events,
+	}
+
+	suite.QueryClient.OracleRegistration.NodePubKey = suite.NodePrivKey.PubKey().SerializeCompressed()
+	suite.Svc.SetBroadcastTxResponse(0, "", nil)
+
+	suite.Require().NoError(e.EventHandler(context.Background(), resultEvent))
+	suite.Require().Len(suite.Svc.BroadCastTxMsgs(), 0)
+}
@@ -7,13 +7,14 @@ import (
 	"fmt"
 
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/service"
 	log "github.com/sirupsen/logrus"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-var _ event.Event = (*UpgradeOracleEvent)(nil)
+var _ event.KeyedEvent = (*UpgradeOracleEvent)(nil)
 
 type UpgradeOracleEvent struct {
 	svc service.Service
@@ -27,29 +28,68 @@ func (e UpgradeOracleEvent) Name() string {
 	return "UpgradeOracleEvent"
 }
 
+// GetEventQuery queries the event of the oracle module instead of the msg action,
+// so that the upgrades executed by MsgExec of authz are also caught.
 func (e UpgradeOracleEvent) GetEventQuery() string {
-	return "message.action = 'UpgradeOracle'"
+	return fmt.Sprintf("%s.%s EXISTS", oracletypes.EventTypeUpgrade, oracletypes.AttributeKeyUniqueID)
+}
+
+// EventKey returns the target oracle address, so that the events of different oracles are handled concurrently.
+func (e UpgradeOracleEvent) EventKey(event ctypes.ResultEvent) string {
+	addresses := event.Events[oracletypes.EventTypeUpgrade+"."+oracletypes.AttributeKeyOracleAddress]
+	if len(addresses) == 0 {
+		return ""
+	}
+	return addresses[0]
 }
 
 func (e UpgradeOracleEvent) EventHandler(ctx context.Context, event ctypes.ResultEvent) error {
 	uniqueID := event.Events[oracletypes.EventTypeUpgrade+"."+oracletypes.AttributeKeyUniqueID][0]
 	targetAddress := event.Events[oracletypes.EventTypeUpgrade+"."+oracletypes.AttributeKeyOracleAddress][0]
 
+	_, err := e.approve(ctx, uniqueID, targetAddress)
+	return err
+}
+
+// approve verifies the oracle upgrade and broadcasts its approval.
+// It returns false without error if the upgrade has been approved already or by another oracle while waiting for the turn,
+// or if it is not approved by the approval policy for now.
+func (e UpgradeOracleEvent) approve(ctx context.Context, uniqueID, targetAddress string) (bool, error) {
 	// get oracle upgrade
 	oracleUpgrade, err := e.svc.QueryClient().GetOracleUpgrade(ctx, uniqueID, targetAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get oracle upgrade. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+		return false, fmt.Errorf("failed to get oracle upgrade. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+
+	if len(oracleUpgrade.EncryptedOraclePrivKey) > 0 {
+		log.Infof("oracle upgrade is already approved. unique ID(%s), target address(%s)", uniqueID, targetAddress)
+		return false, nil
 	}
 
 	// verify oracle upgrade
 	if err := e.verifyOracleUpgrade(ctx, oracleUpgrade, uniqueID, targetAddress); err != nil {
-		return fmt.Errorf("failed to verify oracle upgrade. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+		return false, fmt.Errorf("failed to verify oracle upgrade. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+
+	// evaluate the policy first, so that this oracle doesn't wait for its turn only to deny or defer the request
+	request := approval.NewRequest(approval.KindUpgrade, uniqueID, targetAddress)
+	if ok, err := evaluatePolicy(ctx, e.svc, request); !ok {
+		return false, err
+	}
+
+	// wait for the turn of this oracle, unless this oracle is the leader
+	if ok, err := e.svc.ApprovalCoordinator().Wait(ctx, request, func(ctx context.Context) (bool, error) {
+		return e.isApproved(ctx, uniqueID, targetAddress)
+	}); !ok {
+		donePolicy(e.svc, request, false)
+		return false, err
 	}
 
 	// generate Msg/ApproveOracleUpgrade
 	msgApproveOracleUpgrade, err := e.generateApproveOracleUpgradeMsg(oracleUpgrade, uniqueID, targetAddress)
 	if err != nil {
-		return fmt.Errorf("failed to generate MsgApproveOracleUpgrade: %w", err)
+		donePolicy(e.svc, request, false)
+		return false, fmt.Errorf("failed to generate MsgApproveOracleUpgrade: %w", err)
 	}
 
 	log.Infof("oracle upgrade approval info. unique ID(%s), approver address(%s), target address(%s)",
@@ -58,14 +98,24 @@ func (e UpgradeOracleEvent) EventHandler(ctx context.Context, event ctypes.Resul
 		msgApproveOracleUpgrade.ApprovalSharingOracleKey.TargetOracleAddress,
 	)
 
-	txHeight, txHash, err := e.svc.BroadcastTx(msgApproveOracleUpgrade)
+	txHeight, txHash, err := e.svc.BroadcastTx(ctx, msgApproveOracleUpgrade)
+	donePolicy(e.svc, request, err == nil)
 	if err != nil {
-		return fmt.Errorf("failed to ApproveOracleUpgrade transaction for oracle upgrade: %w", err)
+		return false, fmt.Errorf("failed to ApproveOracleUpgrade transaction for oracle upgrade: %w", err)
 	}
 
 	log.Infof("succeeded to ApproveOracleUpgrae transaction for oracle upgrade. height(%d), hash(%s)", txHeight, txHash)
 
-	return nil
+	return true, nil
+}
+
+// isApproved returns true if the oracle upgrade has been approved by any oracle.
+func (e UpgradeOracleEvent) isApproved(ctx context.Context, uniqueID, targetAddress string) (bool, error) {
+	oracleUpgrade, err := e.svc.QueryClient().GetOracleUpgrade(ctx, uniqueID, targetAddress)
+	if err != nil {
+		return false, fmt.Errorf("failed to get oracle upgrade. unique ID(%s), target address(%s): %w", uniqueID, targetAddress, err)
+	}
+	return len(oracleUpgrade.EncryptedOraclePrivKey) > 0, nil
 }
 
 func (e UpgradeOracleEvent) verifyOracleUpgrade(ctx context.Context, oracleUpgrade *oracletypes.OracleUpgrade, uniqueID, targetAddress string) error {
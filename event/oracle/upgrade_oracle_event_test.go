@@ -41,7 +41,7 @@ func (suite *upgradeOracleEventTestSuite) TestNameAndGetEventQuery() {
 	e := oracle.NewUpgradeOracleEvent(suite.Svc)
 
 	suite.Require().Equal("UpgradeOracleEvent", e.Name())
-	suite.Require().Contains(e.GetEventQuery(), "message.action = 'UpgradeOracle'")
+	suite.Require().Equal("oracle_upgrade.unique_id EXISTS", e.GetEventQuery())
 }
 
 // TestEventHandler tests that the EventHandler function behavior succeeds.
@@ -76,7 +76,7 @@ func (suite *upgradeOracleEventTestSuite) TestEventHandler() {
 	// Decrypt OraclePrivateKey encrypted with NodePrivateKey and OraclePublicKey
 	sharedKey := crypto.DeriveSharedKey(suite.NodePrivKey, suite.OraclePrivKey.PubKey(), crypto.KDFSHA256)
 	encryptedOraclePrivKey := approvalMsg.EncryptedOraclePrivKey
-	decryptedOraclePrivKey, err := crypto.Decrypt(sharedKey, nil, encryptedOraclePrivKey)
+	decryptedOraclePrivKey, err := crypto.DecryptEnvelope(sharedKey, crypto.AdditionalData{Purpose: crypto.PurposeOraclePrivKey}, encryptedOraclePrivKey)
 	suite.Require().NoError(err)
 	suite.Require().Equal(suite.OraclePrivKey.Serialize(), decryptedOraclePrivKey)
 }
@@ -0,0 +1,160 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// PanaceaPoller polls new blocks from Panacea via RPC at an interval, instead of subscribing events via websocket.
+// At each poll, the latest header is verified by the light client, and the txs matched with the event queries
+// in the new blocks are searched with proofs and confirmed by the light client.
+// Then, the events are handled, retried and checkpointed in the same way as PanaceaSubscriber.
+type PanaceaPoller struct {
+	subscriber *PanaceaSubscriber
+	interval   time.Duration
+
+	nextHeight int64 // the lowest height which is not polled yet. 0 before the first poll.
+}
+
+// NewPoller generates a poller with RPC address. The polling is started by Run.
+// The dispatcher is closed when the poller is closed.
+// If deadLetters is nil, failed events are dropped.
+// If checkpoints is nil, the events emitted before the first poll are not replayed.
+func NewPoller(rpcAddr string, interval time.Duration, dispatcher *Dispatcher, deadLetters *DeadLetterQueue, checkpoints CheckpointStore, lightClient LightBlockGetter) (*PanaceaPoller, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("poll interval should be positive")
+	}
+
+	searcher, err := rpchttp.New(rpcAddr, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PanaceaPoller{
+		subscriber: newSubscriber(rpcAddr, searcher, dispatcher, deadLetters, checkpoints, lightClient),
+		interval:   interval,
+	}, nil
+}
+
+// Run registers the events and starts to poll them in background.
+func (p *PanaceaPoller) Run(events ...Event) error {
+	log.Infof("Panacea event poller is started. interval: %s", p.interval)
+
+	if err := p.subscriber.register(events); err != nil {
+		return err
+	}
+	p.subscriber.start(p.pollLoop)
+
+	return nil
+}
+
+// Status returns the state of the last poll and the statistics of the dispatcher.
+// The poller is regarded as connected while the polls succeed.
+func (p *PanaceaPoller) Status() SubscriberStatus {
+	return p.subscriber.Status()
+}
+
+func (p *PanaceaPoller) Close() error {
+	log.Infof("closing Panacea event poller")
+	return p.subscriber.Close()
+}
+
+// pollLoop polls new blocks at the interval until the poller is closed.
+func (p *PanaceaPoller) pollLoop() {
+	s := p.subscriber
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	state := StateConnecting
+	for {
+		if state == StateDisconnected {
+			s.setState(StateConnecting, nil)
+		}
+
+		closed, err := p.poll()
+		if closed {
+			return
+		}
+		if err != nil {
+			log.Errorf("failed to poll Panacea: %v. retry after %s", err, p.interval)
+			state = StateDisconnected
+			s.setState(state, err)
+		} else if state != StateConnected {
+			log.Infof("polling Panacea: %s", s.wsAddr)
+			state = StateConnected
+			s.setState(state, nil)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// poll dispatches the events in the blocks from nextHeight to the latest one.
+// At the first poll, only the events emitted after their checkpoints are replayed.
+// If it fails, the same blocks are polled again at the next poll, and the events dispatched already are skipped by their checkpoints.
+// It returns true if the dispatcher is closed.
+func (p *PanaceaPoller) poll() (bool, error) {
+	s := p.subscriber
+
+	ctx, cancel := context.WithTimeout(context.Background(), catchUpTimeout)
+	defer cancel()
+
+	latestHeight, err := s.latestHeight(ctx)
+	if err != nil {
+		return false, err
+	}
+	if latestHeight < p.nextHeight {
+		return false, nil
+	}
+
+	// the latest header is verified, so that the txs are not withheld by a node which reports a fake height.
+	if _, err := s.lightClient.GetLightBlock(latestHeight); err != nil {
+		return false, fmt.Errorf("failed to verify the header at height %d: %w", latestHeight, err)
+	}
+
+	if p.nextHeight == 0 {
+		if s.checkpoints != nil {
+			if err := s.catchUpTo(ctx, latestHeight); err != nil {
+				if s.isClosed() {
+					return true, nil
+				}
+				return false, fmt.Errorf("failed to replay missed events: %w", err)
+			}
+		}
+		p.nextHeight = latestHeight + 1
+		return false, nil
+	}
+
+	for query, e := range s.registeredEvents() {
+		txs, err := s.searchTxs(ctx, query, p.nextHeight, latestHeight)
+		if err != nil {
+			return false, fmt.Errorf("failed to search txs of %s: %w", e.Name(), err)
+		}
+
+		for _, tx := range txs {
+			if err := s.confirmTx(tx); err != nil {
+				return false, fmt.Errorf("failed to confirm tx %s of %s: %w", tx.Hash, e.Name(), err)
+			}
+			if err := s.enqueue(query, resultEventFromTx(query, tx)); err != nil {
+				return true, nil
+			}
+		}
+
+		if len(txs) > 0 {
+			log.Debugf("poll %d txs of %s from height %d to %d", len(txs), e.Name(), p.nextHeight, latestHeight)
+		}
+	}
+
+	p.nextHeight = latestHeight + 1
+	return false, nil
+}
@@ -0,0 +1,118 @@
+package event
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newTestPoller(t *testing.T, chain *fakeChain, lightClient LightBlockGetter, checkpoints CheckpointStore) *PanaceaPoller {
+	return &PanaceaPoller{
+		subscriber: newSubscriber("", chain, newTestDispatcher(t), nil, checkpoints, lightClient),
+		interval:   10 * time.Millisecond,
+	}
+}
+
+func TestPollerPoll(t *testing.T) {
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{
+			5: {tmtypes.Tx("tx1"), tmtypes.Tx("tx2")},
+			7: {tmtypes.Tx("tx3")},
+		},
+		latest: 8,
+	}
+
+	// tx1 has been processed before the oracle stopped
+	store := NewDBCheckpointStore(dbm.NewMemDB())
+	require.NoError(t, store.SetCheckpoint("test", Checkpoint{Height: 5, TxHashes: []string{fmt.Sprintf("%X", tmtypes.Tx("tx1").Hash())}}))
+
+	poller := newTestPoller(t, chain, chain, store)
+	defer poller.Close()
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 10)}
+	require.NoError(t, poller.subscriber.register([]Event{e}))
+
+	// the first poll replays the events after the checkpoint
+	closed, err := poller.poll()
+	require.NoError(t, err)
+	require.False(t, closed)
+	require.Equal(t, "tx2", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, "tx3", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, int64(9), poller.nextHeight)
+
+	// no new block
+	closed, err = poller.poll()
+	require.NoError(t, err)
+	require.False(t, closed)
+	require.Empty(t, e.received)
+
+	chain.blocks[9] = tmtypes.Txs{tmtypes.Tx("tx4")}
+	chain.blocks[10] = tmtypes.Txs{tmtypes.Tx("tx5")}
+	chain.latest = 10
+
+	closed, err = poller.poll()
+	require.NoError(t, err)
+	require.False(t, closed)
+	require.Equal(t, "tx4", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, "tx5", string(waitFor(t, e.received).Data.(tmtypes.EventDataTx).Tx))
+	require.Equal(t, int64(11), poller.nextHeight)
+
+	require.Eventually(t, func() bool {
+		checkpoint, err := store.GetCheckpoint("test")
+		require.NoError(t, err)
+		return checkpoint.Height == 10
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+type failingLightClient struct{}
+
+func (failingLightClient) GetLightBlock(height int64) (*tmtypes.LightBlock, error) {
+	return nil, fmt.Errorf("cannot verify height %d", height)
+}
+
+func TestPollerUnverifiedHeader(t *testing.T) {
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{3: {tmtypes.Tx("tx1")}},
+		latest: 3,
+	}
+
+	poller := newTestPoller(t, chain, failingLightClient{}, nil)
+	defer poller.Close()
+	poller.nextHeight = 1
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 10)}
+	require.NoError(t, poller.subscriber.register([]Event{e}))
+
+	_, err := poller.poll()
+	require.ErrorContains(t, err, "failed to verify the header at height 3")
+	require.Empty(t, e.received)
+
+	// the same blocks are polled again
+	require.Equal(t, int64(1), poller.nextHeight)
+}
+
+func TestPollerRun(t *testing.T) {
+	chain := &fakeChain{
+		blocks: map[int64]tmtypes.Txs{2: {tmtypes.Tx("tx1")}},
+		latest: 2,
+	}
+
+	poller := newTestPoller(t, chain, chain, NewDBCheckpointStore(dbm.NewMemDB()))
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 10)}
+	require.NoError(t, poller.Run(e))
+	require.Error(t, poller.Run(e))
+
+	require.Eventually(t, func() bool { return poller.Status().Connected() }, 5*time.Second, 10*time.Millisecond)
+
+	// the events before the first poll are not replayed if they have no checkpoint
+	require.Empty(t, e.received)
+
+	require.NoError(t, poller.Close())
+	require.Equal(t, StateClosed, poller.Status().State)
+}
@@ -2,72 +2,645 @@ package event
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 )
 
+const (
+	defaultMinReconnectBackoff = 1 * time.Second
+	defaultMaxReconnectBackoff = 1 * time.Minute
+
+	subscribeTimeout = 5 * time.Second
+
+	defaultRetryCheckInterval = 10 * time.Second
+)
+
+// ConnectionState is the state of the connection of the event source to Panacea.
+type ConnectionState string
+
+const (
+	StateConnecting   ConnectionState = "connecting"
+	StateConnected    ConnectionState = "connected"
+	StateDisconnected ConnectionState = "disconnected"
+	StateClosed       ConnectionState = "closed"
+)
+
+// SubscriberStatus is a snapshot of the connection state of the event source.
+type SubscriberStatus struct {
+	State           ConnectionState
+	LastConnectedAt time.Time
+	Reconnects      uint64
+	LastError       string
+	Dispatcher      DispatcherStats
+}
+
+// Connected returns whether the subscriber is connected and all events are subscribed.
+func (s SubscriberStatus) Connected() bool {
+	return s.State == StateConnected
+}
+
+// PanaceaSubscriber subscribes events from Panacea via websocket.
+// If the connection is lost, it reconnects with exponential backoff and resubscribes all the events.
+// The events are handled by the dispatcher, and the failed ones are retried from the dead-letter queue.
+// If a checkpoint store is given, the events missed while disconnected are replayed after their checkpoints.
 type PanaceaSubscriber struct {
-	client *rpchttp.HTTP
+	wsAddr      string
+	dispatcher  *Dispatcher
+	searcher    TxSearcher
+	lightClient LightBlockGetter
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	deadLetters        *DeadLetterQueue
+	retryCheckInterval time.Duration
+	retrying           map[string]bool // IDs of the failed events being retried
+
+	mtx    sync.RWMutex
+	events map[string]Event // query -> event
+	status SubscriberStatus
+
+	checkpointMtx sync.Mutex
+	checkpoints   CheckpointStore
+	progress      map[string]*eventProgress // event name -> progress
+
+	quit chan struct{}
+	wg   sync.WaitGroup
 }
 
-// NewSubscriber generates a rpc http client with websocket address.
-func NewSubscriber(wsAddr string) (*PanaceaSubscriber, error) {
-	client, err := rpchttp.New(wsAddr, "/websocket")
-	if err != nil {
-		return nil, err
-	}
+// eventProgress has the checkpoint of an event and the txs dispatched after the checkpoint, in the order of dispatch.
+// The checkpoint moves forward only over the handled txs at the front, because the txs may be handled concurrently.
+type eventProgress struct {
+	checkpoint Checkpoint
+	pending    []*pendingTx
+}
+
+type pendingTx struct {
+	height int64
+	hash   string
+	done   bool
+	failed bool // failed without being saved in the dead-letter queue, so it should be dispatched again
+}
 
-	err = client.Start()
+// NewSubscriber generates a subscriber with websocket address. The connection is made by Run.
+// The dispatcher is closed when the subscriber is closed.
+// If deadLetters is nil, failed events are replayed by the next catch-up, or dropped if checkpoints is also nil.
+// If checkpoints is nil, missed events are not replayed.
+// Otherwise, replayed txs are confirmed with light blocks from the lightClient.
+func NewSubscriber(wsAddr string, dispatcher *Dispatcher, deadLetters *DeadLetterQueue, checkpoints CheckpointStore, lightClient LightBlockGetter) (*PanaceaSubscriber, error) {
+	searcher, err := rpchttp.New(wsAddr, "/websocket")
 	if err != nil {
 		return nil, err
 	}
 
+	return newSubscriber(wsAddr, searcher, dispatcher, deadLetters, checkpoints, lightClient), nil
+}
+
+func newSubscriber(addr string, searcher TxSearcher, dispatcher *Dispatcher, deadLetters *DeadLetterQueue, checkpoints CheckpointStore, lightClient LightBlockGetter) *PanaceaSubscriber {
 	return &PanaceaSubscriber{
-		client: client,
-	}, nil
+		wsAddr:      addr,
+		dispatcher:  dispatcher,
+		deadLetters: deadLetters,
+		searcher:    searcher,
+		lightClient: lightClient,
+		checkpoints: checkpoints,
+		progress:    make(map[string]*eventProgress),
+		minBackoff:  defaultMinReconnectBackoff,
+		maxBackoff:  defaultMaxReconnectBackoff,
+		events:      make(map[string]Event),
+		retrying:    make(map[string]bool),
+
+		retryCheckInterval: defaultRetryCheckInterval,
+		status:             SubscriberStatus{State: StateConnecting},
+		quit:               make(chan struct{}),
+	}
 }
 
+// Run registers the events and starts to subscribe them in background.
+// The events are resubscribed whenever the connection is re-established.
 func (s *PanaceaSubscriber) Run(events ...Event) error {
 	log.Infof("Panacea event subscriber is started")
 
+	if err := s.register(events); err != nil {
+		return err
+	}
+	s.start(s.connectLoop)
+
+	return nil
+}
+
+func (s *PanaceaSubscriber) register(events []Event) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	for _, e := range events {
-		if err := s.subscribe(e); err != nil {
-			return err
+		query := e.GetEventQuery()
+		if _, ok := s.events[query]; ok {
+			return fmt.Errorf("event query is already subscribed: %s", query)
+		}
+
+		if s.checkpoints != nil {
+			checkpoint, err := s.checkpoints.GetCheckpoint(e.Name())
+			if err != nil {
+				return err
+			}
+			s.progress[e.Name()] = &eventProgress{checkpoint: checkpoint}
 		}
+
+		s.events[query] = e
 	}
 
 	return nil
 }
 
-func (s *PanaceaSubscriber) subscribe(event Event) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
+// start runs the loop which delivers the events, and the loop which retries the failed events, in background.
+// The delivering loop should call wg.Done when it returns.
+func (s *PanaceaSubscriber) start(deliverLoop func()) {
+	s.wg.Add(1)
+	go deliverLoop()
 
-	query := event.GetEventQuery()
+	if s.deadLetters != nil {
+		s.wg.Add(1)
+		go s.retryLoop()
+	}
+}
+
+// Status returns the current connection state of the subscriber and the statistics of the dispatcher.
+func (s *PanaceaSubscriber) Status() SubscriberStatus {
+	s.mtx.RLock()
+	status := s.status
+	s.mtx.RUnlock()
+
+	status.Dispatcher = s.dispatcher.Stats()
+	return status
+}
+
+func (s *PanaceaSubscriber) isClosed() bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.status.State == StateClosed
+}
+
+func (s *PanaceaSubscriber) setState(state ConnectionState, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 
-	log.Infof("subscribe %s. query: %s", event.Name(), query)
-	txs, err := s.client.Subscribe(ctx, "", query)
+	if s.status.State == StateClosed {
+		return
+	}
+	if state == StateConnected {
+		s.status.LastConnectedAt = time.Now()
+	}
+	if state == StateConnecting && s.status.State == StateDisconnected {
+		s.status.Reconnects++
+	}
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+	s.status.State = state
+}
+
+// connectLoop keeps a connection to Panacea until the subscriber is closed.
+func (s *PanaceaSubscriber) connectLoop() {
+	defer s.wg.Done()
+
+	backoff := s.minBackoff
+	for {
+		s.setState(StateConnecting, nil)
+
+		ws, redialed, err := s.connect()
+		if err != nil {
+			log.Errorf("failed to connect to Panacea websocket: %v. retry after %s", err, backoff)
+			s.setState(StateDisconnected, err)
+
+			select {
+			case <-time.After(backoff):
+			case <-s.quit:
+				return
+			}
+
+			backoff *= 2
+			if backoff > s.maxBackoff {
+				backoff = s.maxBackoff
+			}
+			continue
+		}
+
+		backoff = s.minBackoff
+		s.setState(StateConnected, nil)
+		log.Infof("connected to Panacea websocket: %s", s.wsAddr)
+
+		if closed := s.listen(ws, redialed); closed {
+			return
+		}
+
+		log.Warnf("disconnected from Panacea websocket")
+		s.setState(StateDisconnected, fmt.Errorf("websocket connection is lost"))
+	}
+}
+
+// connect dials the websocket, subscribes all the events and replays the missed events.
+// The websocket client always redials once by itself, which cannot be disabled.
+// The returned channel is notified when it redials, so that the connection is re-established by connectLoop,
+// which replays the events missed while disconnected.
+func (s *PanaceaSubscriber) connect() (*jsonrpcclient.WSClient, <-chan struct{}, error) {
+	redialed := make(chan struct{}, 1)
+	ws, err := jsonrpcclient.NewWS(
+		s.wsAddr,
+		"/websocket",
+		jsonrpcclient.MaxReconnectAttempts(0),
+		jsonrpcclient.OnReconnect(func() {
+			select {
+			case redialed <- struct{}{}:
+			default:
+			}
+		}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ws.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.subscribeAll(ws); err != nil {
+		stopWSClient(ws)
+		return nil, nil, err
+	}
+
+	// The events are replayed after subscribing them, so that no event is missed between them.
+	// The events delivered through the websocket meanwhile are handled after the replayed ones.
+	if s.checkpoints != nil {
+		if err := s.catchUp(); err != nil {
+			stopWSClient(ws)
+			return nil, nil, fmt.Errorf("failed to replay missed events: %w", err)
+		}
+	}
+
+	return ws, redialed, nil
+}
+
+func stopWSClient(ws *jsonrpcclient.WSClient) {
+	if err := ws.Stop(); err != nil {
+		log.Warnf("failed to stop websocket client: %v", err)
+	}
+}
+
+func (s *PanaceaSubscriber) subscribeAll(ws *jsonrpcclient.WSClient) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for query, e := range s.events {
+		ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+		err := ws.Subscribe(ctx, query)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to subscribe %s: %w", e.Name(), err)
+		}
+		log.Infof("subscribe %s. query: %s", e.Name(), query)
+	}
+
+	return nil
+}
+
+// listen dispatches events until the connection is lost or the subscriber is closed.
+// The connection is regarded as lost also when the websocket client redials by itself.
+// It returns true if the subscriber is closed.
+func (s *PanaceaSubscriber) listen(ws *jsonrpcclient.WSClient, redialed <-chan struct{}) bool {
+	defer func() {
+		if ws.IsRunning() {
+			stopWSClient(ws)
+		}
+	}()
+
+	for {
+		select {
+		case resp, ok := <-ws.ResponsesCh:
+			if !ok {
+				return false
+			}
+
+			if resp.Error != nil {
+				// Panacea may be restarting. So, reconnect to subscribe the events again.
+				if !strings.Contains(resp.Error.Error(), tmpubsub.ErrAlreadySubscribed.Error()) {
+					log.Errorf("websocket error: %v", resp.Error)
+					return false
+				}
+				continue
+			}
+
+			var result ctypes.ResultEvent
+			if err := tmjson.Unmarshal(resp.Result, &result); err != nil {
+				log.Errorf("failed to unmarshal event: %v", err)
+				continue
+			}
+
+			if err := s.enqueue(result.Query, result); err != nil {
+				return true
+			}
+		case <-redialed:
+			log.Warnf("websocket client redialed by itself. reconnect to replay the missed events")
+			return false
+		case <-s.quit:
+			return true
+		}
+	}
+}
+
+func (s *PanaceaSubscriber) registeredEvents() map[string]Event {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	events := make(map[string]Event, len(s.events))
+	for query, e := range s.events {
+		events[query] = e
+	}
+	return events
+}
+
+// enqueue dispatches the event unless it has been dispatched already.
+// It ignores responses which are not events, like results of subscriptions.
+func (s *PanaceaSubscriber) enqueue(query string, event ctypes.ResultEvent) error {
+	s.mtx.RLock()
+	e, ok := s.events[query]
+	s.mtx.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	if s.checkpoints == nil {
+		return s.dispatcher.Dispatch(e, event, s.handledWithoutCheckpoint(e, event))
+	}
+
+	height, txHash, err := txPosition(event)
+	if err != nil {
+		log.Warnf("failed to get the position of event %s: %v", e.Name(), err)
+		return s.dispatcher.Dispatch(e, event, s.handledWithoutCheckpoint(e, event))
+	}
+
+	tx, ok := s.begin(e.Name(), height, txHash)
+	if !ok {
+		log.Debugf("skip the processed event %s. height(%d), hash(%s)", e.Name(), height, txHash)
+		return nil
+	}
+
+	return s.dispatcher.Dispatch(e, event, func(handleErr error) {
+		// the checkpoint moves forward over the failed event only if it is kept in the dead-letter queue.
+		// Otherwise, the checkpoint stays so that the event is replayed by the next catch-up.
+		if !s.handled(e, event, handleErr) {
+			s.fail(tx)
+			log.Warnf("the checkpoint of %s stays before the failed event until it is replayed. height(%d), hash(%s)", e.Name(), height, txHash)
+			return
+		}
+		if err := s.finish(e.Name(), tx); err != nil {
+			log.Errorf("failed to save checkpoint: %v", err)
+		}
+	})
+}
+
+// handledWithoutCheckpoint returns a callback which puts the event to the dead-letter queue if it failed.
+func (s *PanaceaSubscriber) handledWithoutCheckpoint(e Event, event ctypes.ResultEvent) func(error) {
+	return func(handleErr error) {
+		s.handled(e, event, handleErr)
+	}
+}
+
+// handled puts the event to the dead-letter queue if it failed.
+// It returns true if the event succeeded or it is kept in the dead-letter queue.
+func (s *PanaceaSubscriber) handled(e Event, event ctypes.ResultEvent, handleErr error) bool {
+	if handleErr == nil {
+		return true
+	}
+	if s.deadLetters == nil {
+		return false
+	}
+
+	failed, err := s.deadLetters.Add(e.Name(), event, handleErr)
+	if err != nil {
+		log.Errorf("failed to put the failed event %s to the dead-letter queue: %v", e.Name(), err)
+		return false
+	}
+	log.Warnf("failed event %s is put to the dead-letter queue. next retry: %s", failed.ID, formatRetryTime(failed.NextRetryAt))
+	return true
+}
+
+// retryLoop retries the failed events in the dead-letter queue when they are due.
+func (s *PanaceaSubscriber) retryLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.retryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.retryFailedEvents(); err != nil {
+				log.Errorf("failed to retry failed events: %v", err)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *PanaceaSubscriber) retryFailedEvents() error {
+	due, err := s.deadLetters.Due(time.Now())
 	if err != nil {
 		return err
 	}
 
-	go func(e Event) {
-		for tx := range txs {
-			ctx := context.Background()
+	events := make(map[string]Event)
+	for _, e := range s.registeredEvents() {
+		events[e.Name()] = e
+	}
+
+	for _, failed := range due {
+		e, ok := events[failed.EventName]
+		if !ok || !s.startRetry(failed.ID) {
+			continue
+		}
+
+		result, err := failed.ResultEvent()
+		if err != nil {
+			s.endRetry(failed.ID)
+			log.Errorf("failed to retry event %s: %v", failed.ID, err)
+			continue
+		}
+
+		log.Infof("retry the failed event %s. attempts: %d", failed.ID, failed.Attempts)
+		failed := failed
+		if err := s.dispatcher.Dispatch(e, result, func(handleErr error) {
+			defer s.endRetry(failed.ID)
+			s.retried(failed, handleErr)
+		}); err != nil {
+			s.endRetry(failed.ID)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PanaceaSubscriber) retried(failed FailedEvent, handleErr error) {
+	if handleErr == nil {
+		if err := s.deadLetters.Succeed(failed); err != nil {
+			log.Errorf("failed to remove the retried event %s from the dead-letter queue: %v", failed.ID, err)
+			return
+		}
+		log.Infof("succeeded to retry the failed event %s", failed.ID)
+		return
+	}
+
+	updated, err := s.deadLetters.Fail(failed, handleErr)
+	if errors.Is(err, errEventDropped) {
+		log.Infof("the event %s failed to be retried, but it is dropped already", failed.ID)
+		return
+	}
+	if err != nil {
+		log.Errorf("failed to update the failed event %s: %v", failed.ID, err)
+		return
+	}
+	log.Warnf("failed to retry the event %s. attempts: %d, next retry: %s", updated.ID, updated.Attempts, formatRetryTime(updated.NextRetryAt))
+}
+
+// startRetry returns false if the failed event is being retried already.
+func (s *PanaceaSubscriber) startRetry(id string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.retrying[id] {
+		return false
+	}
+	s.retrying[id] = true
+	return true
+}
 
-			log.Infof("received event a %s", e.Name())
-			if err := e.EventHandler(ctx, tx); err != nil {
-				log.Errorf("failed to handle event '%s': %v", query, err)
+func (s *PanaceaSubscriber) endRetry(id string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.retrying, id)
+}
+
+func formatRetryTime(t time.Time) string {
+	if t.IsZero() {
+		return "none (retry it manually)"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// begin adds the tx to the pending txs of the event. It returns false if the tx has been dispatched already,
+// unless it failed without being saved in the dead-letter queue.
+func (s *PanaceaSubscriber) begin(eventName string, height int64, txHash string) (*pendingTx, bool) {
+	s.checkpointMtx.Lock()
+	defer s.checkpointMtx.Unlock()
+
+	progress := s.eventProgress(eventName)
+	if progress.checkpoint.Processed(height, txHash) {
+		return nil, false
+	}
+	for _, tx := range progress.pending {
+		if tx.height == height && tx.hash == txHash {
+			if !tx.failed {
+				return nil, false
 			}
+			tx.failed = false
+			return tx, true
 		}
-	}(event)
+	}
+
+	tx := &pendingTx{height: height, hash: txHash}
+	progress.pending = append(progress.pending, tx)
+	return tx, true
+}
+
+// finish marks the tx as handled, and moves the checkpoint forward over the handled txs at the front.
+func (s *PanaceaSubscriber) finish(eventName string, tx *pendingTx) error {
+	s.checkpointMtx.Lock()
+	defer s.checkpointMtx.Unlock()
+
+	tx.done = true
 
+	progress := s.eventProgress(eventName)
+	checkpoint := progress.checkpoint
+	handled := 0
+	for _, pending := range progress.pending {
+		if !pending.done {
+			break
+		}
+		checkpoint = checkpoint.Next(pending.height, pending.hash)
+		handled++
+	}
+	if handled == 0 {
+		return nil
+	}
+
+	if err := s.checkpoints.SetCheckpoint(eventName, checkpoint); err != nil {
+		return err
+	}
+	progress.checkpoint = checkpoint
+	progress.pending = progress.pending[handled:]
+	return nil
+}
+
+// fail marks the tx as failed. The checkpoint doesn't move forward over it until it is dispatched again and handled.
+func (s *PanaceaSubscriber) fail(tx *pendingTx) {
+	s.checkpointMtx.Lock()
+	defer s.checkpointMtx.Unlock()
+	tx.failed = true
+}
+
+func (s *PanaceaSubscriber) checkpoint(eventName string) Checkpoint {
+	s.checkpointMtx.Lock()
+	defer s.checkpointMtx.Unlock()
+	return s.eventProgress(eventName).checkpoint
+}
+
+func (s *PanaceaSubscriber) saveCheckpoint(eventName string, checkpoint Checkpoint) error {
+	s.checkpointMtx.Lock()
+	defer s.checkpointMtx.Unlock()
+
+	if err := s.checkpoints.SetCheckpoint(eventName, checkpoint); err != nil {
+		return err
+	}
+	s.eventProgress(eventName).checkpoint = checkpoint
 	return nil
 }
 
+// eventProgress should be called with checkpointMtx locked.
+func (s *PanaceaSubscriber) eventProgress(eventName string) *eventProgress {
+	progress, ok := s.progress[eventName]
+	if !ok {
+		progress = &eventProgress{}
+		s.progress[eventName] = progress
+	}
+	return progress
+}
+
 func (s *PanaceaSubscriber) Close() error {
 	log.Infof("closing Panacea event subscriber")
-	return s.client.Stop()
+
+	s.mtx.Lock()
+	if s.status.State == StateClosed {
+		s.mtx.Unlock()
+		return nil
+	}
+	s.status.State = StateClosed
+	s.mtx.Unlock()
+
+	close(s.quit)
+	s.wg.Wait()
+	s.dispatcher.Close()
+
+	return nil
 }
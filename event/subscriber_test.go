@@ -0,0 +1,170 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const testQuery = "tm.event = 'Tx' AND message.action = 'test'"
+
+type testEvent struct {
+	received chan ctypes.ResultEvent
+}
+
+func (e testEvent) Name() string {
+	return "test"
+}
+
+func (e testEvent) GetEventQuery() string {
+	return testQuery
+}
+
+func (e testEvent) EventHandler(_ context.Context, event ctypes.ResultEvent) error {
+	e.received <- event
+	return nil
+}
+
+// fakeNode is a websocket endpoint of Tendermint which accepts subscriptions and publishes events.
+type fakeNode struct {
+	*httptest.Server
+
+	mtx        sync.Mutex
+	conns      []*websocket.Conn
+	subscribed chan string
+	// events are published with the ID of the subscription request, like Tendermint does
+	subscription types.RPCRequest
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	node := &fakeNode{subscribed: make(chan string, 10)}
+	upgrader := websocket.Upgrader{}
+
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		node.mtx.Lock()
+		node.conns = append(node.conns, conn)
+		node.mtx.Unlock()
+
+		for {
+			var req types.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			if req.Method != "subscribe" {
+				continue
+			}
+
+			var params struct {
+				Query string `json:"query"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params, &params))
+
+			node.write(t, conn, types.NewRPCSuccessResponse(req.ID, ctypes.ResultSubscribe{}))
+			node.mtx.Lock()
+			node.subscription = req
+			node.mtx.Unlock()
+			node.subscribed <- params.Query
+		}
+	}))
+
+	return node
+}
+
+func (n *fakeNode) write(t *testing.T, conn *websocket.Conn, resp types.RPCResponse) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	require.NoError(t, conn.WriteJSON(resp))
+}
+
+func (n *fakeNode) publish(t *testing.T, query string) {
+	n.publishEvent(t, ctypes.ResultEvent{Query: query})
+}
+
+func (n *fakeNode) publishEvent(t *testing.T, event ctypes.ResultEvent) {
+	n.mtx.Lock()
+	conn, subscription := n.conns[len(n.conns)-1], n.subscription
+	n.mtx.Unlock()
+
+	n.write(t, conn, types.NewRPCSuccessResponse(subscription.ID, event))
+}
+
+func (n *fakeNode) dropConnections() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for _, conn := range n.conns {
+		_ = conn.Close()
+	}
+}
+
+func (n *fakeNode) wsAddr() string {
+	return strings.Replace(n.URL, "http://", "tcp://", 1)
+}
+
+// TestSubscriberReconnect tests that the subscriber resubscribes events after the connection is lost.
+func TestSubscriberReconnect(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	subscriber, err := NewSubscriber(node.wsAddr(), newTestDispatcher(t), nil, nil, nil)
+	require.NoError(t, err)
+	subscriber.minBackoff = 10 * time.Millisecond
+	defer subscriber.Close()
+
+	e := testEvent{received: make(chan ctypes.ResultEvent, 1)}
+	require.NoError(t, subscriber.Run(e))
+
+	require.Equal(t, testQuery, waitFor(t, node.subscribed))
+	require.Eventually(t, func() bool { return subscriber.Status().Connected() }, 5*time.Second, 10*time.Millisecond)
+
+	node.publish(t, testQuery)
+	require.Equal(t, testQuery, waitFor(t, e.received).Query)
+
+	// the events are subscribed again after the connection is lost
+	node.dropConnections()
+	require.Equal(t, testQuery, waitFor(t, node.subscribed))
+
+	node.publish(t, testQuery)
+	require.Equal(t, testQuery, waitFor(t, e.received).Query)
+
+	// the node is down
+	node.Close()
+	node.dropConnections()
+	require.Eventually(t, func() bool {
+		status := subscriber.Status()
+		return !status.Connected() && status.Reconnects > 0 && status.LastError != ""
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, subscriber.Close())
+	require.Equal(t, StateClosed, subscriber.Status().State)
+}
+
+func newTestDispatcher(t *testing.T) *Dispatcher {
+	dispatcher, err := NewDispatcher(2, 10, 5*time.Second)
+	require.NoError(t, err)
+	return dispatcher
+}
+
+func waitFor[T any](t *testing.T, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timeout")
+	}
+	var zero T
+	return zero
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/medibloc/panacea-oracle/crypto"
 	"github.com/medibloc/panacea-oracle/service"
+	"github.com/medibloc/panacea-oracle/sgx"
+	log "github.com/sirupsen/logrus"
 	"github.com/tendermint/tendermint/libs/os"
 )
 
@@ -15,7 +18,8 @@ func DecryptAndStoreOraclePrivKey(ctx context.Context, svc service.Service, encr
 		return err
 	}
 
-	if err := svc.SGX().SealToFile(oraclePrivKeyBz, svc.Config().AbsOraclePrivKeyPath()); err != nil {
+	conf := svc.Config()
+	if err := svc.SGX().SealToFile(oraclePrivKeyBz, conf.AbsOraclePrivKeyPath(), sgx.SealPolicy(conf.Sealing.OraclePrivKey)); err != nil {
 		return fmt.Errorf("failed to seal oraclePrivKey to file. %w", err)
 	}
 
@@ -33,19 +37,22 @@ func decryptOraclePrivKey(ctx context.Context, svc service.Service, encryptedOra
 		return nil, err
 	}
 
-	return crypto.Decrypt(shareKeyBz, nil, encryptedOraclePrivKey)
+	oraclePrivKeyBz, isLegacy, err := crypto.DecryptEnvelopeOrLegacy(shareKeyBz, crypto.AdditionalData{Purpose: crypto.PurposeOraclePrivKey}, encryptedOraclePrivKey, svc.Config().AllowLegacyCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	if isLegacy {
+		log.Warn("accepted the oracle private key in a deprecated legacy ciphertext. it will be refused once allow-legacy-ciphertext is disabled")
+	}
+
+	return oraclePrivKeyBz, nil
 }
 
 func deriveSharedKey(ctx context.Context, svc service.Service) ([]byte, error) {
-	nodePrivKeyPath := svc.Config().AbsNodePrivKeyPath()
-	if !os.FileExists(nodePrivKeyPath) {
-		return nil, fmt.Errorf("the node private key is not exists")
-	}
-	nodePrivKeyBz, err := svc.SGX().UnsealFromFile(nodePrivKeyPath)
+	nodePrivKey, err := LoadNodePrivKey(svc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unseal nodePrivKey from file.%w", err)
+		return nil, err
 	}
-	nodePrivKey, _ := crypto.PrivKeyFromBytes(nodePrivKeyBz)
 
 	oraclePublicKey, err := svc.QueryClient().GetOracleParamsPublicKey(ctx)
 	if err != nil {
@@ -55,3 +62,18 @@ func deriveSharedKey(ctx context.Context, svc service.Service) ([]byte, error) {
 	shareKeyBz := crypto.DeriveSharedKey(nodePrivKey, oraclePublicKey, crypto.KDFSHA256)
 	return shareKeyBz, nil
 }
+
+// LoadNodePrivKey unseals the node private key of the oracle.
+func LoadNodePrivKey(svc service.Service) (*btcec.PrivateKey, error) {
+	nodePrivKeyPath := svc.Config().AbsNodePrivKeyPath()
+	if !os.FileExists(nodePrivKeyPath) {
+		return nil, fmt.Errorf("the node private key is not exists")
+	}
+	nodePrivKeyBz, err := svc.SGX().UnsealFromFile(nodePrivKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal nodePrivKey from file.%w", err)
+	}
+	nodePrivKey, _ := crypto.PrivKeyFromBytes(nodePrivKeyBz)
+
+	return nodePrivKey, nil
+}
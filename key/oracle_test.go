@@ -9,6 +9,7 @@ import (
 	"github.com/medibloc/panacea-oracle/integration/suite"
 	"github.com/medibloc/panacea-oracle/key"
 	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/medibloc/panacea-oracle/sgx"
 )
 
 type oracleTestSuite struct {
@@ -32,7 +33,7 @@ func (suite *oracleTestSuite) AfterTest(_, _ string) {
 func (suite *oracleTestSuite) TestDecryptAndStoreOraclePrivKey() {
 	suite.QueryClient.OraclePubKey = suite.OraclePubKey
 
-	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), suite.Config.AbsNodePrivKeyPath())
+	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), suite.Config.AbsNodePrivKeyPath(), sgx.SealPolicyUniqueKey)
 	suite.Require().NoError(err)
 
 	secretKey := crypto.DeriveSharedKey(suite.OraclePrivKey, suite.NodePrivKey.PubKey(), crypto.KDFSHA256)
@@ -52,10 +53,10 @@ func (suite *oracleTestSuite) TestDecryptAndStoreOraclePrivKey() {
 func (suite *oracleTestSuite) TestRetrieveAndStoreOraclePrivKeyExistOraclePrivKey() {
 	suite.OraclePubKey = suite.OraclePrivKey.PubKey()
 
-	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), suite.Config.AbsNodePrivKeyPath())
+	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), suite.Config.AbsNodePrivKeyPath(), sgx.SealPolicyUniqueKey)
 	suite.Require().NoError(err)
 
-	err = suite.SGX.SealToFile(suite.OraclePrivKey.Serialize(), suite.Config.AbsOraclePrivKeyPath())
+	err = suite.SGX.SealToFile(suite.OraclePrivKey.Serialize(), suite.Config.AbsOraclePrivKeyPath(), sgx.SealPolicyUniqueKey)
 	suite.Require().NoError(err)
 
 	secretKey := crypto.DeriveSharedKey(suite.OraclePrivKey, suite.NodePrivKey.PubKey(), crypto.KDFSHA256)
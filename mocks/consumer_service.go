@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -18,18 +19,30 @@ var (
 	_ consumer_service.FileStorage = &MockConsumerService{}
 )
 
-func (u MockConsumerService) Add(tempDir string, dealID uint64, dataHash string, data []byte) error {
+func (u MockConsumerService) Add(tempDir string, dealID uint64, dataHash string, data io.Reader) error {
 	if err := os.MkdirAll(filepath.Join(tempDir, strconv.FormatUint(dealID, 10)), fs.ModePerm); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(filepath.Join(tempDir, strconv.FormatUint(dealID, 10), dataHash), data, fs.ModePerm); err != nil {
+	// the data is stored as it is read, like the consumer service
+	file, err := os.Create(filepath.Join(tempDir, strconv.FormatUint(dealID, 10), dataHash))
+	if err != nil {
 		return err
 	}
+	defer file.Close()
 
-	return nil
+	if _, err := io.Copy(file, data); err != nil {
+		return err
+	}
+
+	return file.Close()
 }
 
 func (u MockConsumerService) Get(tempDir string, dealID uint64, dataHash string) ([]byte, error) {
 	return os.ReadFile(filepath.Join(tempDir, strconv.FormatUint(dealID, 10), dataHash))
 }
+
+// Open returns a reader of the stored data, so that it can be decrypted as a stream.
+func (u MockConsumerService) Open(tempDir string, dealID uint64, dataHash string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(tempDir, strconv.FormatUint(dealID, 10), dataHash))
+}
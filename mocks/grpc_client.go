@@ -2,8 +2,10 @@ package mocks
 
 import (
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/panacea"
 )
 
@@ -13,9 +15,20 @@ var _ panacea.GRPCClient = &MockGrpcClient{}
 // It is implemented to return the value as it is declared in this mock structure.
 type MockGrpcClient struct {
 	BroadcastResponse *tx.BroadcastTxResponse
+	SimulateResponse  *tx.SimulateResponse
+	GetTxResponse     *tx.GetTxResponse
+	MinGasPrices      sdk.DecCoins
 	ProtoCodec        *codec.ProtoCodec
 	ChainID           string
 	Account           *MockAccount
+
+	OracleRegistration *oracletypes.OracleRegistration
+	OracleUpgrade      *oracletypes.OracleUpgrade
+	OracleUpgradeInfo  *oracletypes.OracleUpgradeInfo
+
+	OracleRegistrations []*oracletypes.OracleRegistration
+	OracleUpgrades      []*oracletypes.OracleUpgrade
+	Oracles             []*oracletypes.Oracle
 }
 
 func (m MockGrpcClient) Close() error {
@@ -26,6 +39,18 @@ func (m MockGrpcClient) BroadcastTx(txBytes []byte) (*tx.BroadcastTxResponse, er
 	return m.BroadcastResponse, nil
 }
 
+func (m MockGrpcClient) Simulate(txBytes []byte) (*tx.SimulateResponse, error) {
+	return m.SimulateResponse, nil
+}
+
+func (m MockGrpcClient) GetTx(txHash string) (*tx.GetTxResponse, error) {
+	return m.GetTxResponse, nil
+}
+
+func (m MockGrpcClient) GetMinGasPrices() (sdk.DecCoins, error) {
+	return m.MinGasPrices, nil
+}
+
 func (m MockGrpcClient) GetCdc() *codec.ProtoCodec {
 	return m.ProtoCodec
 }
@@ -37,3 +62,27 @@ func (m MockGrpcClient) GetChainID() string {
 func (m MockGrpcClient) GetAccount(address string) (authtypes.AccountI, error) {
 	return m.Account, nil
 }
+
+func (m MockGrpcClient) GetOracleRegistration(uniqueID, oracleAddr string) (*oracletypes.OracleRegistration, error) {
+	return m.OracleRegistration, nil
+}
+
+func (m MockGrpcClient) GetOracleUpgrade(uniqueID, oracleAddr string) (*oracletypes.OracleUpgrade, error) {
+	return m.OracleUpgrade, nil
+}
+
+func (m MockGrpcClient) GetOracleUpgradeInfo() (*oracletypes.OracleUpgradeInfo, error) {
+	return m.OracleUpgradeInfo, nil
+}
+
+func (m MockGrpcClient) GetOracleRegistrations(uniqueID string) ([]*oracletypes.OracleRegistration, error) {
+	return m.OracleRegistrations, nil
+}
+
+func (m MockGrpcClient) GetOracleUpgrades(uniqueID string) ([]*oracletypes.OracleUpgrade, error) {
+	return m.OracleUpgrades, nil
+}
+
+func (m MockGrpcClient) GetOracles() ([]*oracletypes.Oracle, error) {
+	return m.Oracles, nil
+}
@@ -2,10 +2,12 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	didtypes "github.com/medibloc/panacea-core/v2/x/did/types"
 
 	"github.com/btcsuite/btcd/btcec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
@@ -18,6 +20,9 @@ import (
 type MockQueryClient struct {
 	Account                     authtypes.AccountI
 	AccountError                error
+	AccountCreatedTime          time.Time
+	BondedStake                 *sdk.Coin
+	BondedStakeError            error
 	OracleRegistration          *oracletypes.OracleRegistration
 	LightBlock                  *tmtypes.LightBlock
 	LastBlockHeight             int64
@@ -29,6 +34,7 @@ type MockQueryClient struct {
 	OracleUpgradeInfo           *oracletypes.OracleUpgradeInfo
 	VerifyTrustedBlockInfoError error
 	DidDocWithSeq               *didtypes.DIDDocumentWithSeq
+	InvalidatedOracles          map[string]int64 // the heights of the oracles invalidated, recorded if not nil
 }
 
 func (q MockQueryClient) GetCachedLastBlockHeight() int64 {
@@ -45,6 +51,21 @@ func (q MockQueryClient) GetAccount(ctx context.Context, s string) (authtypes.Ac
 	return q.Account, q.AccountError
 }
 
+func (q MockQueryClient) GetAccountCreatedTime(_ context.Context, _ string) (time.Time, error) {
+	return q.AccountCreatedTime, nil
+}
+
+func (q MockQueryClient) GetBondedStake(_ context.Context, _ string) (*sdk.Coin, error) {
+	if q.BondedStakeError != nil {
+		return nil, q.BondedStakeError
+	}
+	if q.BondedStake == nil {
+		coin := sdk.NewInt64Coin("umed", 0)
+		return &coin, nil
+	}
+	return q.BondedStake, nil
+}
+
 func (q MockQueryClient) GetDID(_ context.Context, _ string) (*didtypes.DIDDocumentWithSeq, error) {
 	return q.DidDocWithSeq, nil
 }
@@ -88,3 +109,11 @@ func (q MockQueryClient) GetOracle(ctx context.Context, s string) (*oracletypes.
 func (q MockQueryClient) VerifyTrustedBlockInfo(i int64, bytes []byte) error {
 	return q.VerifyTrustedBlockInfoError
 }
+
+func (q MockQueryClient) InvalidateDeal(_ uint64, _ int64) {}
+
+func (q MockQueryClient) InvalidateOracle(oracleAddr string, height int64) {
+	if q.InvalidatedOracles != nil {
+		q.InvalidatedOracles[oracleAddr] = height
+	}
+}
@@ -1,14 +1,19 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/btcsuite/btcd/btcec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/config"
 	"github.com/medibloc/panacea-oracle/consumer_service"
+	"github.com/medibloc/panacea-oracle/deal"
 	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/medibloc/panacea-oracle/service"
 	"github.com/medibloc/panacea-oracle/sgx"
+	dbm "github.com/tendermint/tm-db"
 )
 
 var _ service.Service = &MockService{}
@@ -20,6 +25,12 @@ type MockService struct {
 	queryClient     *MockQueryClient
 	consumerService *MockConsumerService
 	sgx             *MockSGX
+	dealRegistry    *deal.Registry
+	approvalPolicy  *approval.Policy
+	approvalStore   *approval.DBStore
+	coordinator     *approval.Coordinator
+	dispatcher      *event.Dispatcher
+	deadLetters     *event.DeadLetterQueue
 
 	config *config.Config
 
@@ -32,6 +43,8 @@ type MockService struct {
 	broadcastTxResponse *MockBroadcastTxResponse
 
 	broadcastMsgs []sdk.Msg
+
+	subscriberStatus event.SubscriberStatus
 }
 
 func NewMockService(
@@ -45,11 +58,21 @@ func NewMockService(
 	oraclePrivKey *btcec.PrivateKey,
 	nodePrivKey *btcec.PrivateKey,
 ) *MockService {
+	approvalStore := approval.NewDBStore(dbm.NewMemDB())
+	approvalPolicy, _ := approval.NewPolicy(conf.Approval, queryClient, approvalStore)
+	dispatcher, _ := event.NewDispatcher(conf.Event.Workers, conf.Event.QueueSize, conf.Event.HandlerTimeout)
+
 	return &MockService{
 		grpcClient:      grpcClient,
 		queryClient:     queryClient,
 		consumerService: consumerService,
 		sgx:             sgx,
+		dealRegistry:    deal.NewRegistry(),
+		approvalPolicy:  approvalPolicy,
+		approvalStore:   approvalStore,
+		dispatcher:      dispatcher,
+		deadLetters:     event.NewDeadLetterQueue(event.NewDBDeadLetterStore(dbm.NewMemDB()), conf.Event.MaxRetries, conf.Event.RetryInterval),
+		coordinator:     approval.NewCoordinator(grpcClient, enclaveInfo.UniqueIDHex(), oracleAccount.GetAddress(), conf.Approval.FollowerDelay, conf.MaxFollowerWait()),
 		config:          conf,
 		enclaveInfo:     enclaveInfo,
 		oracleAccount:   oracleAccount,
@@ -104,12 +127,49 @@ func (m *MockService) ConsumerService() consumer_service.FileStorage {
 	return m.consumerService
 }
 
-func (m *MockService) BroadcastTx(msg ...sdk.Msg) (int64, string, error) {
+func (m *MockService) DealRegistry() *deal.Registry {
+	return m.dealRegistry
+}
+
+func (m *MockService) ApprovalPolicy() *approval.Policy {
+	return m.approvalPolicy
+}
+
+// SetApprovalConfig replaces ApprovalPolicy with a new one of the config, keeping its store
+func (m *MockService) SetApprovalConfig(conf config.ApprovalConfig) error {
+	approvalPolicy, err := approval.NewPolicy(conf, m.queryClient, m.approvalStore)
+	if err != nil {
+		return err
+	}
+	m.approvalPolicy = approvalPolicy
+	return nil
+}
+
+func (m *MockService) ApprovalCoordinator() *approval.Coordinator {
+	return m.coordinator
+}
+
+// SetApprovalCoordinator sets the result of ApprovalCoordinator
+func (m *MockService) SetApprovalCoordinator(coordinator *approval.Coordinator) {
+	m.coordinator = coordinator
+}
+
+// ApprovalStore returns the in-memory store of ApprovalPolicy
+func (m *MockService) ApprovalStore() *approval.DBStore {
+	return m.approvalStore
+}
+
+func (m *MockService) BroadcastTx(_ context.Context, msg ...sdk.Msg) (int64, string, error) {
 	m.broadcastMsgs = append(m.broadcastMsgs, msg...)
 	tx := m.broadcastTxResponse
 	return tx.code, tx.description, tx.error
 }
 
+func (m *MockService) BroadcastSignedTx(_ context.Context, _ []byte) (int64, string, error) {
+	tx := m.broadcastTxResponse
+	return tx.code, tx.description, tx.error
+}
+
 // BroadCastTxMsgs returns the Tx messages for which it ran BroadcastTx
 func (m *MockService) BroadCastTxMsgs() []sdk.Msg {
 	return m.broadcastMsgs
@@ -119,6 +179,23 @@ func (m *MockService) StartSubscriptions(event ...event.Event) error {
 	return nil
 }
 
+func (m *MockService) Dispatcher() *event.Dispatcher {
+	return m.dispatcher
+}
+
+func (m *MockService) DeadLetters() *event.DeadLetterQueue {
+	return m.deadLetters
+}
+
+// SetSubscriberStatus sets the result of SubscriberStatus
+func (m *MockService) SetSubscriberStatus(status event.SubscriberStatus) {
+	m.subscriberStatus = status
+}
+
+func (m *MockService) SubscriberStatus() event.SubscriberStatus {
+	return m.subscriberStatus
+}
+
 func (m *MockService) Close() error {
 	return nil
 }
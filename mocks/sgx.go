@@ -4,12 +4,14 @@ import (
 	"io/fs"
 	"os"
 
+	"github.com/edgelesssys/ego/attestation"
 	"github.com/medibloc/panacea-oracle/sgx"
 )
 
 type MockSGX struct {
 	RemoteReport            []byte
 	SelfEnclaveInfo         *sgx.EnclaveInfo
+	ParsedRemoteReport      attestation.Report
 	VerifyRemoteReportError error
 }
 
@@ -23,11 +25,15 @@ func (m MockSGX) GenerateSelfEnclaveInfo() (*sgx.EnclaveInfo, error) {
 	return m.SelfEnclaveInfo, nil
 }
 
+func (m MockSGX) ParseRemoteReport(reportBytes []byte) (attestation.Report, error) {
+	return m.ParsedRemoteReport, m.VerifyRemoteReportError
+}
+
 func (m MockSGX) VerifyRemoteReport(reportBytes, expectedData []byte, expectedUniqueID []byte) error {
 	return m.VerifyRemoteReportError
 }
 
-func (m MockSGX) SealToFile(data []byte, filePath string) error {
+func (m MockSGX) SealToFile(data []byte, filePath string, policy sgx.SealPolicy) error {
 	return os.WriteFile(filePath, data, fs.ModePerm)
 }
 
@@ -35,7 +41,7 @@ func (m MockSGX) UnsealFromFile(filePath string) ([]byte, error) {
 	return os.ReadFile(filePath)
 }
 
-func (m MockSGX) Seal(data []byte) ([]byte, error) {
+func (m MockSGX) Seal(data []byte, policy sgx.SealPolicy) ([]byte, error) {
 	return data, nil
 }
 
@@ -7,13 +7,19 @@ import (
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/go-bip39"
+	"github.com/medibloc/panacea-oracle/config"
 	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/sgx"
 	log "github.com/sirupsen/logrus"
+	tos "github.com/tendermint/tendermint/libs/os"
 )
 
 type OracleAccount struct {
 	privKey cryptotypes.PrivKey
 	pubKey  cryptotypes.PubKey
+	address string // the address of the account without keys. empty if the account has its keys.
+	granter string // the address of the account which granted this account via authz. empty if authz is not used.
 }
 
 // NewOracleAccount returns an oracle account from mnemonic, account number, and index
@@ -33,7 +39,77 @@ func NewOracleAccount(mnemonic string, accNum, index uint32) (*OracleAccount, er
 	}, nil
 }
 
+// NewOracleAddressAccount returns an oracle account which has only the address, without loading its keys.
+// It is used to generate the txs to be signed offline, so it cannot sign txs.
+func NewOracleAddressAccount(address string) (*OracleAccount, error) {
+	if _, err := GetAccAddressFromBech32(address); err != nil {
+		return nil, fmt.Errorf("invalid oracle address: %w", err)
+	}
+
+	return &OracleAccount{
+		address: address,
+	}, nil
+}
+
+// SealOracleMnemonic seals the mnemonic of the oracle account to the oracle-mnemonic-file.
+func SealOracleMnemonic(conf *config.Config, oracleSgx sgx.Sgx, mnemonic string) error {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return fmt.Errorf("invalid mnemonic")
+	}
+
+	if err := oracleSgx.SealToFile([]byte(mnemonic), conf.AbsOracleMnemonicPath(), sgx.SealPolicy(conf.Sealing.OracleMnemonic)); err != nil {
+		return fmt.Errorf("failed to seal the oracle mnemonic: %w", err)
+	}
+	return nil
+}
+
+// LoadOracleMnemonic returns the mnemonic of the oracle account unsealed from the oracle-mnemonic-file.
+// The plain oracle-mnemonic in the config is refused unless allow-plaintext-mnemonic is set.
+func LoadOracleMnemonic(conf *config.Config, oracleSgx sgx.Sgx) (string, error) {
+	if conf.OracleMnemonic != "" {
+		if !conf.AllowPlaintextMnemonic {
+			return "", fmt.Errorf("oracle-mnemonic is in plain text. import it by 'oracled keys import' and remove it from the config, or set allow-plaintext-mnemonic to true")
+		}
+		log.Warn("the plain oracle-mnemonic in the config is used. it is not protected by the enclave")
+		return conf.OracleMnemonic, nil
+	}
+
+	if !tos.FileExists(conf.AbsOracleMnemonicPath()) {
+		return "", fmt.Errorf("oracle mnemonic is not found in %s. import it by 'oracled keys import'", conf.AbsOracleMnemonicPath())
+	}
+
+	mnemonic, err := oracleSgx.UnsealFromFile(conf.AbsOracleMnemonicPath())
+	if err != nil {
+		return "", fmt.Errorf("failed to unseal the oracle mnemonic: %w", err)
+	}
+	return string(mnemonic), nil
+}
+
+// WithGranter returns the oracle account which signs txs on behalf of the granter via authz.
+// Then, the granter is the oracle in Panacea, and this account is a low-privilege key granted by it.
+func (oa OracleAccount) WithGranter(granter string) (*OracleAccount, error) {
+	if _, err := GetAccAddressFromBech32(granter); err != nil {
+		return nil, fmt.Errorf("invalid authz granter address: %w", err)
+	}
+
+	oa.granter = granter
+	return &oa, nil
+}
+
+// GetAddress returns the address of the oracle in Panacea, which is the address of the granter if authz is used.
 func (oa OracleAccount) GetAddress() string {
+	if oa.granter != "" {
+		return oa.granter
+	}
+	return oa.GetSignerAddress()
+}
+
+// GetSignerAddress returns the address of the key which signs txs.
+func (oa OracleAccount) GetSignerAddress() string {
+	if oa.pubKey == nil {
+		return oa.address
+	}
+
 	address, err := bech32.ConvertAndEncode(prefix, oa.pubKey.Address().Bytes())
 	if err != nil {
 		log.Panic(err)
@@ -42,7 +118,16 @@ func (oa OracleAccount) GetAddress() string {
 	return address
 }
 
+// GetGranter returns the address of the authz granter, or an empty string if authz is not used.
+func (oa OracleAccount) GetGranter() string {
+	return oa.granter
+}
+
 func (oa OracleAccount) AccAddressFromBech32() sdk.AccAddress {
+	if oa.pubKey == nil {
+		acc, _ := GetAccAddressFromBech32(oa.address)
+		return acc
+	}
 	return oa.pubKey.Address().Bytes()
 }
 
@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/medibloc/panacea-oracle/config"
 	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/mocks"
 	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/stretchr/testify/require"
 )
@@ -18,3 +20,71 @@ func TestNewOracleAccount(t *testing.T) {
 	require.True(t, strings.HasPrefix(oracleAcc.GetAddress(), "panacea1"))
 	require.Equal(t, oracleAcc.GetPubKey().Address().Bytes(), oracleAcc.AccAddressFromBech32().Bytes())
 }
+
+func TestOracleAccountWithGranter(t *testing.T) {
+	mnemonic, err := crypto.NewMnemonic()
+	require.NoError(t, err)
+	granterAcc, err := panacea.NewOracleAccount(mnemonic, 0, 1)
+	require.NoError(t, err)
+
+	oracleAcc, err := panacea.NewOracleAccount(mnemonic, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "", oracleAcc.GetGranter())
+
+	hotAcc, err := oracleAcc.WithGranter(granterAcc.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, granterAcc.GetAddress(), hotAcc.GetAddress())
+	require.Equal(t, granterAcc.GetAddress(), hotAcc.GetGranter())
+	require.Equal(t, oracleAcc.GetAddress(), hotAcc.GetSignerAddress())
+
+	_, err = oracleAcc.WithGranter("invalid")
+	require.Error(t, err)
+}
+
+func TestNewOracleAddressAccount(t *testing.T) {
+	mnemonic, err := crypto.NewMnemonic()
+	require.NoError(t, err)
+	oracleAcc, err := panacea.NewOracleAccount(mnemonic, 0, 0)
+	require.NoError(t, err)
+
+	addressAcc, err := panacea.NewOracleAddressAccount(oracleAcc.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, oracleAcc.GetAddress(), addressAcc.GetAddress())
+	require.Equal(t, oracleAcc.GetAddress(), addressAcc.GetSignerAddress())
+	require.Equal(t, oracleAcc.AccAddressFromBech32(), addressAcc.AccAddressFromBech32())
+	require.Nil(t, addressAcc.GetPrivKey())
+
+	_, err = panacea.NewOracleAddressAccount("invalid")
+	require.Error(t, err)
+}
+
+func TestLoadOracleMnemonic(t *testing.T) {
+	mnemonic, err := crypto.NewMnemonic()
+	require.NoError(t, err)
+
+	conf := config.DefaultConfig()
+	conf.SetHomeDir(t.TempDir())
+	oracleSgx := mocks.MockSGX{}
+
+	_, err = panacea.LoadOracleMnemonic(conf, oracleSgx)
+	require.ErrorContains(t, err, "oracled keys import")
+
+	require.Error(t, panacea.SealOracleMnemonic(conf, oracleSgx, "invalid mnemonic"))
+	require.NoError(t, panacea.SealOracleMnemonic(conf, oracleSgx, mnemonic))
+
+	loaded, err := panacea.LoadOracleMnemonic(conf, oracleSgx)
+	require.NoError(t, err)
+	require.Equal(t, mnemonic, loaded)
+
+	// the plain mnemonic is refused unless it is allowed explicitly
+	plainMnemonic, err := crypto.NewMnemonic()
+	require.NoError(t, err)
+	conf.OracleMnemonic = plainMnemonic
+	_, err = panacea.LoadOracleMnemonic(conf, oracleSgx)
+	require.ErrorContains(t, err, "oracle-mnemonic is in plain text")
+
+	conf.AllowPlaintextMnemonic = true
+	loaded, err = panacea.LoadOracleMnemonic(conf, oracleSgx)
+	require.NoError(t, err)
+	require.Equal(t, plainMnemonic, loaded)
+}
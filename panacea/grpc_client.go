@@ -7,9 +7,13 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -18,9 +22,18 @@ import (
 type GRPCClient interface {
 	Close() error
 	BroadcastTx(txBytes []byte) (*tx.BroadcastTxResponse, error)
+	Simulate(txBytes []byte) (*tx.SimulateResponse, error)
+	GetTx(txHash string) (*tx.GetTxResponse, error)
+	GetMinGasPrices() (sdk.DecCoins, error)
 	GetCdc() *codec.ProtoCodec
 	GetChainID() string
 	GetAccount(address string) (authtypes.AccountI, error)
+	GetOracleRegistration(uniqueID, oracleAddr string) (*oracletypes.OracleRegistration, error)
+	GetOracleUpgrade(uniqueID, oracleAddr string) (*oracletypes.OracleUpgrade, error)
+	GetOracleUpgradeInfo() (*oracletypes.OracleUpgradeInfo, error)
+	GetOracleRegistrations(uniqueID string) ([]*oracletypes.OracleRegistration, error)
+	GetOracleUpgrades(uniqueID string) ([]*oracletypes.OracleUpgrade, error)
+	GetOracles() ([]*oracletypes.Oracle, error)
 }
 
 var _ GRPCClient = &grpcClient{}
@@ -64,18 +77,63 @@ func (c *grpcClient) Close() error {
 	return c.conn.Close()
 }
 
+// BroadcastTx broadcasts the tx, and returns the result of CheckTx without waiting for the tx to be included in a block.
 func (c *grpcClient) BroadcastTx(txBytes []byte) (*tx.BroadcastTxResponse, error) {
 	txClient := tx.NewServiceClient(c.conn)
 
 	return txClient.BroadcastTx(
 		context.Background(),
 		&tx.BroadcastTxRequest{
-			Mode:    tx.BroadcastMode_BROADCAST_MODE_BLOCK,
+			Mode:    tx.BroadcastMode_BROADCAST_MODE_SYNC,
 			TxBytes: txBytes,
 		},
 	)
 }
 
+// Simulate simulates the tx to estimate the gas.
+func (c *grpcClient) Simulate(txBytes []byte) (*tx.SimulateResponse, error) {
+	txClient := tx.NewServiceClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	response, err := txClient.Simulate(ctx, &tx.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx via grpc: %w", err)
+	}
+	return response, nil
+}
+
+// GetTx queries the tx included in a block. It returns an error if the tx is not found.
+func (c *grpcClient) GetTx(txHash string) (*tx.GetTxResponse, error) {
+	txClient := tx.NewServiceClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	response, err := txClient.GetTx(ctx, &tx.GetTxRequest{Hash: txHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx via grpc: %w", err)
+	}
+	return response, nil
+}
+
+// GetMinGasPrices queries the minimum gas prices of the node which the oracle is connected to.
+func (c *grpcClient) GetMinGasPrices() (sdk.DecCoins, error) {
+	client := node.NewServiceClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	response, err := client.Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node config via grpc: %w", err)
+	}
+
+	prices, err := sdk.ParseDecCoins(response.MinimumGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum gas prices of the node: %w", err)
+	}
+	return prices, nil
+}
+
 func (c *grpcClient) GetCdc() *codec.ProtoCodec {
 	return c.cdc
 }
@@ -100,3 +158,122 @@ func (c *grpcClient) GetAccount(address string) (authtypes.AccountI, error) {
 	}
 	return acc, nil
 }
+
+// GetOracleRegistration queries the oracle registration via gRPC without verification by the light client.
+func (c *grpcClient) GetOracleRegistration(uniqueID, oracleAddr string) (*oracletypes.OracleRegistration, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	response, err := client.OracleRegistration(ctx, &oracletypes.QueryOracleRegistrationRequest{
+		UniqueId:      uniqueID,
+		OracleAddress: oracleAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle registration via grpc: %w", err)
+	}
+	return response.OracleRegistration, nil
+}
+
+// GetOracleUpgrade queries the oracle upgrade via gRPC without verification by the light client.
+func (c *grpcClient) GetOracleUpgrade(uniqueID, oracleAddr string) (*oracletypes.OracleUpgrade, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	response, err := client.OracleUpgrade(ctx, &oracletypes.QueryOracleUpgradeRequest{
+		UniqueId:      uniqueID,
+		OracleAddress: oracleAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle upgrade via grpc: %w", err)
+	}
+	return response.OracleUpgrade, nil
+}
+
+// GetOracleUpgradeInfo queries the oracle upgrade info via gRPC without verification by the light client.
+func (c *grpcClient) GetOracleUpgradeInfo() (*oracletypes.OracleUpgradeInfo, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	response, err := client.OracleUpgradeInfo(ctx, &oracletypes.QueryOracleUpgradeInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle upgrade info via grpc: %w", err)
+	}
+	return response.OracleUpgradeInfo, nil
+}
+
+// GetOracleRegistrations queries all the oracle registrations of the unique ID via gRPC without verification by the light client.
+func (c *grpcClient) GetOracleRegistrations(uniqueID string) ([]*oracletypes.OracleRegistration, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+
+	var registrations []*oracletypes.OracleRegistration
+	var nextKey []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		response, err := client.OracleRegistrations(ctx, &oracletypes.QueryOracleRegistrationsRequest{
+			UniqueId:   uniqueID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oracle registrations via grpc: %w", err)
+		}
+
+		registrations = append(registrations, response.OracleRegistrations...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return registrations, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
+
+// GetOracleUpgrades queries all the oracle upgrades of the unique ID via gRPC without verification by the light client.
+func (c *grpcClient) GetOracleUpgrades(uniqueID string) ([]*oracletypes.OracleUpgrade, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+
+	var upgrades []*oracletypes.OracleUpgrade
+	var nextKey []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		response, err := client.OracleUpgrades(ctx, &oracletypes.QueryOracleUpgradesRequest{
+			UniqueId:   uniqueID,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oracle upgrades via grpc: %w", err)
+		}
+
+		upgrades = append(upgrades, response.OracleUpgrades...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return upgrades, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
+
+// GetOracles queries all the registered oracles via gRPC without verification by the light client.
+func (c *grpcClient) GetOracles() ([]*oracletypes.Oracle, error) {
+	client := oracletypes.NewQueryClient(c.conn)
+
+	var oracles []*oracletypes.Oracle
+	var nextKey []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		response, err := client.Oracles(ctx, &oracletypes.QueryOraclesRequest{
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oracles via grpc: %w", err)
+		}
+
+		oracles = append(oracles, response.Oracles...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			return oracles, nil
+		}
+		nextKey = response.Pagination.NextKey
+	}
+}
@@ -0,0 +1,194 @@
+package panacea
+
+import (
+	"container/list"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/config"
+)
+
+// queryKind is a kind of the verified queries, which has its own max age in the cache.
+type queryKind int
+
+const (
+	accountQuery queryKind = iota
+	dealQuery
+	oracleQuery
+	paramsQuery
+)
+
+type cachedResult struct {
+	key       string
+	height    int64 // the height at which the value is queried
+	value     []byte
+	fetchedAt time.Time
+	// invalidatedAt is the height at which the data is changed. The results queried before it are never cached.
+	invalidatedAt int64
+	// empty is true if only the invalidation is recorded without a result.
+	empty bool
+}
+
+// queryCache is an LRU cache of the verified query results, keyed by store key and key.
+// A result is served only for the queries at its height or later, until it gets older than the max age of its kind.
+// The invalidations are kept in the cache with the results, so that a result queried before the change is not cached
+// even if the query finishes after the invalidation.
+type queryCache struct {
+	mtx        sync.Mutex
+	maxEntries int
+	maxAges    map[queryKind]time.Duration
+	entries    map[string]*list.Element
+	lru        *list.List // the most recently used result is at the front
+	// minHeight is the highest invalidation removed from the cache. The results queried before it are never cached.
+	minHeight int64
+	now       func() time.Time
+}
+
+func newQueryCache(conf config.QueryCacheConfig) *queryCache {
+	return &queryCache{
+		maxEntries: conf.MaxEntries,
+		maxAges: map[queryKind]time.Duration{
+			accountQuery: conf.AccountMaxAge,
+			dealQuery:    conf.DealMaxAge,
+			oracleQuery:  conf.OracleMaxAge,
+			paramsQuery:  conf.ParamsMaxAge,
+		},
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+func queryCacheKey(storeKey string, key []byte) string {
+	return storeKey + "/" + hex.EncodeToString(key)
+}
+
+func (c *queryCache) enabled(kind queryKind) bool {
+	return c.maxEntries > 0 && c.maxAges[kind] > 0
+}
+
+// get returns the cached result for the query at the height, if it is fresh.
+func (c *queryCache) get(kind queryKind, storeKey string, key []byte, height int64) ([]byte, bool) {
+	if !c.enabled(kind) {
+		return nil, false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[queryCacheKey(storeKey, key)]
+	if !ok {
+		return nil, false
+	}
+
+	result := elem.Value.(*cachedResult)
+	if result.empty {
+		return nil, false
+	}
+	if c.now().Sub(result.fetchedAt) > c.maxAges[kind] {
+		c.removeElement(elem)
+		return nil, false
+	}
+	if result.height > height {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return result.value, true
+}
+
+// set caches the result queried at the height. The least recently used result is evicted if the cache is full.
+// The result is not cached if the data has been invalidated at a later height.
+func (c *queryCache) set(kind queryKind, storeKey string, key []byte, height int64, value []byte) {
+	if !c.enabled(kind) {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if height < c.minHeight {
+		return
+	}
+
+	cacheKey := queryCacheKey(storeKey, key)
+	if elem, ok := c.entries[cacheKey]; ok {
+		result := elem.Value.(*cachedResult)
+		if height < result.invalidatedAt || (!result.empty && result.height > height) {
+			return
+		}
+		result.height = height
+		result.value = value
+		result.fetchedAt = c.now()
+		result.empty = false
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.push(&cachedResult{
+		key:       cacheKey,
+		height:    height,
+		value:     value,
+		fetchedAt: c.now(),
+	})
+}
+
+// drop removes the cached result queried before the height, since the data is changed at the height.
+// The height is recorded even if no result is cached, so that the results queried before it are not cached later.
+func (c *queryCache) drop(storeKey string, key []byte, height int64) {
+	if c.maxEntries == 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	cacheKey := queryCacheKey(storeKey, key)
+	elem, ok := c.entries[cacheKey]
+	if !ok {
+		c.push(&cachedResult{key: cacheKey, invalidatedAt: height, empty: true})
+		return
+	}
+
+	result := elem.Value.(*cachedResult)
+	if height > result.invalidatedAt {
+		result.invalidatedAt = height
+	}
+	if !result.empty && result.height < height {
+		result.value = nil
+		result.empty = true
+	}
+	c.lru.MoveToFront(elem)
+}
+
+// push adds the result to the front, evicting the least recently used ones if the cache is full.
+// push should be called with mtx locked.
+func (c *queryCache) push(result *cachedResult) {
+	c.entries[result.key] = c.lru.PushFront(result)
+
+	for c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// remove removes the cached result regardless of its height.
+func (c *queryCache) remove(storeKey string, key []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[queryCacheKey(storeKey, key)]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// removeElement should be called with mtx locked.
+// The invalidation of the removed result is kept in minHeight, so that it still applies.
+func (c *queryCache) removeElement(elem *list.Element) {
+	result := elem.Value.(*cachedResult)
+	if result.invalidatedAt > c.minHeight {
+		c.minHeight = result.invalidatedAt
+	}
+	c.lru.Remove(elem)
+	delete(c.entries, result.key)
+}
@@ -0,0 +1,123 @@
+package panacea
+
+import (
+	"testing"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueryCache(maxEntries int) (*queryCache, *time.Time) {
+	now := time.Now()
+	cache := newQueryCache(config.QueryCacheConfig{
+		MaxEntries:    maxEntries,
+		AccountMaxAge: time.Minute,
+		DealMaxAge:    10 * time.Second,
+	})
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestQueryCacheMaxAge(t *testing.T) {
+	cache, now := newTestQueryCache(10)
+
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 10, []byte("value1"))
+
+	value, ok := cache.get(dealQuery, "datadeal", []byte("deal1"), 12)
+	require.True(t, ok)
+	require.Equal(t, []byte("value1"), value)
+
+	// the result is not served for the queries before its height
+	_, ok = cache.get(dealQuery, "datadeal", []byte("deal1"), 9)
+	require.False(t, ok)
+
+	*now = now.Add(11 * time.Second)
+	_, ok = cache.get(dealQuery, "datadeal", []byte("deal1"), 12)
+	require.False(t, ok)
+
+	// the kind of query without max age is not cached
+	cache.set(paramsQuery, "params", []byte("key"), 10, []byte("value"))
+	_, ok = cache.get(paramsQuery, "params", []byte("key"), 10)
+	require.False(t, ok)
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	cache, _ := newTestQueryCache(2)
+
+	cache.set(accountQuery, "acc", []byte("acc1"), 10, []byte("value1"))
+	cache.set(accountQuery, "acc", []byte("acc2"), 10, []byte("value2"))
+
+	// acc1 is used more recently than acc2
+	_, ok := cache.get(accountQuery, "acc", []byte("acc1"), 10)
+	require.True(t, ok)
+
+	cache.set(accountQuery, "acc", []byte("acc3"), 10, []byte("value3"))
+	require.Equal(t, 2, cache.lru.Len())
+
+	_, ok = cache.get(accountQuery, "acc", []byte("acc2"), 10)
+	require.False(t, ok)
+	_, ok = cache.get(accountQuery, "acc", []byte("acc1"), 10)
+	require.True(t, ok)
+	_, ok = cache.get(accountQuery, "acc", []byte("acc3"), 10)
+	require.True(t, ok)
+}
+
+func TestQueryCacheDrop(t *testing.T) {
+	cache, _ := newTestQueryCache(10)
+
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 10, []byte("value1"))
+
+	// the result queried after the change is kept
+	cache.drop("datadeal", []byte("deal1"), 10)
+	_, ok := cache.get(dealQuery, "datadeal", []byte("deal1"), 10)
+	require.True(t, ok)
+
+	cache.drop("datadeal", []byte("deal1"), 11)
+	_, ok = cache.get(dealQuery, "datadeal", []byte("deal1"), 11)
+	require.False(t, ok)
+
+	// the result queried at an older height doesn't replace the newer one
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 12, []byte("value12"))
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 11, []byte("value11"))
+	value, ok := cache.get(dealQuery, "datadeal", []byte("deal1"), 12)
+	require.True(t, ok)
+	require.Equal(t, []byte("value12"), value)
+
+	// the cache is disabled if max-entries is 0
+	disabled, _ := newTestQueryCache(0)
+	disabled.set(dealQuery, "datadeal", []byte("deal1"), 10, []byte("value1"))
+	_, ok = disabled.get(dealQuery, "datadeal", []byte("deal1"), 10)
+	require.False(t, ok)
+}
+
+// TestQueryCacheDropBeforeSet tests that the result queried before an invalidation is not cached,
+// even if the query finishes after the invalidation.
+func TestQueryCacheDropBeforeSet(t *testing.T) {
+	cache, _ := newTestQueryCache(2)
+
+	cache.drop("datadeal", []byte("deal1"), 11)
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 10, []byte("value10"))
+	_, ok := cache.get(dealQuery, "datadeal", []byte("deal1"), 12)
+	require.False(t, ok)
+
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 11, []byte("value11"))
+	value, ok := cache.get(dealQuery, "datadeal", []byte("deal1"), 12)
+	require.True(t, ok)
+	require.Equal(t, []byte("value11"), value)
+
+	// the invalidation at a future height keeps the results from being cached until the height
+	cache.drop("datadeal", []byte("deal1"), 20)
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 15, []byte("value15"))
+	_, ok = cache.get(dealQuery, "datadeal", []byte("deal1"), 15)
+	require.False(t, ok)
+
+	// the invalidation evicted from the cache still applies
+	cache.set(dealQuery, "datadeal", []byte("deal2"), 20, []byte("value2"))
+	cache.set(dealQuery, "datadeal", []byte("deal3"), 20, []byte("value3"))
+	_, ok = cache.entries[queryCacheKey("datadeal", []byte("deal1"))]
+	require.False(t, ok)
+	cache.set(dealQuery, "datadeal", []byte("deal1"), 15, []byte("value15"))
+	_, ok = cache.get(dealQuery, "datadeal", []byte("deal1"), 15)
+	require.False(t, ok)
+}
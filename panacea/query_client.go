@@ -18,8 +18,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/std"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/ibc-go/v4/modules/core/23-commitment/types"
 	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
 	didtypes "github.com/medibloc/panacea-core/v2/x/did/types"
@@ -44,6 +50,8 @@ import (
 type QueryClient interface {
 	Close() error
 	GetAccount(context.Context, string) (authtypes.AccountI, error)
+	GetAccountCreatedTime(context.Context, string) (time.Time, error)
+	GetBondedStake(context.Context, string) (*sdktypes.Coin, error)
 	GetDID(context.Context, string) (*didtypes.DIDDocumentWithSeq, error)
 	GetOracleRegistration(context.Context, string, string) (*oracletypes.OracleRegistration, error)
 	GetLightBlock(height int64) (*tmtypes.LightBlock, error)
@@ -56,8 +64,13 @@ type QueryClient interface {
 	GetOracleUpgradeInfo(context.Context) (*oracletypes.OracleUpgradeInfo, error)
 	GetOracle(context.Context, string) (*oracletypes.Oracle, error)
 	VerifyTrustedBlockInfo(int64, []byte) error
+	InvalidateDeal(dealID uint64, height int64)
+	InvalidateOracle(oracleAddr string, height int64)
 }
 
+// oracleParamsPublicKeyKey is the key of the oracle public key in the params store.
+var oracleParamsPublicKeyKey = append(append([]byte(oracletypes.StoreKey), '/'), oracletypes.KeyOraclePublicKey...)
+
 const (
 	trustedPeriod       = 2 * 7 * 24 * time.Hour
 	refreshIntervalTime = time.Second * 3
@@ -78,6 +91,8 @@ type verifiedQueryClient struct {
 	cdc                   *codec.ProtoCodec
 	aminoCdc              *codec.AminoCodec
 	cachedLastBlockHeight int64
+	cache                 *queryCache
+	paramsCheckedHeight   int64 // the height up to which the param changes are applied to the cache
 }
 
 // makeInterfaceRegistry
@@ -85,9 +100,14 @@ func makeInterfaceRegistry() sdk.InterfaceRegistry {
 	interfaceRegistry := sdk.NewInterfaceRegistry()
 	std.RegisterInterfaces(interfaceRegistry)
 	authtypes.RegisterInterfaces(interfaceRegistry)
+	authz.RegisterInterfaces(interfaceRegistry)
+	oracletypes.RegisterInterfaces(interfaceRegistry)
 	return interfaceRegistry
 }
 
+// LightClientDBName is the name of the sealed DB for the light client in the data directory.
+const LightClientDBName = "light-client"
+
 // NewVerifiedQueryClient set verifiedQueryClient with rpcClient & and returns, if successful,
 // a verifiedQueryClient that can be used to add query function.
 func NewVerifiedQueryClient(ctx context.Context, config *config.Config, info *TrustedBlockInfo, sgx sgx.Sgx) (QueryClient, error) {
@@ -98,8 +118,8 @@ func LoadVerifiedQueryClient(ctx context.Context, config *config.Config, sgx sgx
 	return newVerifiedQueryClientWithSgxLevelDB(ctx, config, nil, sgx)
 }
 
-func newVerifiedQueryClientWithSgxLevelDB(ctx context.Context, config *config.Config, info *TrustedBlockInfo, sgx sgx.Sgx) (QueryClient, error) {
-	db, err := sgxdb.NewSgxLevelDB("light-client", config.AbsDataDirPath(), sgx)
+func newVerifiedQueryClientWithSgxLevelDB(ctx context.Context, config *config.Config, info *TrustedBlockInfo, oracleSgx sgx.Sgx) (QueryClient, error) {
+	db, err := sgxdb.NewSgxLevelDB(LightClientDBName, config.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(config.Sealing.LightClientDB))
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +195,7 @@ func NewVerifiedQueryClientWithDB(ctx context.Context, config *config.Config, in
 		mutex:       &lcMutex,
 		cdc:         codec.NewProtoCodec(makeInterfaceRegistry()),
 		aminoCdc:    codec.NewAminoCodec(codec.NewLegacyAmino()),
+		cache:       newQueryCache(config.QueryCache),
 	}
 
 	// If the last height is not present when oracle initially start the server, all queries will fail.
@@ -212,6 +233,43 @@ func (q *verifiedQueryClient) lastBlockCaching() error {
 	log.Debugf("Refresh last block. Height(%d)", lastHeight)
 	q.cachedLastBlockHeight = lastHeight
 
+	if err := q.invalidateChangedParams(context.Background(), lastHeight); err != nil {
+		log.Warnf("failed to check param changes: %v", err)
+	}
+
+	return nil
+}
+
+// invalidateChangedParams drops the cached params queried before the last proposal passed up to the height,
+// since the params are changed only by the governance proposals.
+func (q *verifiedQueryClient) invalidateChangedParams(ctx context.Context, height int64) error {
+	if !q.cache.enabled(paramsQuery) {
+		return nil
+	}
+	// no params are cached before the first check
+	if q.paramsCheckedHeight == 0 {
+		q.paramsCheckedHeight = height
+		return nil
+	}
+	if height <= q.paramsCheckedHeight {
+		return nil
+	}
+
+	query := fmt.Sprintf("%s.%s = '%s' AND block.height > %d AND block.height <= %d",
+		govtypes.EventTypeActiveProposal, govtypes.AttributeKeyProposalResult, govtypes.AttributeValueProposalPassed,
+		q.paramsCheckedHeight, height,
+	)
+	page, perPage := 1, 1
+	result, err := q.rpcClient.BlockSearch(ctx, query, &page, &perPage, "desc")
+	if err != nil {
+		return fmt.Errorf("failed to search passed proposals: %w", err)
+	}
+	if len(result.Blocks) > 0 {
+		// the proposal is executed at the end of the block, so the params queried at the height are already changed.
+		q.cache.drop(paramstypes.StoreKey, oracleParamsPublicKeyKey, result.Blocks[0].Block.Height)
+	}
+
+	q.paramsCheckedHeight = height
 	return nil
 }
 
@@ -243,8 +301,31 @@ func (q *verifiedQueryClient) safeVerifyLightBlockAtHeight(ctx context.Context,
 // GetStoreData get data from panacea with storeKey and key, then verify queried data with light client and merkle proof.
 // the returned data type is ResponseQuery.value ([]byte), so recommend to convert to expected type
 func (q *verifiedQueryClient) GetStoreData(ctx context.Context, storeKey string, key []byte) ([]byte, error) {
+	return q.getStoreDataAtHeight(ctx, storeKey, key, q.getQueryBlockHeight())
+}
+
+// getCachedStoreData is GetStoreData, but returns the result in the cache if it is fresh for the kind of query.
+func (q *verifiedQueryClient) getCachedStoreData(ctx context.Context, kind queryKind, storeKey string, key []byte) ([]byte, error) {
 	queryHeight := q.getQueryBlockHeight()
+	if bz, ok := q.cache.get(kind, storeKey, key, queryHeight); ok {
+		return bz, nil
+	}
+
+	bz, err := q.getStoreDataAtHeight(ctx, storeKey, key, queryHeight)
+	if err != nil {
+		return nil, err
+	}
 
+	q.cache.set(kind, storeKey, key, queryHeight, bz)
+	return bz, nil
+}
+
+func (q *verifiedQueryClient) getStoreDataAtHeight(ctx context.Context, storeKey string, key []byte, queryHeight int64) ([]byte, error) {
+	return q.queryStoreDataAtHeight(ctx, storeKey, key, queryHeight, false)
+}
+
+// queryStoreDataAtHeight queries the data with proof. If allowAbsent is true, nil is returned when the absence of the data is proven.
+func (q *verifiedQueryClient) queryStoreDataAtHeight(ctx context.Context, storeKey string, key []byte, queryHeight int64, allowAbsent bool) ([]byte, error) {
 	//set queryOption prove to true
 	option := client.ABCIQueryOptions{
 		Prove:  true,
@@ -285,6 +366,12 @@ func (q *verifiedQueryClient) GetStoreData(ctx context.Context, storeKey string,
 
 	keyPath := url.PathEscape(string(key))
 	merklePath := types.NewMerklePath(storeKey, keyPath)
+	if allowAbsent && len(result.Response.Value) == 0 {
+		if err := merkleProof.VerifyNonMembership(sdkSpecs, merkleRootKey, merklePath); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
 	err = merkleProof.VerifyMembership(sdkSpecs, merkleRootKey, merklePath, result.Response.Value)
 	if err != nil {
 		return nil, err
@@ -345,7 +432,7 @@ func (q *verifiedQueryClient) GetAccount(ctx context.Context, address string) (a
 	}
 
 	key := authtypes.AddressStoreKey(acc)
-	bz, err := q.GetStoreData(ctx, authtypes.StoreKey, key)
+	bz, err := q.getCachedStoreData(ctx, accountQuery, authtypes.StoreKey, key)
 	if err != nil {
 		return nil, err
 	}
@@ -356,9 +443,209 @@ func (q *verifiedQueryClient) GetAccount(ctx context.Context, address string) (a
 		return nil, err
 	}
 
+	// the pubkey is set by the first tx of the account, so the account without it is queried again next time.
+	if account.GetPubKey() == nil {
+		q.cache.remove(authtypes.StoreKey, key)
+	}
+
 	return account, nil
 }
 
+// GetAccountCreatedTime returns the time of the first tx which transferred tokens to the account via the bank module,
+// since an account is created when it receives tokens first.
+// The txs are searched via RPC, but only the tx whose bytes and result are verified with the light blocks is trusted,
+// since the events used by the search are not covered by the block header.
+// If there is no such tx, for example for a genesis account, the time of the earliest block whose state has the account is returned.
+func (q *verifiedQueryClient) GetAccountCreatedTime(ctx context.Context, address string) (time.Time, error) {
+	acc, err := GetAccAddressFromBech32(address)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	page, perPage := 1, 10
+	result, err := q.rpcClient.TxSearch(ctx, fmt.Sprintf("transfer.recipient='%s'", address), true, &page, &perPage, "asc")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to search txs to %s: %w", address, err)
+	}
+
+	for _, tx := range result.Txs {
+		createdTime, err := q.verifyTransferTx(ctx, tx, address)
+		if err != nil {
+			log.Debugf("tx %s is not a verified transfer to %s: %v", tx.Hash, address, err)
+			continue
+		}
+		return createdTime, nil
+	}
+
+	return q.getAccountGenesisTime(ctx, acc)
+}
+
+// verifyTransferTx verifies that the tx is included in the block, succeeded,
+// and has a message of the bank module which sends tokens to the address. It returns the time of the block.
+func (q *verifiedQueryClient) verifyTransferTx(ctx context.Context, tx *ctypes.ResultTx, address string) (time.Time, error) {
+	block, err := q.safeVerifyLightBlockAtHeight(ctx, tx.Height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to verify light block at height %d: %w", tx.Height, err)
+	}
+	if err := tx.Proof.Validate(block.DataHash); err != nil {
+		return time.Time{}, fmt.Errorf("failed to verify tx %s: %w", tx.Hash, err)
+	}
+
+	ok, err := hasTransferTo(tx.Proof.Data, address)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !ok {
+		return time.Time{}, errors.New("the tx has no bank message to the address")
+	}
+
+	// the results of the txs in a block are committed by the next block.
+	nextBlock, err := q.safeVerifyLightBlockAtHeight(ctx, tx.Height+1)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to verify light block at height %d: %w", tx.Height+1, err)
+	}
+	height := tx.Height
+	blockResults, err := q.rpcClient.BlockResults(ctx, &height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get block results at height %d: %w", height, err)
+	}
+	if !bytes.Equal(tmtypes.NewResults(blockResults.TxsResults).Hash(), nextBlock.LastResultsHash) {
+		return time.Time{}, fmt.Errorf("block results at height %d don't match the light block", height)
+	}
+
+	index := tx.Proof.Proof.Index
+	if index < 0 || index >= int64(len(blockResults.TxsResults)) {
+		return time.Time{}, fmt.Errorf("invalid tx index %d", index)
+	}
+	if code := blockResults.TxsResults[index].Code; code != 0 {
+		return time.Time{}, fmt.Errorf("the tx failed with code %d", code)
+	}
+
+	return block.Time, nil
+}
+
+// hasTransferTo returns true if the tx has a MsgSend or a MsgMultiSend to the address.
+// The messages are unmarshalled without the interface registry, since the tx may have the messages of any module.
+func hasTransferTo(txBytes []byte, address string) (bool, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(txBytes); err != nil {
+		return false, fmt.Errorf("failed to unmarshal tx: %w", err)
+	}
+	var body txtypes.TxBody
+	if err := body.Unmarshal(raw.BodyBytes); err != nil {
+		return false, fmt.Errorf("failed to unmarshal tx body: %w", err)
+	}
+
+	for _, msg := range body.Messages {
+		switch msg.TypeUrl {
+		case sdktypes.MsgTypeURL(&banktypes.MsgSend{}):
+			var send banktypes.MsgSend
+			if err := send.Unmarshal(msg.Value); err != nil {
+				return false, fmt.Errorf("failed to unmarshal MsgSend: %w", err)
+			}
+			if send.ToAddress == address {
+				return true, nil
+			}
+		case sdktypes.MsgTypeURL(&banktypes.MsgMultiSend{}):
+			var multiSend banktypes.MsgMultiSend
+			if err := multiSend.Unmarshal(msg.Value); err != nil {
+				return false, fmt.Errorf("failed to unmarshal MsgMultiSend: %w", err)
+			}
+			for _, output := range multiSend.Outputs {
+				if output.Address == address {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// getAccountGenesisTime returns the time of the earliest block kept by the node, if the account is in the state at the block.
+// It is the genesis time if the node has all blocks, or a later time if the node has pruned the old blocks.
+func (q *verifiedQueryClient) getAccountGenesisTime(ctx context.Context, acc sdktypes.AccAddress) (time.Time, error) {
+	status, err := q.rpcClient.Status(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get status: %w", err)
+	}
+	height := status.SyncInfo.EarliestBlockHeight
+
+	bz, err := q.queryStoreDataAtHeight(ctx, authtypes.StoreKey, authtypes.AddressStoreKey(acc), height, true)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get account %s at height %d: %w", acc, height, err)
+	}
+	if bz == nil {
+		return time.Time{}, fmt.Errorf("no verified tx transferred tokens to %s, and it is not a genesis account", acc)
+	}
+
+	block, err := q.safeVerifyLightBlockAtHeight(ctx, height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to verify light block at height %d: %w", height, err)
+	}
+	return block.Time, nil
+}
+
+// GetBondedStake returns the tokens that the account delegates to the bonded validators.
+// The bonded validators are taken from the validator set of the light block, and each delegation is verified at the same height.
+func (q *verifiedQueryClient) GetBondedStake(ctx context.Context, address string) (*sdktypes.Coin, error) {
+	delAddr, err := GetAccAddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+
+	height := q.getQueryBlockHeight()
+	block, err := q.safeVerifyLightBlockAtHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify light block at height %d: %w", height, err)
+	}
+
+	bondDenomBz, err := q.getStoreDataAtHeight(ctx, paramstypes.StoreKey, append([]byte(stakingtypes.StoreKey+"/"), stakingtypes.KeyBondDenom...), height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bond denom: %w", err)
+	}
+	var bondDenom string
+	if err := q.aminoCdc.LegacyAmino.UnmarshalJSON(bondDenomBz, &bondDenom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bond denom: %w", err)
+	}
+
+	stake := sdktypes.ZeroInt()
+	for _, val := range block.ValidatorSet.Validators {
+		valAddr, err := q.getStoreDataAtHeight(ctx, stakingtypes.StoreKey, stakingtypes.GetValidatorByConsAddrKey(sdktypes.ConsAddress(val.Address)), height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operator of validator %s: %w", val.Address, err)
+		}
+
+		delegationBz, err := q.queryStoreDataAtHeight(ctx, stakingtypes.StoreKey, stakingtypes.GetDelegationKey(delAddr, valAddr), height, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get delegation to %s: %w", sdktypes.ValAddress(valAddr), err)
+		}
+		if delegationBz == nil {
+			continue
+		}
+		delegation, err := stakingtypes.UnmarshalDelegation(q.cdc, delegationBz)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delegation: %w", err)
+		}
+
+		validatorBz, err := q.getStoreDataAtHeight(ctx, stakingtypes.StoreKey, stakingtypes.GetValidatorKey(valAddr), height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get validator %s: %w", sdktypes.ValAddress(valAddr), err)
+		}
+		validator, err := stakingtypes.UnmarshalValidator(q.cdc, validatorBz)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal validator: %w", err)
+		}
+		if !validator.IsBonded() {
+			continue
+		}
+
+		stake = stake.Add(validator.TokensFromShares(delegation.Shares).TruncateInt())
+	}
+
+	coin := sdktypes.NewCoin(bondDenom, stake)
+	return &coin, nil
+}
+
 func (q *verifiedQueryClient) GetDID(ctx context.Context, did string) (*didtypes.DIDDocumentWithSeq, error) {
 	key := append(didtypes.DIDKeyPrefix, []byte(did)...)
 	bz, err := q.GetStoreData(ctx, didtypes.StoreKey, key)
@@ -377,7 +664,7 @@ func (q *verifiedQueryClient) GetDID(ctx context.Context, did string) (*didtypes
 func (q *verifiedQueryClient) GetDeal(ctx context.Context, dealID uint64) (*datadealtypes.Deal, error) {
 	key := datadealtypes.GetDealKey(dealID)
 
-	bz, err := q.GetStoreData(ctx, datadealtypes.StoreKey, key)
+	bz, err := q.getCachedStoreData(ctx, dealQuery, datadealtypes.StoreKey, key)
 	if err != nil {
 		return nil, err
 	}
@@ -394,7 +681,8 @@ func (q *verifiedQueryClient) GetConsent(ctx context.Context, dealID uint64, dat
 
 	key := datadealtypes.GetConsentKey(dealID, dataHash)
 
-	bz, err := q.GetStoreData(ctx, datadealtypes.StoreKey, key)
+	// a consent is not changed once it is submitted.
+	bz, err := q.getCachedStoreData(ctx, dealQuery, datadealtypes.StoreKey, key)
 	if err != nil {
 		return nil, err
 	}
@@ -436,7 +724,7 @@ func (q *verifiedQueryClient) GetOracle(ctx context.Context, oracleAddr string)
 		return nil, fmt.Errorf("failed to get AccAddr from Bech32 address(%s): %w", oracleAddr, err)
 	}
 
-	bz, err := q.GetStoreData(ctx, oracletypes.StoreKey, oracletypes.GetOracleKey(acc))
+	bz, err := q.getCachedStoreData(ctx, oracleQuery, oracletypes.StoreKey, oracletypes.GetOracleKey(acc))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get oracle from data: %w", err)
 	}
@@ -450,7 +738,7 @@ func (q *verifiedQueryClient) GetOracle(ctx context.Context, oracleAddr string)
 }
 
 func (q *verifiedQueryClient) GetOracleParamsPublicKey(ctx context.Context) (*btcec.PublicKey, error) {
-	pubKeyBase64Bz, err := q.GetStoreData(ctx, paramstypes.StoreKey, append(append([]byte(oracletypes.StoreKey), '/'), oracletypes.KeyOraclePublicKey...))
+	pubKeyBase64Bz, err := q.getCachedStoreData(ctx, paramsQuery, paramstypes.StoreKey, oracleParamsPublicKeyKey)
 	if err != nil {
 		return nil, err
 	}
@@ -525,6 +813,21 @@ func (q *verifiedQueryClient) GetLastBlockHeight(ctx context.Context) (int64, er
 	return trustedBlock.Height, nil
 }
 
+// InvalidateDeal drops the cached deal queried before the height, since the deal is changed at the height.
+func (q *verifiedQueryClient) InvalidateDeal(dealID uint64, height int64) {
+	q.cache.drop(datadealtypes.StoreKey, datadealtypes.GetDealKey(dealID), height)
+}
+
+// InvalidateOracle drops the cached oracle queried before the height, since the oracle is changed at the height.
+func (q *verifiedQueryClient) InvalidateOracle(oracleAddr string, height int64) {
+	acc, err := GetAccAddressFromBech32(oracleAddr)
+	if err != nil {
+		// the oracle of an invalid address is never cached
+		return
+	}
+	q.cache.drop(oracletypes.StoreKey, oracletypes.GetOracleKey(acc), height)
+}
+
 func (q *verifiedQueryClient) GetCachedLastBlockHeight() int64 {
 	return q.cachedLastBlockHeight
 }
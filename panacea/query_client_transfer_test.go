@@ -0,0 +1,56 @@
+package panacea
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTxBytes(t *testing.T, msgs ...sdktypes.Msg) []byte {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		any, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys[i] = any
+	}
+
+	body := txtypes.TxBody{Messages: anys}
+	bodyBytes, err := body.Marshal()
+	require.NoError(t, err)
+
+	raw := txtypes.TxRaw{BodyBytes: bodyBytes}
+	txBytes, err := raw.Marshal()
+	require.NoError(t, err)
+	return txBytes
+}
+
+func TestHasTransferTo(t *testing.T) {
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin("umed", 1))
+	other := &oracletypes.MsgUpdateOracleInfo{OracleAddress: "panacea1recipient"}
+
+	txBytes := newTestTxBytes(t, other, &banktypes.MsgSend{FromAddress: "panacea1sender", ToAddress: "panacea1recipient", Amount: coins})
+	ok, err := hasTransferTo(txBytes, "panacea1recipient")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	txBytes = newTestTxBytes(t, &banktypes.MsgMultiSend{
+		Inputs:  []banktypes.Input{{Address: "panacea1sender", Coins: coins}},
+		Outputs: []banktypes.Output{{Address: "panacea1recipient", Coins: coins}},
+	})
+	ok, err = hasTransferTo(txBytes, "panacea1recipient")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the address in a message of another module is not a transfer
+	ok, err = hasTransferTo(newTestTxBytes(t, other), "panacea1recipient")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = hasTransferTo([]byte("invalid"), "panacea1recipient")
+	require.Error(t, err)
+}
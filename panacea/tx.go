@@ -2,15 +2,20 @@ package panacea
 
 import (
 	"fmt"
+	"math"
 
 	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/medibloc/panacea-oracle/config"
+	log "github.com/sirupsen/logrus"
 )
 
 type TxBuilder struct {
@@ -23,18 +28,212 @@ func NewTxBuilder(client GRPCClient) *TxBuilder {
 	}
 }
 
-// GenerateTxBytes generates transaction byte array.
+// GenerateTxBytes generates transaction byte array, signed with the account number and the sequence read from chain.
 func (tb TxBuilder) GenerateTxBytes(privKey cryptotypes.PrivKey, conf *config.Config, msg ...sdk.Msg) ([]byte, error) {
-	defaultFeeAmount, err := sdk.ParseCoinsNormalized(conf.Panacea.DefaultFeeAmount)
+	signerAccount, err := tb.getSignerAccount(privKey)
 	if err != nil {
 		return nil, err
 	}
-	txBytes, err := tb.GenerateSignedTxBytes(privKey, conf.Panacea.DefaultGasLimit, defaultFeeAmount, msg...)
+
+	return tb.GenerateTxBytesWithSequence(privKey, conf, signerAccount.GetAccountNumber(), signerAccount.GetSequence(), msg...)
+}
+
+// GenerateTxBytesWithSequence generates transaction byte array signed with the account number and the sequence.
+// The gas limit is estimated by simulation, and the fee is computed from the minimum gas prices.
+// If an authz granter is configured, the msgs are executed on behalf of the granter by MsgExec.
+// If a fee granter is configured, the fee is paid by the fee granter.
+func (tb TxBuilder) GenerateTxBytesWithSequence(privKey cryptotypes.PrivKey, conf *config.Config, accountNumber, sequence uint64, msg ...sdk.Msg) ([]byte, error) {
+	msg, err := wrapMsgs(privKey, conf, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	feeGranter, err := getFeeGranter(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := tb.estimateGas(privKey, conf, accountNumber, sequence, feeGranter, msg...)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := tb.EstimateFee(conf, gasLimit)
 	if err != nil {
 		return nil, err
 	}
 
-	return txBytes, nil
+	return tb.signTx(privKey, accountNumber, sequence, gasLimit, fee, feeGranter, msg...)
+}
+
+// wrapMsgs wraps the msgs into MsgExec if an authz granter is configured, so that the signer executes them on behalf of the granter.
+func wrapMsgs(privKey cryptotypes.PrivKey, conf *config.Config, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	if conf.Panacea.AuthzGranter == "" {
+		return msgs, nil
+	}
+
+	msgExec := authz.NewMsgExec(sdk.AccAddress(privKey.PubKey().Address()), msgs)
+	// the bech32 prefix of the SDK config is not set to Panacea's.
+	grantee, err := bech32.ConvertAndEncode(prefix, privKey.PubKey().Address().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	msgExec.Grantee = grantee
+
+	return []sdk.Msg{&msgExec}, nil
+}
+
+func getFeeGranter(conf *config.Config) (string, error) {
+	if conf.Panacea.FeeGranter == "" {
+		return "", nil
+	}
+
+	if _, err := GetAccAddressFromBech32(conf.Panacea.FeeGranter); err != nil {
+		return "", fmt.Errorf("invalid fee granter address: %w", err)
+	}
+	return conf.Panacea.FeeGranter, nil
+}
+
+// EstimateGas simulates the msgs and returns the gas used multiplied by the gas multiplier.
+// If the gas multiplier is 0, the default gas limit is returned without simulation.
+func (tb TxBuilder) EstimateGas(privKey cryptotypes.PrivKey, conf *config.Config, msg ...sdk.Msg) (uint64, error) {
+	signerAccount, err := tb.getSignerAccount(privKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return tb.estimateGas(privKey, conf, signerAccount.GetAccountNumber(), signerAccount.GetSequence(), "", msg...)
+}
+
+func (tb TxBuilder) estimateGas(privKey cryptotypes.PrivKey, conf *config.Config, accountNumber, sequence uint64, feeGranter string, msg ...sdk.Msg) (uint64, error) {
+	if conf.Panacea.GasMultiplier == 0 {
+		return conf.Panacea.DefaultGasLimit, nil
+	}
+
+	txBytes, err := tb.signTx(privKey, accountNumber, sequence, 0, sdk.NewCoins(), feeGranter, msg...)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tb.client.Simulate(txBytes)
+	if err != nil {
+		return 0, err
+	}
+	if res == nil || res.GasInfo == nil {
+		return 0, fmt.Errorf("no gas info in the simulation result")
+	}
+
+	return uint64(math.Ceil(float64(res.GasInfo.GasUsed) * conf.Panacea.GasMultiplier)), nil
+}
+
+// EstimateFee returns the fee for the gas limit, which is computed from the configured minimum gas prices,
+// or from the minimum gas prices of the node if they are not configured.
+// The fee is paid in a single denom, which is the configured fee denom or the first denom of the gas prices.
+// If there is no gas price, the default fee amount is returned.
+// It returns an error if the fee exceeds the max fee amount.
+func (tb TxBuilder) EstimateFee(conf *config.Config, gasLimit uint64) (sdk.Coins, error) {
+	gasPrices, err := tb.minGasPrices(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var fee sdk.Coins
+	if gasPrices.IsZero() {
+		fee, err = sdk.ParseCoinsNormalized(conf.Panacea.DefaultFeeAmount)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		gasPrice := gasPrices[0]
+		if conf.Panacea.FeeDenom != "" {
+			found := false
+			for _, price := range gasPrices {
+				if price.Denom == conf.Panacea.FeeDenom {
+					gasPrice, found = price, true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no gas price in the fee denom %s: %s", conf.Panacea.FeeDenom, gasPrices)
+			}
+		}
+
+		gas := sdk.NewDec(int64(gasLimit))
+		fee = sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(gas).Ceil().RoundInt()))
+	}
+
+	if conf.Panacea.MaxFeeAmount != "" {
+		maxFee, err := sdk.ParseCoinsNormalized(conf.Panacea.MaxFeeAmount)
+		if err != nil {
+			return nil, err
+		}
+		if !fee.IsAllLTE(maxFee) {
+			return nil, fmt.Errorf("fee %s for gas %d exceeds the max fee amount %s", fee, gasLimit, maxFee)
+		}
+	}
+
+	return fee, nil
+}
+
+func (tb TxBuilder) minGasPrices(conf *config.Config) (sdk.DecCoins, error) {
+	if conf.Panacea.MinGasPrices != "" {
+		return sdk.ParseDecCoins(conf.Panacea.MinGasPrices)
+	}
+
+	gasPrices, err := tb.client.GetMinGasPrices()
+	if err != nil {
+		log.Warnf("failed to get the minimum gas prices of the node. using the default fee amount: %v", err)
+		return sdk.DecCoins{}, nil
+	}
+	return gasPrices, nil
+}
+
+// GenerateUnsignedTxJSON generates the unsigned tx of the msgs in JSON, so that it is signed offline by the signer of the msgs.
+// The gas limit is the default gas limit, because the tx cannot be simulated without the signer's key.
+func (tb TxBuilder) GenerateUnsignedTxJSON(conf *config.Config, msg ...sdk.Msg) ([]byte, error) {
+	fee, err := tb.EstimateFee(conf, conf.Panacea.DefaultGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	txConfig := authtx.NewTxConfig(tb.client.GetCdc(), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
+	txBuilder := txConfig.NewTxBuilder()
+	txBuilder.SetGasLimit(conf.Panacea.DefaultGasLimit)
+	txBuilder.SetFeeAmount(fee)
+
+	if err := txBuilder.SetMsgs(msg...); err != nil {
+		return nil, err
+	}
+
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// DecodeSignedTxJSON decodes the tx signed offline in JSON, and returns the tx bytes to be broadcast with the msgs in the tx.
+func (tb TxBuilder) DecodeSignedTxJSON(txJSON []byte) ([]byte, []sdk.Msg, error) {
+	txConfig := authtx.NewTxConfig(tb.client.GetCdc(), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
+
+	tx, err := txConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode tx JSON: %w", err)
+	}
+
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid tx type: %T", tx)
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sigs) == 0 {
+		return nil, nil, fmt.Errorf("tx is not signed")
+	}
+
+	txBytes, err := txConfig.TxEncoder()(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txBytes, tx.GetMsgs(), nil
 }
 
 // GenerateSignedTxBytes signs msgs using the private key and returns the signed Tx message in form of byte array.
@@ -44,15 +243,15 @@ func (tb TxBuilder) GenerateSignedTxBytes(
 	feeAmount sdk.Coins,
 	msg ...sdk.Msg,
 ) ([]byte, error) {
-	txConfig := authtx.NewTxConfig(tb.client.GetCdc(), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
-	txBuilder := txConfig.NewTxBuilder()
-	txBuilder.SetGasLimit(gasLimit)
-	txBuilder.SetFeeAmount(feeAmount)
-
-	if err := txBuilder.SetMsgs(msg...); err != nil {
+	signerAccount, err := tb.getSignerAccount(privateKey)
+	if err != nil {
 		return nil, err
 	}
 
+	return tb.signTx(privateKey, signerAccount.GetAccountNumber(), signerAccount.GetSequence(), gasLimit, feeAmount, "", msg...)
+}
+
+func (tb TxBuilder) getSignerAccount(privateKey cryptotypes.PrivKey) (authtypes.AccountI, error) {
 	signerAddress, err := bech32.ConvertAndEncode(prefix, privateKey.PubKey().Address().Bytes())
 	if err != nil {
 		return nil, err
@@ -62,6 +261,36 @@ func (tb TxBuilder) GenerateSignedTxBytes(
 	if err != nil {
 		return nil, fmt.Errorf("can not get signer account from address(%s): %w", signerAddress, err)
 	}
+	return signerAccount, nil
+}
+
+func (tb TxBuilder) signTx(
+	privateKey cryptotypes.PrivKey,
+	accountNumber uint64,
+	sequence uint64,
+	gasLimit uint64,
+	feeAmount sdk.Coins,
+	feeGranter string,
+	msg ...sdk.Msg,
+) ([]byte, error) {
+	txConfig := authtx.NewTxConfig(tb.client.GetCdc(), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
+	txBuilder := txConfig.NewTxBuilder()
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(feeAmount)
+	if feeGranter != "" {
+		// SetFeeGranter encodes the address with the bech32 prefix of the SDK config, which is not Panacea's.
+		// So, the fee granter is set to the proto tx directly after the cached auth info is invalidated by SetFeeGranter.
+		txBuilder.SetFeeGranter(nil)
+		protoTx, ok := txBuilder.(interface{ GetProtoTx() *txtypes.Tx })
+		if !ok {
+			return nil, fmt.Errorf("failed to set fee granter to the tx")
+		}
+		protoTx.GetProtoTx().AuthInfo.Fee.Granter = feeGranter
+	}
+
+	if err := txBuilder.SetMsgs(msg...); err != nil {
+		return nil, err
+	}
 
 	sigV2 := signing.SignatureV2{
 		PubKey: privateKey.PubKey(),
@@ -69,7 +298,7 @@ func (tb TxBuilder) GenerateSignedTxBytes(
 			SignMode:  signing.SignMode_SIGN_MODE_DIRECT,
 			Signature: nil,
 		},
-		Sequence: signerAccount.GetSequence(),
+		Sequence: sequence,
 	}
 
 	if err := txBuilder.SetSignatures(sigV2); err != nil {
@@ -78,17 +307,17 @@ func (tb TxBuilder) GenerateSignedTxBytes(
 
 	signerData := authsigning.SignerData{
 		ChainID:       tb.client.GetChainID(),
-		AccountNumber: signerAccount.GetAccountNumber(),
-		Sequence:      signerAccount.GetSequence(),
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
 	}
 
-	sigV2, err = clienttx.SignWithPrivKey(
+	sigV2, err := clienttx.SignWithPrivKey(
 		signing.SignMode_SIGN_MODE_DIRECT,
 		signerData,
 		txBuilder,
 		privateKey,
 		txConfig,
-		signerAccount.GetSequence(),
+		sequence,
 	)
 	if err != nil {
 		return nil, err
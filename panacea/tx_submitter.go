@@ -0,0 +1,201 @@
+package panacea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/medibloc/panacea-oracle/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultTxConfirmPollInterval = time.Second
+
+	maxSequenceRetries = 3
+)
+
+// sequenceMismatchRegexp matches the error of the ante handler for a wrong sequence.
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// TxSubmitter signs and broadcasts the txs of an account one by one, tracking the sequence of the account locally.
+// So, the txs submitted concurrently don't collide with the same sequence.
+// The sequence is read from chain at first, and whenever it turns out to be wrong.
+type TxSubmitter struct {
+	builder *TxBuilder
+	client  GRPCClient
+	privKey cryptotypes.PrivKey
+	conf    *config.Config
+
+	pollInterval time.Duration
+
+	mtx           sync.Mutex
+	synced        bool // false if the sequence should be read from chain
+	accountNumber uint64
+	sequence      uint64
+}
+
+func NewTxSubmitter(client GRPCClient, privKey cryptotypes.PrivKey, conf *config.Config) *TxSubmitter {
+	return &TxSubmitter{
+		builder:      NewTxBuilder(client),
+		client:       client,
+		privKey:      privKey,
+		conf:         conf,
+		pollInterval: defaultTxConfirmPollInterval,
+	}
+}
+
+// Submit broadcasts the msgs in a tx, and waits until the tx is included in a block or the tx-confirm-timeout passes.
+// It returns the result of the tx in the block. If the tx failed in the block, the result is returned with an error.
+func (s *TxSubmitter) Submit(ctx context.Context, msg ...sdk.Msg) (*sdk.TxResponse, error) {
+	txHash, err := s.broadcast(msg...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.confirm(ctx, txHash)
+}
+
+// confirm waits for the tx to be included in a block, and returns an error if the tx failed in the block.
+func (s *TxSubmitter) confirm(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	res, err := s.waitForTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction %s failed: %v", txHash, res.RawLog)
+	}
+	return res, nil
+}
+
+// SubmitSigned broadcasts the tx signed by another account, and waits until the tx is included in a block like Submit.
+// The sequence of this submitter is not used.
+func (s *TxSubmitter) SubmitSigned(ctx context.Context, txBytes []byte) (*sdk.TxResponse, error) {
+	res, err := s.client.BroadcastTx(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	if res.TxResponse.Code != 0 {
+		return nil, fmt.Errorf("transaction is rejected: %v", res.TxResponse.RawLog)
+	}
+
+	return s.confirm(ctx, res.TxResponse.TxHash)
+}
+
+// broadcast signs the msgs with the next sequence and broadcasts them.
+// If the sequence is wrong, it retries with the sequence expected by the chain.
+func (s *TxSubmitter) broadcast(msg ...sdk.Msg) (string, error) {
+	if s.privKey == nil {
+		return "", errors.New("no key to sign the tx. sign it offline and broadcast it by broadcast-signed-tx")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		txHash, err := s.tryBroadcast(msg...)
+		if err == nil {
+			return txHash, nil
+		}
+
+		if !strings.Contains(err.Error(), "account sequence mismatch") || attempt >= maxSequenceRetries {
+			return "", err
+		}
+
+		if expected, ok := expectedSequence(err); ok {
+			s.sequence = expected
+		} else {
+			s.synced = false
+		}
+		log.Warnf("retry the tx with a new sequence: %v", err)
+	}
+}
+
+// tryBroadcast should be called with mtx locked.
+func (s *TxSubmitter) tryBroadcast(msg ...sdk.Msg) (string, error) {
+	if !s.synced {
+		if err := s.syncSequence(); err != nil {
+			return "", err
+		}
+	}
+
+	txBytes, err := s.builder.GenerateTxBytesWithSequence(s.privKey, s.conf, s.accountNumber, s.sequence, msg...)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signed Tx bytes: %w", err)
+	}
+
+	res, err := s.client.BroadcastTx(txBytes)
+	if err != nil {
+		// the tx may have been accepted. so, the sequence is read from chain again for the next tx.
+		s.synced = false
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	if res.TxResponse.Code != 0 {
+		return "", fmt.Errorf("transaction is rejected: %v", res.TxResponse.RawLog)
+	}
+
+	s.sequence++
+	return res.TxResponse.TxHash, nil
+}
+
+func (s *TxSubmitter) syncSequence() error {
+	address, err := bech32.ConvertAndEncode(prefix, s.privKey.PubKey().Address().Bytes())
+	if err != nil {
+		return err
+	}
+
+	account, err := s.client.GetAccount(address)
+	if err != nil {
+		return fmt.Errorf("failed to get the sequence of account(%s): %w", address, err)
+	}
+
+	s.accountNumber = account.GetAccountNumber()
+	s.sequence = account.GetSequence()
+	s.synced = true
+	return nil
+}
+
+func expectedSequence(err error) (uint64, bool) {
+	matches := sequenceMismatchRegexp.FindStringSubmatch(err.Error())
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	sequence, parseErr := strconv.ParseUint(matches[1], 10, 64)
+	if parseErr != nil {
+		return 0, false
+	}
+	return sequence, true
+}
+
+// waitForTx polls the tx until it is included in a block.
+func (s *TxSubmitter) waitForTx(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.conf.Panacea.TxConfirmTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.client.GetTx(txHash)
+		if err == nil && res != nil && res.TxResponse != nil {
+			return res.TxResponse, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s is not included in a block: %w", txHash, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package panacea_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/mocks"
+	"github.com/medibloc/panacea-oracle/panacea"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// sequenceGrpcClient accepts the txs only with the next sequence, like the ante handler of the chain.
+// The account queried has the committed sequence, which doesn't count the txs in the mempool.
+type sequenceGrpcClient struct {
+	mocks.MockGrpcClient
+
+	mtx       sync.Mutex
+	committed uint64
+	next      uint64
+	included  map[string]bool
+	deliverTx uint32 // the code of the txs in blocks
+}
+
+func newSequenceGrpcClient(account *mocks.MockAccount) *sequenceGrpcClient {
+	return &sequenceGrpcClient{
+		MockGrpcClient: mocks.MockGrpcClient{
+			Account:          account,
+			SimulateResponse: &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 100000}},
+		},
+		committed: account.GetSequence(),
+		next:      account.GetSequence(),
+		included:  make(map[string]bool),
+	}
+}
+
+func (c *sequenceGrpcClient) GetAccount(_ string) (authtypes.AccountI, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	account := *c.Account.BaseAccount
+	account.Sequence = c.committed
+	return &account, nil
+}
+
+func (c *sequenceGrpcClient) BroadcastTx(txBytes []byte) (*tx.BroadcastTxResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var txRaw tx.TxRaw
+	if err := txRaw.Unmarshal(txBytes); err != nil {
+		return nil, err
+	}
+	var authInfo tx.AuthInfo
+	if err := authInfo.Unmarshal(txRaw.AuthInfoBytes); err != nil {
+		return nil, err
+	}
+
+	sequence := authInfo.SignerInfos[0].Sequence
+	if sequence != c.next {
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{
+			Code:   32,
+			RawLog: fmt.Sprintf("account sequence mismatch, expected %d, got %d: incorrect account sequence", c.next, sequence),
+		}}, nil
+	}
+
+	c.next++
+	txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	c.included[txHash] = true
+	return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: txHash}}, nil
+}
+
+func (c *sequenceGrpcClient) GetTx(txHash string) (*tx.GetTxResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if !c.included[txHash] {
+		return nil, fmt.Errorf("tx not found: %s", txHash)
+	}
+	return &tx.GetTxResponse{TxResponse: &sdk.TxResponse{TxHash: txHash, Height: 10, Code: c.deliverTx, RawLog: "out of gas"}}, nil
+}
+
+func newTestMsg() sdk.Msg {
+	return &oracletypes.MsgUpdateOracleInfo{OracleAddress: "oracle_address"}
+}
+
+func TestTxSubmitterConcurrentSubmit(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	client := newSequenceGrpcClient(mocks.NewMockAccount(privKey.PubKey()))
+	submitter := panacea.NewTxSubmitter(client, privKey, config.DefaultConfig())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := submitter.Submit(context.Background(), newTestMsg())
+			if err == nil && res.Height != 10 {
+				err = fmt.Errorf("unexpected height: %d", res.Height)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, uint64(11), client.next)
+}
+
+func TestTxSubmitterSequenceMismatch(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	client := newSequenceGrpcClient(mocks.NewMockAccount(privKey.PubKey()))
+	submitter := panacea.NewTxSubmitter(client, privKey, config.DefaultConfig())
+
+	_, err := submitter.Submit(context.Background(), newTestMsg())
+	require.NoError(t, err)
+
+	// another tx of the same account is broadcast by someone else
+	client.next++
+
+	_, err = submitter.Submit(context.Background(), newTestMsg())
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), client.next)
+}
+
+func TestTxSubmitterFailedTx(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	client := newSequenceGrpcClient(mocks.NewMockAccount(privKey.PubKey()))
+	client.deliverTx = 11
+	submitter := panacea.NewTxSubmitter(client, privKey, config.DefaultConfig())
+
+	res, err := submitter.Submit(context.Background(), newTestMsg())
+	require.ErrorContains(t, err, "out of gas")
+	require.Equal(t, uint32(11), res.Code)
+
+	// the sequence is consumed by the failed tx
+	client.deliverTx = 0
+	_, err = submitter.Submit(context.Background(), newTestMsg())
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), client.next)
+}
+
+func TestTxSubmitterConfirmTimeout(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	conf := config.DefaultConfig()
+	conf.Panacea.TxConfirmTimeout = 50 * time.Millisecond
+	submitter := panacea.NewTxSubmitter(
+		mocks.MockGrpcClient{
+			Account:           mocks.NewMockAccount(privKey.PubKey()),
+			SimulateResponse:  &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 100000}},
+			BroadcastResponse: &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "ABCD"}},
+		},
+		privKey,
+		conf,
+	)
+
+	_, err := submitter.Submit(context.Background(), newTestMsg())
+	require.ErrorContains(t, err, "transaction ABCD is not included in a block")
+}
+
+func TestTxSubmitterSubmitSigned(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	client := newSequenceGrpcClient(mocks.NewMockAccount(privKey.PubKey()))
+	submitter := panacea.NewTxSubmitter(client, secp256k1.GenPrivKey(), config.DefaultConfig())
+
+	// the tx is signed by another account
+	txBytes, err := panacea.NewTxBuilder(client).GenerateTxBytes(privKey, config.DefaultConfig(), newTestMsg())
+	require.NoError(t, err)
+
+	res, err := submitter.SubmitSigned(context.Background(), txBytes)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), res.Height)
+
+	// the same tx is rejected by its sequence
+	_, err = submitter.SubmitSigned(context.Background(), txBytes)
+	require.ErrorContains(t, err, "account sequence mismatch")
+
+	// the submitter without a key only broadcasts the signed txs
+	submitter = panacea.NewTxSubmitter(client, nil, config.DefaultConfig())
+	_, err = submitter.Submit(context.Background(), newTestMsg())
+	require.ErrorContains(t, err, "no key to sign the tx")
+}
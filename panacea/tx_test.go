@@ -3,9 +3,15 @@ package panacea_test
 import (
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/std"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/config"
 	"github.com/medibloc/panacea-oracle/mocks"
@@ -29,8 +35,9 @@ func TestGenerateTxBytes(t *testing.T) {
 	chainID := "chainID"
 	builder := panacea.NewTxBuilder(
 		mocks.MockGrpcClient{
-			Account: signerAccount,
-			ChainID: chainID,
+			Account:          signerAccount,
+			ChainID:          chainID,
+			SimulateResponse: &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 100000}},
 		})
 	txBodyBz, err := builder.GenerateTxBytes(privKey, conf, msg)
 	require.NoError(t, err)
@@ -50,6 +57,7 @@ func TestGenerateTxBytes(t *testing.T) {
 	defaultFeeAmount, err := sdk.ParseCoinsNormalized(conf.Panacea.DefaultFeeAmount)
 	require.NoError(t, err)
 	require.Equal(t, defaultFeeAmount, authInfo.GetFee().GetAmount())
+	require.Equal(t, uint64(130000), authInfo.GetFee().GetGasLimit())
 
 	authInfoBz, err := authInfo.Marshal()
 	require.NoError(t, err)
@@ -65,3 +73,165 @@ func TestGenerateTxBytes(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, privKey.PubKey().VerifySignature(signDocBz, txRaw.Signatures[0]))
 }
+
+func TestEstimateGas(t *testing.T) {
+	conf := config.DefaultConfig()
+	privKey := secp256k1.GenPrivKey()
+	msg := &oracletypes.MsgUpdateOracleInfo{OracleAddress: "oracle_address"}
+
+	builder := panacea.NewTxBuilder(
+		mocks.MockGrpcClient{
+			Account:          mocks.NewMockAccount(privKey.PubKey()),
+			SimulateResponse: &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 12345}},
+		})
+
+	conf.Panacea.GasMultiplier = 1.5
+	gas, err := builder.EstimateGas(privKey, conf, msg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(18518), gas)
+
+	// simulation is disabled
+	conf.Panacea.GasMultiplier = 0
+	gas, err = builder.EstimateGas(privKey, conf, msg)
+	require.NoError(t, err)
+	require.Equal(t, conf.Panacea.DefaultGasLimit, gas)
+}
+
+func TestEstimateFee(t *testing.T) {
+	conf := config.DefaultConfig()
+	builder := panacea.NewTxBuilder(mocks.MockGrpcClient{
+		MinGasPrices: sdk.NewDecCoins(sdk.NewDecCoinFromDec("umed", sdk.NewDecWithPrec(25, 1))),
+	})
+
+	// the minimum gas prices of the node
+	fee, err := builder.EstimateFee(conf, 100001)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("umed", 250003)), fee)
+
+	// the configured minimum gas prices
+	conf.Panacea.MinGasPrices = "5umed"
+	fee, err = builder.EstimateFee(conf, 100000)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("umed", 500000)), fee)
+
+	// the fee is paid in a single denom
+	conf.Panacea.MinGasPrices = "5umed,1uatom"
+	conf.Panacea.MaxFeeAmount = "20000000uatom,20000000umed"
+	fee, err = builder.EstimateFee(conf, 100000)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100000)), fee)
+
+	conf.Panacea.FeeDenom = "umed"
+	fee, err = builder.EstimateFee(conf, 100000)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("umed", 500000)), fee)
+
+	conf.Panacea.FeeDenom = "ukrw"
+	_, err = builder.EstimateFee(conf, 100000)
+	require.ErrorContains(t, err, "no gas price in the fee denom ukrw")
+	conf.Panacea.FeeDenom = ""
+
+	// the fee exceeds the max fee amount
+	conf.Panacea.MinGasPrices = "5umed"
+	conf.Panacea.MaxFeeAmount = "400000umed"
+	_, err = builder.EstimateFee(conf, 100000)
+	require.ErrorContains(t, err, "exceeds the max fee amount")
+
+	// no gas price
+	conf.Panacea.MinGasPrices = ""
+	conf.Panacea.MaxFeeAmount = ""
+	builder = panacea.NewTxBuilder(mocks.MockGrpcClient{})
+	fee, err = builder.EstimateFee(conf, 100000)
+	require.NoError(t, err)
+	require.Equal(t, conf.Panacea.DefaultFeeAmount, fee.String())
+}
+
+// TestGenerateTxBytesWithGranters tests that msgs are executed on behalf of the authz granter, and the fee is paid by the fee granter.
+func TestGenerateTxBytesWithGranters(t *testing.T) {
+	conf := config.DefaultConfig()
+	privKey := secp256k1.GenPrivKey()
+	conf.Panacea.AuthzGranter = panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey())
+	conf.Panacea.FeeGranter = panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey())
+
+	msg := &oracletypes.MsgUpdateOracleInfo{OracleAddress: conf.Panacea.AuthzGranter}
+	builder := panacea.NewTxBuilder(
+		mocks.MockGrpcClient{
+			Account:          mocks.NewMockAccount(privKey.PubKey()),
+			SimulateResponse: &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 100000}},
+		})
+	txBytes, err := builder.GenerateTxBytes(privKey, conf, msg)
+	require.NoError(t, err)
+
+	var txRaw tx.TxRaw
+	require.NoError(t, txRaw.Unmarshal(txBytes))
+
+	var txBody tx.TxBody
+	require.NoError(t, txBody.Unmarshal(txRaw.BodyBytes))
+	require.Len(t, txBody.Messages, 1)
+	require.Equal(t, "/cosmos.authz.v1beta1.MsgExec", txBody.Messages[0].TypeUrl)
+
+	var msgExec authz.MsgExec
+	require.NoError(t, msgExec.Unmarshal(txBody.Messages[0].Value))
+	require.Equal(t, panacea.GetAddressFromPrivateKey(*privKey), msgExec.Grantee)
+	require.Len(t, msgExec.Msgs, 1)
+	require.Equal(t, "/panacea.oracle.v2.MsgUpdateOracleInfo", msgExec.Msgs[0].TypeUrl)
+
+	var authInfo tx.AuthInfo
+	require.NoError(t, authInfo.Unmarshal(txRaw.AuthInfoBytes))
+	require.Equal(t, conf.Panacea.FeeGranter, authInfo.Fee.Granter)
+}
+
+func newTestProtoCodec() *codec.ProtoCodec {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	oracletypes.RegisterInterfaces(interfaceRegistry)
+	return codec.NewProtoCodec(interfaceRegistry)
+}
+
+func TestGenerateUnsignedTxJSON(t *testing.T) {
+	conf := config.DefaultConfig()
+	conf.Panacea.AuthzGranter = panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey())
+	conf.Panacea.FeeGranter = panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey())
+
+	msg := &oracletypes.MsgUpdateOracleInfo{OracleAddress: conf.Panacea.AuthzGranter, Endpoint: "end_point"}
+	builder := panacea.NewTxBuilder(mocks.MockGrpcClient{ProtoCodec: newTestProtoCodec()})
+
+	txJSON, err := builder.GenerateUnsignedTxJSON(conf, msg)
+	require.NoError(t, err)
+	require.Contains(t, string(txJSON), `"@type":"/panacea.oracle.v2.MsgUpdateOracleInfo"`)
+	// the msg is signed by the oracle account itself, not via authz and feegrant.
+	require.NotContains(t, string(txJSON), "MsgExec")
+	require.NotContains(t, string(txJSON), conf.Panacea.FeeGranter)
+
+	_, _, err = builder.DecodeSignedTxJSON(txJSON)
+	require.ErrorContains(t, err, "tx is not signed")
+}
+
+func TestDecodeSignedTxJSON(t *testing.T) {
+	conf := config.DefaultConfig()
+	privKey := secp256k1.GenPrivKey()
+	cdc := newTestProtoCodec()
+
+	msg := &oracletypes.MsgUpdateOracleInfo{OracleAddress: panacea.GetAddressFromPrivateKey(*privKey), Endpoint: "end_point"}
+	builder := panacea.NewTxBuilder(
+		mocks.MockGrpcClient{
+			ProtoCodec:       cdc,
+			Account:          mocks.NewMockAccount(privKey.PubKey()),
+			SimulateResponse: &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 100000}},
+		})
+	signedTxBytes, err := builder.GenerateTxBytes(privKey, conf, msg)
+	require.NoError(t, err)
+
+	// the tx signed offline is given in JSON
+	txConfig := authtx.NewTxConfig(cdc, []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
+	signedTx, err := txConfig.TxDecoder()(signedTxBytes)
+	require.NoError(t, err)
+	txJSON, err := txConfig.TxJSONEncoder()(signedTx)
+	require.NoError(t, err)
+
+	txBytes, msgs, err := builder.DecodeSignedTxJSON(txJSON)
+	require.NoError(t, err)
+	require.Equal(t, signedTxBytes, txBytes)
+	require.Len(t, msgs, 1)
+	require.Equal(t, msg.Endpoint, msgs[0].(*oracletypes.MsgUpdateOracleInfo).Endpoint)
+}
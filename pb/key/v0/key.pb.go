@@ -123,6 +123,141 @@ func (x *GetSecretKeyResponse) GetEncryptedSecretKey() []byte {
 	return nil
 }
 
+type GetSecretKeyShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DealId   uint64 `protobuf:"varint,1,opt,name=deal_id,proto3" json:"deal_id,omitempty"`
+	DataHash string `protobuf:"bytes,2,opt,name=data_hash,proto3" json:"data_hash,omitempty"`
+	// the share of this oracle, stored in front of the consumer data
+	EncryptedShare []byte `protobuf:"bytes,3,opt,name=encrypted_share,proto3" json:"encrypted_share,omitempty"`
+}
+
+func (x *GetSecretKeyShareRequest) Reset() {
+	*x = GetSecretKeyShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_key_v0_key_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSecretKeyShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretKeyShareRequest) ProtoMessage() {}
+
+func (x *GetSecretKeyShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_key_v0_key_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretKeyShareRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretKeyShareRequest) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_key_v0_key_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSecretKeyShareRequest) GetDealId() uint64 {
+	if x != nil {
+		return x.DealId
+	}
+	return 0
+}
+
+func (x *GetSecretKeyShareRequest) GetDataHash() string {
+	if x != nil {
+		return x.DataHash
+	}
+	return ""
+}
+
+func (x *GetSecretKeyShareRequest) GetEncryptedShare() []byte {
+	if x != nil {
+		return x.EncryptedShare
+	}
+	return nil
+}
+
+type GetSecretKeyShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShareIndex     uint32 `protobuf:"varint,1,opt,name=share_index,proto3" json:"share_index,omitempty"`
+	Threshold      uint32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	TotalShares    uint32 `protobuf:"varint,3,opt,name=total_shares,proto3" json:"total_shares,omitempty"`
+	EncryptedShare []byte `protobuf:"bytes,4,opt,name=encrypted_share,proto3" json:"encrypted_share,omitempty"`
+}
+
+func (x *GetSecretKeyShareResponse) Reset() {
+	*x = GetSecretKeyShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_key_v0_key_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSecretKeyShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretKeyShareResponse) ProtoMessage() {}
+
+func (x *GetSecretKeyShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_key_v0_key_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretKeyShareResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretKeyShareResponse) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_key_v0_key_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSecretKeyShareResponse) GetShareIndex() uint32 {
+	if x != nil {
+		return x.ShareIndex
+	}
+	return 0
+}
+
+func (x *GetSecretKeyShareResponse) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *GetSecretKeyShareResponse) GetTotalShares() uint32 {
+	if x != nil {
+		return x.TotalShares
+	}
+	return 0
+}
+
+func (x *GetSecretKeyShareResponse) GetEncryptedShare() []byte {
+	if x != nil {
+		return x.EncryptedShare
+	}
+	return nil
+}
+
 var File_panacea_oracle_key_v0_key_proto protoreflect.FileDescriptor
 
 var file_panacea_oracle_key_v0_key_proto_rawDesc = []byte{
@@ -141,20 +276,48 @@ var file_panacea_oracle_key_v0_key_proto_rawDesc = []byte{
 	0x14, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65,
 	0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x14, 0x65, 0x6e, 0x63,
 	0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6b, 0x65,
-	0x79, 0x32, 0x98, 0x01, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x89, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65,
-	0x79, 0x12, 0x2a, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x2e, 0x6b, 0x65, 0x79, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e,
+	0x79, 0x22, 0x7c, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65,
+	0x79, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x65, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x64, 0x65, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x65, 0x64, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x22,
+	0xa9, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x73, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x32, 0xb9, 0x02, 0x0a, 0x0a,
+	0x4b, 0x65, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x89, 0x01, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x2a, 0x2e, 0x70, 0x61,
+	0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x6b, 0x65, 0x79,
+	0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65,
+	0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x6b, 0x65, 0x79, 0x2e, 0x76, 0x30, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x76,
+	0x30, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x64, 0x65, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x2d, 0x6b, 0x65, 0x79, 0x12, 0x9e, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x2f, 0x2e, 0x70,
+	0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x6b, 0x65,
+	0x79, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65,
+	0x79, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e,
 	0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x6b,
 	0x65, 0x79, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x1a, 0x12, 0x18, 0x2f, 0x76, 0x30, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x64, 0x65, 0x61,
-	0x6c, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2d, 0x6b, 0x65, 0x79, 0x42, 0x2e, 0x5a, 0x2c,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x62,
-	0x6c, 0x6f, 0x63, 0x2f, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x2d, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x2f, 0x70, 0x62, 0x2f, 0x6b, 0x65, 0x79, 0x2f, 0x76, 0x30, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x79, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x12, 0x1e, 0x2f, 0x76, 0x30, 0x2f, 0x64, 0x61, 0x74,
+	0x61, 0x2d, 0x64, 0x65, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2d, 0x6b, 0x65,
+	0x79, 0x2d, 0x73, 0x68, 0x61, 0x72, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x62, 0x6c, 0x6f, 0x63, 0x2f, 0x70,
+	0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x2d, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2f, 0x70, 0x62,
+	0x2f, 0x6b, 0x65, 0x79, 0x2f, 0x76, 0x30, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -169,16 +332,20 @@ func file_panacea_oracle_key_v0_key_proto_rawDescGZIP() []byte {
 	return file_panacea_oracle_key_v0_key_proto_rawDescData
 }
 
-var file_panacea_oracle_key_v0_key_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_panacea_oracle_key_v0_key_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_panacea_oracle_key_v0_key_proto_goTypes = []interface{}{
-	(*GetSecretKeyRequest)(nil),  // 0: panacea_oracle.key.v0.GetSecretKeyRequest
-	(*GetSecretKeyResponse)(nil), // 1: panacea_oracle.key.v0.GetSecretKeyResponse
+	(*GetSecretKeyRequest)(nil),       // 0: panacea_oracle.key.v0.GetSecretKeyRequest
+	(*GetSecretKeyResponse)(nil),      // 1: panacea_oracle.key.v0.GetSecretKeyResponse
+	(*GetSecretKeyShareRequest)(nil),  // 2: panacea_oracle.key.v0.GetSecretKeyShareRequest
+	(*GetSecretKeyShareResponse)(nil), // 3: panacea_oracle.key.v0.GetSecretKeyShareResponse
 }
 var file_panacea_oracle_key_v0_key_proto_depIdxs = []int32{
 	0, // 0: panacea_oracle.key.v0.KeyService.GetSecretKey:input_type -> panacea_oracle.key.v0.GetSecretKeyRequest
-	1, // 1: panacea_oracle.key.v0.KeyService.GetSecretKey:output_type -> panacea_oracle.key.v0.GetSecretKeyResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: panacea_oracle.key.v0.KeyService.GetSecretKeyShare:input_type -> panacea_oracle.key.v0.GetSecretKeyShareRequest
+	1, // 2: panacea_oracle.key.v0.KeyService.GetSecretKey:output_type -> panacea_oracle.key.v0.GetSecretKeyResponse
+	3, // 3: panacea_oracle.key.v0.KeyService.GetSecretKeyShare:output_type -> panacea_oracle.key.v0.GetSecretKeyShareResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -214,6 +381,30 @@ func file_panacea_oracle_key_v0_key_proto_init() {
 				return nil
 			}
 		}
+		file_panacea_oracle_key_v0_key_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSecretKeyShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_key_v0_key_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSecretKeyShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -221,7 +412,7 @@ func file_panacea_oracle_key_v0_key_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_panacea_oracle_key_v0_key_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
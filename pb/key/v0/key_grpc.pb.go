@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type KeyServiceClient interface {
 	GetSecretKey(ctx context.Context, in *GetSecretKeyRequest, opts ...grpc.CallOption) (*GetSecretKeyResponse, error)
+	GetSecretKeyShare(ctx context.Context, in *GetSecretKeyShareRequest, opts ...grpc.CallOption) (*GetSecretKeyShareResponse, error)
 }
 
 type keyServiceClient struct {
@@ -42,11 +43,21 @@ func (c *keyServiceClient) GetSecretKey(ctx context.Context, in *GetSecretKeyReq
 	return out, nil
 }
 
+func (c *keyServiceClient) GetSecretKeyShare(ctx context.Context, in *GetSecretKeyShareRequest, opts ...grpc.CallOption) (*GetSecretKeyShareResponse, error) {
+	out := new(GetSecretKeyShareResponse)
+	err := c.cc.Invoke(ctx, "/panacea_oracle.key.v0.KeyService/GetSecretKeyShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeyServiceServer is the server API for KeyService service.
 // All implementations must embed UnimplementedKeyServiceServer
 // for forward compatibility
 type KeyServiceServer interface {
 	GetSecretKey(context.Context, *GetSecretKeyRequest) (*GetSecretKeyResponse, error)
+	GetSecretKeyShare(context.Context, *GetSecretKeyShareRequest) (*GetSecretKeyShareResponse, error)
 	mustEmbedUnimplementedKeyServiceServer()
 }
 
@@ -57,6 +68,9 @@ type UnimplementedKeyServiceServer struct {
 func (UnimplementedKeyServiceServer) GetSecretKey(context.Context, *GetSecretKeyRequest) (*GetSecretKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSecretKey not implemented")
 }
+func (UnimplementedKeyServiceServer) GetSecretKeyShare(context.Context, *GetSecretKeyShareRequest) (*GetSecretKeyShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretKeyShare not implemented")
+}
 func (UnimplementedKeyServiceServer) mustEmbedUnimplementedKeyServiceServer() {}
 
 // UnsafeKeyServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +102,24 @@ func _KeyService_GetSecretKey_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeyService_GetSecretKeyShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretKeyShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).GetSecretKeyShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/panacea_oracle.key.v0.KeyService/GetSecretKeyShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).GetSecretKeyShare(ctx, req.(*GetSecretKeyShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KeyService_ServiceDesc is the grpc.ServiceDesc for KeyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +131,10 @@ var KeyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSecretKey",
 			Handler:    _KeyService_GetSecretKey_Handler,
 		},
+		{
+			MethodName: "GetSecretKeyShare",
+			Handler:    _KeyService_GetSecretKeyShare_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "panacea_oracle/key/v0/key.proto",
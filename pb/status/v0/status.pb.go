@@ -64,10 +64,12 @@ type GetStatusResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OracleAccountAddress string             `protobuf:"bytes,1,opt,name=oracle_account_address,proto3" json:"oracle_account_address,omitempty"`
-	Api                  *StatusAPI         `protobuf:"bytes,2,opt,name=api,proto3" json:"api,omitempty"`
-	Grpc                 *StatusGRPC        `protobuf:"bytes,3,opt,name=grpc,proto3" json:"grpc,omitempty"`
-	EnclaveInfo          *StatusEnclaveInfo `protobuf:"bytes,4,opt,name=enclave_info,proto3" json:"enclave_info,omitempty"`
+	OracleAccountAddress string                     `protobuf:"bytes,1,opt,name=oracle_account_address,proto3" json:"oracle_account_address,omitempty"`
+	Api                  *StatusAPI                 `protobuf:"bytes,2,opt,name=api,proto3" json:"api,omitempty"`
+	Grpc                 *StatusGRPC                `protobuf:"bytes,3,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	EnclaveInfo          *StatusEnclaveInfo         `protobuf:"bytes,4,opt,name=enclave_info,proto3" json:"enclave_info,omitempty"`
+	Subscriber           *StatusSubscriber          `protobuf:"bytes,5,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
+	ApprovalCoordinator  *StatusApprovalCoordinator `protobuf:"bytes,6,opt,name=approval_coordinator,proto3" json:"approval_coordinator,omitempty"`
 }
 
 func (x *GetStatusResponse) Reset() {
@@ -130,6 +132,20 @@ func (x *GetStatusResponse) GetEnclaveInfo() *StatusEnclaveInfo {
 	return nil
 }
 
+func (x *GetStatusResponse) GetSubscriber() *StatusSubscriber {
+	if x != nil {
+		return x.Subscriber
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetApprovalCoordinator() *StatusApprovalCoordinator {
+	if x != nil {
+		return x.ApprovalCoordinator
+	}
+	return nil
+}
+
 type StatusAPI struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -287,6 +303,531 @@ func (x *StatusEnclaveInfo) GetUniqueId() string {
 	return ""
 }
 
+type StatusSubscriber struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// state is one of connecting, connected, disconnected and closed
+	State     string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Connected bool   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	// last_connected_at is a unix timestamp in seconds. 0 if it has never been connected.
+	LastConnectedAt int64                  `protobuf:"varint,3,opt,name=last_connected_at,proto3" json:"last_connected_at,omitempty"`
+	Reconnects      uint64                 `protobuf:"varint,4,opt,name=reconnects,proto3" json:"reconnects,omitempty"`
+	LastError       string                 `protobuf:"bytes,5,opt,name=last_error,proto3" json:"last_error,omitempty"`
+	Dispatcher      *StatusEventDispatcher `protobuf:"bytes,6,opt,name=dispatcher,proto3" json:"dispatcher,omitempty"`
+}
+
+func (x *StatusSubscriber) Reset() {
+	*x = StatusSubscriber{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusSubscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusSubscriber) ProtoMessage() {}
+
+func (x *StatusSubscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusSubscriber.ProtoReflect.Descriptor instead.
+func (*StatusSubscriber) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StatusSubscriber) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StatusSubscriber) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *StatusSubscriber) GetLastConnectedAt() int64 {
+	if x != nil {
+		return x.LastConnectedAt
+	}
+	return 0
+}
+
+func (x *StatusSubscriber) GetReconnects() uint64 {
+	if x != nil {
+		return x.Reconnects
+	}
+	return 0
+}
+
+func (x *StatusSubscriber) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *StatusSubscriber) GetDispatcher() *StatusEventDispatcher {
+	if x != nil {
+		return x.Dispatcher
+	}
+	return nil
+}
+
+type StatusEventDispatcher struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workers       uint32                `protobuf:"varint,1,opt,name=workers,proto3" json:"workers,omitempty"`
+	QueueDepth    uint32                `protobuf:"varint,2,opt,name=queue_depth,proto3" json:"queue_depth,omitempty"`
+	QueueCapacity uint32                `protobuf:"varint,3,opt,name=queue_capacity,proto3" json:"queue_capacity,omitempty"`
+	InFlight      uint32                `protobuf:"varint,4,opt,name=in_flight,proto3" json:"in_flight,omitempty"`
+	Handlers      []*StatusEventHandler `protobuf:"bytes,5,rep,name=handlers,proto3" json:"handlers,omitempty"`
+}
+
+func (x *StatusEventDispatcher) Reset() {
+	*x = StatusEventDispatcher{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusEventDispatcher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusEventDispatcher) ProtoMessage() {}
+
+func (x *StatusEventDispatcher) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusEventDispatcher.ProtoReflect.Descriptor instead.
+func (*StatusEventDispatcher) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StatusEventDispatcher) GetWorkers() uint32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *StatusEventDispatcher) GetQueueDepth() uint32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *StatusEventDispatcher) GetQueueCapacity() uint32 {
+	if x != nil {
+		return x.QueueCapacity
+	}
+	return 0
+}
+
+func (x *StatusEventDispatcher) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *StatusEventDispatcher) GetHandlers() []*StatusEventHandler {
+	if x != nil {
+		return x.Handlers
+	}
+	return nil
+}
+
+type StatusEventHandler struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event         string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Handled       uint64 `protobuf:"varint,2,opt,name=handled,proto3" json:"handled,omitempty"`
+	Failed        uint64 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	TimedOut      uint64 `protobuf:"varint,4,opt,name=timed_out,proto3" json:"timed_out,omitempty"`
+	LastLatencyMs int64  `protobuf:"varint,5,opt,name=last_latency_ms,proto3" json:"last_latency_ms,omitempty"`
+	AvgLatencyMs  int64  `protobuf:"varint,6,opt,name=avg_latency_ms,proto3" json:"avg_latency_ms,omitempty"`
+	MaxLatencyMs  int64  `protobuf:"varint,7,opt,name=max_latency_ms,proto3" json:"max_latency_ms,omitempty"`
+}
+
+func (x *StatusEventHandler) Reset() {
+	*x = StatusEventHandler{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusEventHandler) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusEventHandler) ProtoMessage() {}
+
+func (x *StatusEventHandler) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusEventHandler.ProtoReflect.Descriptor instead.
+func (*StatusEventHandler) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StatusEventHandler) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *StatusEventHandler) GetHandled() uint64 {
+	if x != nil {
+		return x.Handled
+	}
+	return 0
+}
+
+func (x *StatusEventHandler) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *StatusEventHandler) GetTimedOut() uint64 {
+	if x != nil {
+		return x.TimedOut
+	}
+	return 0
+}
+
+func (x *StatusEventHandler) GetLastLatencyMs() int64 {
+	if x != nil {
+		return x.LastLatencyMs
+	}
+	return 0
+}
+
+func (x *StatusEventHandler) GetAvgLatencyMs() int64 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+func (x *StatusEventHandler) GetMaxLatencyMs() int64 {
+	if x != nil {
+		return x.MaxLatencyMs
+	}
+	return 0
+}
+
+// StatusApprovalCoordinator counts how oracle registrations and upgrades are coordinated among the oracles.
+type StatusApprovalCoordinator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// led is the number of requests for which this oracle is the leader.
+	Led uint64 `protobuf:"varint,1,opt,name=led,proto3" json:"led,omitempty"`
+	// followed is the number of requests approved as a follower, since they were not approved during the delay.
+	Followed uint64 `protobuf:"varint,2,opt,name=followed,proto3" json:"followed,omitempty"`
+	// skipped is the number of requests approved by another oracle during the delay.
+	Skipped uint64 `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *StatusApprovalCoordinator) Reset() {
+	*x = StatusApprovalCoordinator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusApprovalCoordinator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusApprovalCoordinator) ProtoMessage() {}
+
+func (x *StatusApprovalCoordinator) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusApprovalCoordinator.ProtoReflect.Descriptor instead.
+func (*StatusApprovalCoordinator) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StatusApprovalCoordinator) GetLed() uint64 {
+	if x != nil {
+		return x.Led
+	}
+	return 0
+}
+
+func (x *StatusApprovalCoordinator) GetFollowed() uint64 {
+	if x != nil {
+		return x.Followed
+	}
+	return 0
+}
+
+func (x *StatusApprovalCoordinator) GetSkipped() uint64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type GetAttestationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonce []byte `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *GetAttestationRequest) Reset() {
+	*x = GetAttestationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAttestationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttestationRequest) ProtoMessage() {}
+
+func (x *GetAttestationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttestationRequest.ProtoReflect.Descriptor instead.
+func (*GetAttestationRequest) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAttestationRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type GetAttestationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemoteReport         []byte `protobuf:"bytes,1,opt,name=remote_report,proto3" json:"remote_report,omitempty"`
+	OraclePubKey         []byte `protobuf:"bytes,2,opt,name=oracle_pub_key,proto3" json:"oracle_pub_key,omitempty"`
+	OracleAccountAddress string `protobuf:"bytes,3,opt,name=oracle_account_address,proto3" json:"oracle_account_address,omitempty"`
+}
+
+func (x *GetAttestationResponse) Reset() {
+	*x = GetAttestationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAttestationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttestationResponse) ProtoMessage() {}
+
+func (x *GetAttestationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttestationResponse.ProtoReflect.Descriptor instead.
+func (*GetAttestationResponse) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAttestationResponse) GetRemoteReport() []byte {
+	if x != nil {
+		return x.RemoteReport
+	}
+	return nil
+}
+
+func (x *GetAttestationResponse) GetOraclePubKey() []byte {
+	if x != nil {
+		return x.OraclePubKey
+	}
+	return nil
+}
+
+func (x *GetAttestationResponse) GetOracleAccountAddress() string {
+	if x != nil {
+		return x.OracleAccountAddress
+	}
+	return ""
+}
+
+type GetHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetHealthRequest) Reset() {
+	*x = GetHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthRequest) ProtoMessage() {}
+
+func (x *GetHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetHealthRequest) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{11}
+}
+
+type GetHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscriber *StatusSubscriber `protobuf:"bytes,1,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
+}
+
+func (x *GetHealthResponse) Reset() {
+	*x = GetHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthResponse) ProtoMessage() {}
+
+func (x *GetHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_panacea_oracle_status_v0_status_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthResponse.ProtoReflect.Descriptor instead.
+func (*GetHealthResponse) Descriptor() ([]byte, []int) {
+	return file_panacea_oracle_status_v0_status_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetHealthResponse) GetSubscriber() *StatusSubscriber {
+	if x != nil {
+		return x.Subscriber
+	}
+	return nil
+}
+
 var File_panacea_oracle_status_v0_status_proto protoreflect.FileDescriptor
 
 var file_panacea_oracle_status_v0_status_proto_rawDesc = []byte{
@@ -297,7 +838,7 @@ var file_panacea_oracle_status_v0_status_proto_rawDesc = []byte{
 	0x30, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e,
 	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
 	0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x8d, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xc2, 0x03, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x6f, 0x72, 0x61,
 	0x63, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72,
 	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x6f, 0x72, 0x61, 0x63, 0x6c,
@@ -314,32 +855,133 @@ var file_panacea_oracle_status_v0_status_proto_rawDesc = []byte{
 	0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
 	0x2e, 0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76,
 	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x65, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x5f, 0x69,
-	0x6e, 0x66, 0x6f, 0x22, 0x47, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x50, 0x49,
-	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x22, 0x2e, 0x0a, 0x0a,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x47, 0x52, 0x50, 0x43, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x22, 0x51, 0x0a, 0x11,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x63, 0x6c, 0x61, 0x76, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x32,
-	0x89, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x78, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2a,
+	0x6e, 0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65,
+	0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12,
+	0x67, 0x0a, 0x14, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e,
+	0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x14, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x47, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x41, 0x50, 0x49, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x22, 0x2e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x47, 0x52, 0x50, 0x43, 0x12,
+	0x20, 0x0a, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x22, 0x51, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x63, 0x6c, 0x61,
+	0x76, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x71, 0x75,
+	0x65, 0x5f, 0x69, 0x64, 0x22, 0x85, 0x02, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a,
+	0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4f, 0x0a, 0x0a, 0x64,
+	0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72,
+	0x52, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x22, 0xe3, 0x01, 0x0a,
+	0x15, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x70,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e,
+	0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69,
+	0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x48, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x61, 0x6e,
+	0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x72, 0x73, 0x22, 0xf4, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x12,
+	0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x76, 0x67,
+	0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x61, 0x76, 0x67, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x22, 0x63, 0x0a, 0x19, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x22, 0x2d,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x9e, 0x01,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x26,
+	0x0a, 0x0e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x70,
+	0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x16, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65,
+	0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x12,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x5f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x61,
+	0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x32, 0x92, 0x03, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x78, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2a, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b,
 	0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e,
 	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x61, 0x6e,
-	0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12,
-	0x0a, 0x2f, 0x76, 0x30, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x62, 0x6c,
-	0x6f, 0x63, 0x2f, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x2d, 0x6f, 0x72, 0x61, 0x63, 0x6c,
-	0x65, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x76, 0x30, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x30, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x8c, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2f, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65,
+	0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72,
+	0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f,
+	0x76, 0x30, 0x2f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x78,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x2a, 0x2e, 0x70, 0x61,
+	0x6e, 0x61, 0x63, 0x65, 0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x61, 0x6e, 0x61, 0x63, 0x65,
+	0x61, 0x5f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x76, 0x30, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76,
+	0x30, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x62, 0x6c, 0x6f, 0x63, 0x2f,
+	0x70, 0x61, 0x6e, 0x61, 0x63, 0x65, 0x61, 0x2d, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2f, 0x70,
+	0x62, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x76, 0x30, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -354,25 +996,42 @@ func file_panacea_oracle_status_v0_status_proto_rawDescGZIP() []byte {
 	return file_panacea_oracle_status_v0_status_proto_rawDescData
 }
 
-var file_panacea_oracle_status_v0_status_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_panacea_oracle_status_v0_status_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_panacea_oracle_status_v0_status_proto_goTypes = []interface{}{
-	(*GetStatusRequest)(nil),  // 0: panacea_oracle.status.v0.GetStatusRequest
-	(*GetStatusResponse)(nil), // 1: panacea_oracle.status.v0.GetStatusResponse
-	(*StatusAPI)(nil),         // 2: panacea_oracle.status.v0.StatusAPI
-	(*StatusGRPC)(nil),        // 3: panacea_oracle.status.v0.StatusGRPC
-	(*StatusEnclaveInfo)(nil), // 4: panacea_oracle.status.v0.StatusEnclaveInfo
+	(*GetStatusRequest)(nil),          // 0: panacea_oracle.status.v0.GetStatusRequest
+	(*GetStatusResponse)(nil),         // 1: panacea_oracle.status.v0.GetStatusResponse
+	(*StatusAPI)(nil),                 // 2: panacea_oracle.status.v0.StatusAPI
+	(*StatusGRPC)(nil),                // 3: panacea_oracle.status.v0.StatusGRPC
+	(*StatusEnclaveInfo)(nil),         // 4: panacea_oracle.status.v0.StatusEnclaveInfo
+	(*StatusSubscriber)(nil),          // 5: panacea_oracle.status.v0.StatusSubscriber
+	(*StatusEventDispatcher)(nil),     // 6: panacea_oracle.status.v0.StatusEventDispatcher
+	(*StatusEventHandler)(nil),        // 7: panacea_oracle.status.v0.StatusEventHandler
+	(*StatusApprovalCoordinator)(nil), // 8: panacea_oracle.status.v0.StatusApprovalCoordinator
+	(*GetAttestationRequest)(nil),     // 9: panacea_oracle.status.v0.GetAttestationRequest
+	(*GetAttestationResponse)(nil),    // 10: panacea_oracle.status.v0.GetAttestationResponse
+	(*GetHealthRequest)(nil),          // 11: panacea_oracle.status.v0.GetHealthRequest
+	(*GetHealthResponse)(nil),         // 12: panacea_oracle.status.v0.GetHealthResponse
 }
 var file_panacea_oracle_status_v0_status_proto_depIdxs = []int32{
-	2, // 0: panacea_oracle.status.v0.GetStatusResponse.api:type_name -> panacea_oracle.status.v0.StatusAPI
-	3, // 1: panacea_oracle.status.v0.GetStatusResponse.grpc:type_name -> panacea_oracle.status.v0.StatusGRPC
-	4, // 2: panacea_oracle.status.v0.GetStatusResponse.enclave_info:type_name -> panacea_oracle.status.v0.StatusEnclaveInfo
-	0, // 3: panacea_oracle.status.v0.StatusService.GetStatus:input_type -> panacea_oracle.status.v0.GetStatusRequest
-	1, // 4: panacea_oracle.status.v0.StatusService.GetStatus:output_type -> panacea_oracle.status.v0.GetStatusResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: panacea_oracle.status.v0.GetStatusResponse.api:type_name -> panacea_oracle.status.v0.StatusAPI
+	3,  // 1: panacea_oracle.status.v0.GetStatusResponse.grpc:type_name -> panacea_oracle.status.v0.StatusGRPC
+	4,  // 2: panacea_oracle.status.v0.GetStatusResponse.enclave_info:type_name -> panacea_oracle.status.v0.StatusEnclaveInfo
+	5,  // 3: panacea_oracle.status.v0.GetStatusResponse.subscriber:type_name -> panacea_oracle.status.v0.StatusSubscriber
+	8,  // 4: panacea_oracle.status.v0.GetStatusResponse.approval_coordinator:type_name -> panacea_oracle.status.v0.StatusApprovalCoordinator
+	6,  // 5: panacea_oracle.status.v0.StatusSubscriber.dispatcher:type_name -> panacea_oracle.status.v0.StatusEventDispatcher
+	7,  // 6: panacea_oracle.status.v0.StatusEventDispatcher.handlers:type_name -> panacea_oracle.status.v0.StatusEventHandler
+	5,  // 7: panacea_oracle.status.v0.GetHealthResponse.subscriber:type_name -> panacea_oracle.status.v0.StatusSubscriber
+	0,  // 8: panacea_oracle.status.v0.StatusService.GetStatus:input_type -> panacea_oracle.status.v0.GetStatusRequest
+	9,  // 9: panacea_oracle.status.v0.StatusService.GetAttestation:input_type -> panacea_oracle.status.v0.GetAttestationRequest
+	11, // 10: panacea_oracle.status.v0.StatusService.GetHealth:input_type -> panacea_oracle.status.v0.GetHealthRequest
+	1,  // 11: panacea_oracle.status.v0.StatusService.GetStatus:output_type -> panacea_oracle.status.v0.GetStatusResponse
+	10, // 12: panacea_oracle.status.v0.StatusService.GetAttestation:output_type -> panacea_oracle.status.v0.GetAttestationResponse
+	12, // 13: panacea_oracle.status.v0.StatusService.GetHealth:output_type -> panacea_oracle.status.v0.GetHealthResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_panacea_oracle_status_v0_status_proto_init() }
@@ -441,6 +1100,102 @@ func file_panacea_oracle_status_v0_status_proto_init() {
 				return nil
 			}
 		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusSubscriber); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusEventDispatcher); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusEventHandler); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusApprovalCoordinator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAttestationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAttestationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_panacea_oracle_status_v0_status_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -448,7 +1203,7 @@ func file_panacea_oracle_status_v0_status_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_panacea_oracle_status_v0_status_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
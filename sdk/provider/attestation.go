@@ -0,0 +1,131 @@
+// Package provider helps data providers to talk to oracles securely.
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/edgelesssys/ego/attestation"
+	"github.com/medibloc/panacea-oracle/panacea"
+	status "github.com/medibloc/panacea-oracle/pb/status/v0"
+	"github.com/medibloc/panacea-oracle/sgx"
+)
+
+// ReportVerifier verifies the signature of a remote report and returns its content.
+// Outside an enclave, eclient.VerifyRemoteReport of EGo can be used.
+type ReportVerifier func(reportBytes []byte) (attestation.Report, error)
+
+// SimulationReportVerifier verifies a report of the SGX simulation. Never use it in production.
+func SimulationReportVerifier(reportBytes []byte) (attestation.Report, error) {
+	report, err := sgx.ParseSimulationReport(reportBytes)
+	if err != nil {
+		return attestation.Report{}, err
+	}
+	return report.AttestationReport(), nil
+}
+
+// AttestationVerifier verifies that an oracle endpoint is a genuine enclave holding the oracle key.
+// Either ExpectedUniqueID or the signer ID and the product ID of the Policy must be set,
+// otherwise any enclave would be accepted.
+type AttestationVerifier struct {
+	VerifyReport ReportVerifier
+	Policy       sgx.AttestationPolicy
+
+	// ExpectedUniqueID is the unique ID of the oracle binary.
+	ExpectedUniqueID []byte
+	// ExpectedOraclePubKey is the oracle public key registered in Panacea. It is required by Verify and Attest.
+	// It can be read from Panacea by OraclePubKeyFromParams.
+	ExpectedOraclePubKey []byte
+}
+
+// OraclePubKeyFromParams returns the oracle public key in the oracle params, verified by the light client of the query client.
+func OraclePubKeyFromParams(ctx context.Context, queryClient panacea.QueryClient) ([]byte, error) {
+	oraclePubKey, err := queryClient.GetOracleParamsPublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracle public key from params: %w", err)
+	}
+	return oraclePubKey.SerializeCompressed(), nil
+}
+
+// NewNonce returns a random nonce for an attestation request.
+func NewNonce() ([]byte, error) {
+	nonce := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// Verify verifies the attestation response for the nonce.
+func (v AttestationVerifier) Verify(nonce []byte, res *status.GetAttestationResponse) error {
+	if len(v.ExpectedOraclePubKey) == 0 {
+		return fmt.Errorf("no expected oracle public key")
+	}
+
+	report, err := v.verifyReport(res.RemoteReport)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(res.OraclePubKey, v.ExpectedOraclePubKey) {
+		return fmt.Errorf("invalid oracle public key. expected(%X) got(%X)", v.ExpectedOraclePubKey, res.OraclePubKey)
+	}
+
+	expectedData := sgx.AttestationReportData(nonce, res.OraclePubKey, res.OracleAccountAddress)
+	if len(report.Data) < len(expectedData) || !bytes.Equal(report.Data[:len(expectedData)], expectedData) {
+		return fmt.Errorf("invalid data in the report. it is not bound to the nonce, oracle public key and oracle address")
+	}
+
+	return nil
+}
+
+// verifyReport verifies the signature of the report, the policy and the unique ID.
+func (v AttestationVerifier) verifyReport(reportBytes []byte) (attestation.Report, error) {
+	if len(v.ExpectedUniqueID) == 0 && (len(v.Policy.SignerID) == 0 || v.Policy.ProductID == 0) {
+		return attestation.Report{}, fmt.Errorf("no expected unique ID, or signer ID and product ID. any enclave would be accepted")
+	}
+
+	report, err := v.VerifyReport(reportBytes)
+	if err != nil {
+		return attestation.Report{}, fmt.Errorf("failed to verify remote report: %w", err)
+	}
+
+	if err := v.Policy.Verify(report); err != nil {
+		return attestation.Report{}, err
+	}
+
+	if len(v.ExpectedUniqueID) > 0 && !bytes.Equal(report.UniqueID, v.ExpectedUniqueID) {
+		return attestation.Report{}, fmt.Errorf("invalid unique ID in the report. expected(%X) got(%X)", v.ExpectedUniqueID, report.UniqueID)
+	}
+
+	return report, nil
+}
+
+// Attest requests a fresh attestation of the oracle with a new nonce and verifies it.
+// It returns the oracle public key which data can be encrypted to.
+func (v AttestationVerifier) Attest(ctx context.Context, client status.StatusServiceClient) (*btcec.PublicKey, error) {
+	nonce, err := NewNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	res, err := client.GetAttestation(ctx, &status.GetAttestationRequest{Nonce: nonce})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestation: %w", err)
+	}
+
+	if err := v.Verify(nonce, res); err != nil {
+		return nil, err
+	}
+
+	oraclePubKey, err := btcec.ParsePubKey(res.OraclePubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oracle public key: %w", err)
+	}
+
+	return oraclePubKey, nil
+}
@@ -0,0 +1,150 @@
+package provider_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/mocks"
+	status "github.com/medibloc/panacea-oracle/pb/status/v0"
+	"github.com/medibloc/panacea-oracle/sdk/provider"
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const oracleAddress = "panacea1oracle"
+
+// simulatedStatusClient responds attestations with the SGX simulation.
+type simulatedStatusClient struct {
+	status.StatusServiceClient
+
+	sgx          sgx.Sgx
+	oraclePubKey []byte
+}
+
+func (c simulatedStatusClient) GetAttestation(_ context.Context, req *status.GetAttestationRequest, _ ...grpc.CallOption) (*status.GetAttestationResponse, error) {
+	report, err := c.sgx.GenerateRemoteReport(sgx.AttestationReportData(req.Nonce, c.oraclePubKey, oracleAddress))
+	if err != nil {
+		return nil, err
+	}
+	return &status.GetAttestationResponse{
+		RemoteReport:         report,
+		OraclePubKey:         c.oraclePubKey,
+		OracleAccountAddress: oracleAddress,
+	}, nil
+}
+
+func newSimulatedStatusClient(t *testing.T) simulatedStatusClient {
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+	oraclePrivKey, err := crypto.NewPrivKey()
+	require.NoError(t, err)
+
+	return simulatedStatusClient{
+		sgx:          simulation,
+		oraclePubKey: oraclePrivKey.PubKey().SerializeCompressed(),
+	}
+}
+
+// TestAttest tests that an attestation for a new nonce is verified.
+func TestAttest(t *testing.T) {
+	client := newSimulatedStatusClient(t)
+	enclaveInfo, err := client.sgx.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	verifier := provider.AttestationVerifier{
+		VerifyReport:         provider.SimulationReportVerifier,
+		Policy:               sgx.SimulationAttestationPolicy(),
+		ExpectedUniqueID:     enclaveInfo.UniqueID,
+		ExpectedOraclePubKey: client.oraclePubKey,
+	}
+
+	oraclePubKey, err := verifier.Attest(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, client.oraclePubKey, oraclePubKey.SerializeCompressed())
+
+	verifier.ExpectedUniqueID = []byte("other unique ID")
+	_, err = verifier.Attest(context.Background(), client)
+	require.ErrorContains(t, err, "invalid unique ID")
+}
+
+// TestVerifyAttestationNotBound tests that a response not bound to the nonce or the oracle key is rejected.
+func TestVerifyAttestationNotBound(t *testing.T) {
+	client := newSimulatedStatusClient(t)
+	enclaveInfo, err := client.sgx.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	verifier := provider.AttestationVerifier{
+		VerifyReport:         provider.SimulationReportVerifier,
+		Policy:               sgx.SimulationAttestationPolicy(),
+		ExpectedUniqueID:     enclaveInfo.UniqueID,
+		ExpectedOraclePubKey: client.oraclePubKey,
+	}
+
+	nonce, err := provider.NewNonce()
+	require.NoError(t, err)
+	res, err := client.GetAttestation(context.Background(), &status.GetAttestationRequest{Nonce: nonce})
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(nonce, res))
+
+	// replayed for another nonce
+	otherNonce, err := provider.NewNonce()
+	require.NoError(t, err)
+	require.ErrorContains(t, verifier.Verify(otherNonce, res), "invalid data")
+
+	// the oracle public key is replaced
+	otherPrivKey, err := crypto.NewPrivKey()
+	require.NoError(t, err)
+	replaced := &status.GetAttestationResponse{
+		RemoteReport:         res.RemoteReport,
+		OraclePubKey:         otherPrivKey.PubKey().SerializeCompressed(),
+		OracleAccountAddress: res.OracleAccountAddress,
+	}
+	require.ErrorContains(t, verifier.Verify(nonce, replaced), "invalid oracle public key")
+	verifier.ExpectedOraclePubKey = replaced.OraclePubKey
+	require.ErrorContains(t, verifier.Verify(nonce, replaced), "invalid data")
+
+	// the expected oracle public key is different
+	require.ErrorContains(t, verifier.Verify(nonce, res), "invalid oracle public key")
+}
+
+// TestVerifyWithoutExpectations tests that a verifier without the expected enclave or oracle public key rejects any report.
+func TestVerifyWithoutExpectations(t *testing.T) {
+	client := newSimulatedStatusClient(t)
+
+	nonce, err := provider.NewNonce()
+	require.NoError(t, err)
+	res, err := client.GetAttestation(context.Background(), &status.GetAttestationRequest{Nonce: nonce})
+	require.NoError(t, err)
+	report, err := provider.SimulationReportVerifier(res.RemoteReport)
+	require.NoError(t, err)
+
+	verifier := provider.AttestationVerifier{
+		VerifyReport:         provider.SimulationReportVerifier,
+		Policy:               sgx.SimulationAttestationPolicy(),
+		ExpectedOraclePubKey: client.oraclePubKey,
+	}
+	require.ErrorContains(t, verifier.Verify(nonce, res), "any enclave would be accepted")
+
+	// the signer ID without the product ID is not enough
+	verifier.Policy.SignerID = report.SignerID
+	require.ErrorContains(t, verifier.Verify(nonce, res), "any enclave would be accepted")
+
+	verifier.Policy.ProductID = sgx.ReportProductID(report)
+	require.NoError(t, verifier.Verify(nonce, res))
+
+	verifier.ExpectedOraclePubKey = nil
+	require.ErrorContains(t, verifier.Verify(nonce, res), "no expected oracle public key")
+}
+
+// TestOraclePubKeyFromParams tests that the expected oracle public key is read from the oracle params.
+func TestOraclePubKeyFromParams(t *testing.T) {
+	oraclePrivKey, err := crypto.NewPrivKey()
+	require.NoError(t, err)
+
+	oraclePubKey, err := provider.OraclePubKeyFromParams(context.Background(), &mocks.MockQueryClient{OraclePubKey: oraclePrivKey.PubKey()})
+	require.NoError(t, err)
+	require.Equal(t, oraclePrivKey.PubKey().SerializeCompressed(), oraclePubKey)
+}
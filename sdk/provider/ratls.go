@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/medibloc/panacea-oracle/sgx"
+)
+
+// VerifyTLSCertificate verifies the RA-TLS certificate of an oracle server.
+// The certificate must have a remote report which satisfies the verifier and is bound to the certificate public key.
+func (v AttestationVerifier) VerifyTLSCertificate(certDER []byte) error {
+	cert, reportBytes, err := sgx.ParseRATLSCertificate(certDER)
+	if err != nil {
+		return err
+	}
+
+	report, err := v.verifyReport(reportBytes)
+	if err != nil {
+		return err
+	}
+
+	expectedData := sgx.RATLSReportData(cert.RawSubjectPublicKeyInfo)
+	if len(report.Data) < len(expectedData) || !bytes.Equal(report.Data[:len(expectedData)], expectedData) {
+		return fmt.Errorf("invalid data in the report. it is not bound to the TLS public key")
+	}
+
+	return nil
+}
+
+// TLSConfig returns a client TLS config which accepts only RA-TLS certificates verified by the verifier.
+// It can be used with credentials.NewTLS for gRPC, or with http.Transport for REST.
+func (v AttestationVerifier) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// RA-TLS certificates are self-signed, so they are verified by VerifyPeerCertificate
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("no server certificate")
+			}
+			return v.VerifyTLSCertificate(rawCerts[0])
+		},
+	}
+}
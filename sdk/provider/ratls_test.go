@@ -0,0 +1,70 @@
+package provider_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/sdk/provider"
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRATLS tests that a client connects to a server with an RA-TLS certificate of the SGX simulation.
+func TestRATLS(t *testing.T) {
+	client := newSimulatedStatusClient(t)
+	enclaveInfo, err := client.sgx.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	cert, err := sgx.CreateRATLSCertificate(client.sgx, "panacea-oracle", time.Hour)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, "ok")
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	defer server.Close()
+
+	verifier := provider.AttestationVerifier{
+		VerifyReport:     provider.SimulationReportVerifier,
+		Policy:           sgx.SimulationAttestationPolicy(),
+		ExpectedUniqueID: enclaveInfo.UniqueID,
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: verifier.TLSConfig()}}
+	res, err := httpClient.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	verifier.ExpectedUniqueID = []byte("other unique ID")
+	httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: verifier.TLSConfig()}}
+	_, err = httpClient.Get(server.URL)
+	require.ErrorContains(t, err, "invalid unique ID")
+}
+
+// TestVerifyTLSCertificateNotBound tests that a certificate whose report is not bound to its public key is rejected.
+func TestVerifyTLSCertificateNotBound(t *testing.T) {
+	client := newSimulatedStatusClient(t)
+	enclaveInfo, err := client.sgx.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	verifier := provider.AttestationVerifier{
+		VerifyReport:     provider.SimulationReportVerifier,
+		Policy:           sgx.SimulationAttestationPolicy(),
+		ExpectedUniqueID: enclaveInfo.UniqueID,
+	}
+
+	cert, err := sgx.CreateRATLSCertificate(client.sgx, "panacea-oracle", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, verifier.VerifyTLSCertificate(cert.Certificate[0]))
+
+	// a TLS certificate without a remote report
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	err = verifier.VerifyTLSCertificate(server.Certificate().Raw)
+	require.Error(t, err)
+}
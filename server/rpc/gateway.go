@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"golang.org/x/net/netutil"
 	"net"
@@ -13,9 +14,11 @@ import (
 	"github.com/medibloc/panacea-oracle/server/service/datadeal"
 	"github.com/medibloc/panacea-oracle/server/service/key"
 	"github.com/medibloc/panacea-oracle/server/service/status"
+	"github.com/medibloc/panacea-oracle/sgx"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
 )
 
 type GatewayServer struct {
@@ -24,10 +27,12 @@ type GatewayServer struct {
 	maxConnections int
 }
 
-func NewGatewayServer(conf *config.Config) (*GatewayServer, error) {
+// NewGatewayServer creates a gateway server. If ratlsCerts is not nil,
+// the server serves TLS with its current certificate and connects to the gRPC server which serves TLS with the same certificates.
+func NewGatewayServer(conf *config.Config, ratlsCerts *sgx.RATLSCertificates) (*GatewayServer, error) {
 	mux := runtime.NewServeMux()
 
-	conn, err := createGrpcConnection(conf)
+	conn, err := createGrpcConnection(conf, ratlsCerts)
 	if err != nil {
 		return nil, err
 	}
@@ -42,21 +47,32 @@ func NewGatewayServer(conf *config.Config) (*GatewayServer, error) {
 		return nil, fmt.Errorf("failed to register service handlers: %w", err)
 	}
 
+	var tlsConfig *tls.Config
+	if ratlsCerts != nil {
+		tlsConfig = ratlsCerts.ServerTLSConfig()
+	}
+
 	return &GatewayServer{
 		Server: &http.Server{
 			Handler:      appendPreHandlers(mux, conf),
 			Addr:         conf.API.ListenAddr,
 			WriteTimeout: conf.API.WriteTimeout,
 			ReadTimeout:  conf.API.ReadTimeout,
+			TLSConfig:    tlsConfig,
 		},
 		grpcConn:       conn,
 		maxConnections: conf.API.MaxConnections,
 	}, nil
 }
 
-func createGrpcConnection(conf *config.Config) (*grpc.ClientConn, error) {
+func createGrpcConnection(conf *config.Config, ratlsCerts *sgx.RATLSCertificates) (*grpc.ClientConn, error) {
 	log.Infof("Dial gateway to gRPC server > %s", conf.GRPC.ListenAddr)
 
+	transportOpt := grpc.WithInsecure()
+	if ratlsCerts != nil {
+		transportOpt = grpc.WithTransportCredentials(credentials.NewTLS(ratlsCerts.PinnedTLSConfig()))
+	}
+
 	return grpc.DialContext(
 		context.Background(),
 		conf.GRPC.ListenAddr,
@@ -64,7 +80,7 @@ func createGrpcConnection(conf *config.Config) (*grpc.ClientConn, error) {
 			Backoff:           backoff.DefaultConfig,
 			MinConnectTimeout: conf.API.GrpcConnectTimeout,
 		}),
-		grpc.WithInsecure(),
+		transportOpt,
 	)
 }
 
@@ -107,8 +123,15 @@ func (s *GatewayServer) Run() error {
 		return err
 	}
 
+	lis = netutil.LimitListener(lis, s.maxConnections)
+
+	if s.TLSConfig != nil {
+		log.Infof("API server is started with TLS: %s", s.Addr)
+		return s.ServeTLS(lis, "", "")
+	}
+
 	log.Infof("API server is started: %s", s.Addr)
-	return s.Serve(netutil.LimitListener(lis, s.maxConnections))
+	return s.Serve(lis)
 }
 
 func (s *GatewayServer) Close() error {
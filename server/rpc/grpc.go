@@ -10,9 +10,11 @@ import (
 	"github.com/medibloc/panacea-oracle/server/service/key"
 	"github.com/medibloc/panacea-oracle/server/service/status"
 	"github.com/medibloc/panacea-oracle/service"
+	"github.com/medibloc/panacea-oracle/sgx"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 )
 
@@ -21,12 +23,13 @@ type GrpcServer struct {
 	svc        service.Service
 }
 
-func NewGrpcServer(svc service.Service) *GrpcServer {
+// NewGrpcServer creates a gRPC server. If ratlsCerts is not nil, the server serves TLS with its current certificate.
+func NewGrpcServer(svc service.Service, ratlsCerts *sgx.RATLSCertificates) *GrpcServer {
 	conf := svc.Config().GRPC
 
 	unaryInterceptor, streamInterceptor := createInterceptors(svc)
 
-	grpcSvr := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		unaryInterceptor,
 		streamInterceptor,
 		grpc.ConnectionTimeout(conf.ConnectionTimeout),
@@ -39,7 +42,12 @@ func NewGrpcServer(svc service.Service) *GrpcServer {
 			Time:                  conf.KeepaliveTime,
 			Timeout:               conf.KeepaliveTimeout,
 		}),
-	)
+	}
+	if ratlsCerts != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(ratlsCerts.ServerTLSConfig())))
+	}
+
+	grpcSvr := grpc.NewServer(opts...)
 
 	return &GrpcServer{
 		grpcSvr,
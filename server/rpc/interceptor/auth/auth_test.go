@@ -210,6 +210,14 @@ func (c *mockQueryClient) Close() error {
 	return nil
 }
 
+func (c *mockQueryClient) GetAccountCreatedTime(_ context.Context, _ string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (c *mockQueryClient) GetBondedStake(_ context.Context, _ string) (*sdk.Coin, error) {
+	return nil, nil
+}
+
 func (c *mockQueryClient) GetAccount(_ context.Context, address string) (authtypes.AccountI, error) {
 	if address != testAccAddr {
 		return nil, fmt.Errorf("address not found: %v", address)
@@ -299,3 +307,7 @@ func (a *mockAccountWithoutPubKey) GetPubKey() cryptotypes.PubKey {
 func (c *mockQueryClient) GetLastBlockHeight(_ context.Context) (int64, error) {
 	return 0, nil
 }
+
+func (c *mockQueryClient) InvalidateDeal(_ uint64, _ int64) {}
+
+func (c *mockQueryClient) InvalidateOracle(_ string, _ int64) {}
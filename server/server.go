@@ -1,7 +1,11 @@
 package server
 
 import (
+	"fmt"
+
+	"github.com/medibloc/panacea-oracle/admin"
 	"github.com/medibloc/panacea-oracle/server/rpc"
+	"github.com/medibloc/panacea-oracle/sgx"
 
 	"github.com/medibloc/panacea-oracle/service"
 	log "github.com/sirupsen/logrus"
@@ -19,13 +23,31 @@ func Serve(svc service.Service) ([]Server, chan error) {
 	var servers []Server
 
 	errCh := make(chan error)
-	svr := rpc.NewGrpcServer(svc)
+
+	var ratlsCerts *sgx.RATLSCertificates
+	log.Infof("RA-TLS enabled: %v", cfg.RATLS.Enabled)
+	if cfg.RATLS.Enabled {
+		var err error
+		ratlsCerts, err = sgx.NewRATLSCertificates(svc.SGX(), svc.OracleAcc().GetAddress(), cfg.RATLS.Validity)
+		if err != nil {
+			go func() { errCh <- fmt.Errorf("failed to create RA-TLS certificate: %w", err) }()
+			return servers, errCh
+		}
+		servers = append(servers, ratlsCerts)
+		go runServer(ratlsCerts, errCh)
+	}
+
+	svr := rpc.NewGrpcServer(svc, ratlsCerts)
 	servers = append(servers, svr)
 	go runServer(svr, errCh)
 
+	adminSvr := admin.NewServer(admin.SocketPath(cfg.AbsDataDirPath()), svc.ApprovalPolicy(), svc.DeadLetters())
+	servers = append(servers, adminSvr)
+	go runServer(adminSvr, errCh)
+
 	log.Infof("API enabled: %v", cfg.API.Enabled)
 	if cfg.API.Enabled {
-		svr, err := rpc.NewGatewayServer(cfg)
+		svr, err := rpc.NewGatewayServer(cfg, ratlsCerts)
 		if err != nil {
 			go func() { errCh <- err }()
 		} else {
@@ -6,7 +6,6 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	datadeal "github.com/medibloc/panacea-oracle/pb/datadeal/v0"
 	"github.com/medibloc/panacea-oracle/service"
-	"github.com/medibloc/panacea-oracle/validation"
 	"google.golang.org/grpc"
 )
 
@@ -14,13 +13,11 @@ type dataDealServiceServer struct {
 	datadeal.UnimplementedDataDealServiceServer
 
 	service.Service
-	schema *validation.JSONSchema
 }
 
 func RegisterService(svc service.Service, svr *grpc.Server) {
 	datadeal.RegisterDataDealServiceServer(svr, &dataDealServiceServer{
 		Service: svc,
-		schema:  validation.NewJSONSchema(),
 	})
 }
 
@@ -1,9 +1,11 @@
 package datadeal
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
+	"io"
 
 	"github.com/medibloc/vc-sdk/pkg/vdr"
 
@@ -52,34 +54,20 @@ func (s *dataDealServiceServer) ValidateData(ctx context.Context, req *datadeal.
 		return nil, fmt.Errorf("cannot provide data to INACTIVE/COMPLETED deal")
 	}
 
-	// Decrypt data
-	encryptedData := req.EncryptedData
-
-	providerAcc, err := queryClient.GetAccount(ctx, req.ProviderAddress)
+	// The deal is flushed with the data being delivered when it is closed.
+	dealRegistry := s.DealRegistry()
+	done, err := dealRegistry.BeginDelivery(dealID)
 	if err != nil {
-		log.Debugf("failed to get provider's account: %v", err)
-		return nil, fmt.Errorf("failed to get provider's account: %w", err)
-	}
-
-	if providerAcc.GetPubKey() == nil {
-		log.Debugf("failed to get public key of provider's account: %s", req.ProviderAddress)
-		return nil, fmt.Errorf("failed to get public key of provider's account: %s", req.ProviderAddress)
-	}
-
-	providerPubKeyBytes := providerAcc.GetPubKey().Bytes()
-
-	providerPubKey, err := btcec.ParsePubKey(providerPubKeyBytes, btcec.S256())
-	if err != nil {
-		log.Debugf("failed to parse provider's public key: %v", err)
-		return nil, fmt.Errorf("failed to parse provider's public key: %w", err)
+		log.Debugf("failed to begin delivery to deal(%d): %s", dealID, err.Error())
+		return nil, fmt.Errorf("cannot provide data to INACTIVE/COMPLETED deal")
 	}
+	defer done()
 
-	decryptSharedKey := crypto.DeriveSharedKey(oraclePrivKey, providerPubKey, crypto.KDFSHA256)
-
-	decryptedData, err := crypto.Decrypt(decryptSharedKey, nil, encryptedData)
+	// Decrypt data.
+	// A stream envelope is decrypted segment by segment, but the data hash and validation need the whole canonical JSON.
+	decryptedData, err := s.decryptProviderData(ctx, req)
 	if err != nil {
-		log.Debugf("failed to decrypt data: %s", err.Error())
-		return nil, fmt.Errorf("failed to decrypt data")
+		return nil, err
 	}
 
 	decryptedDataBz, err := jsoncanonicalizer.Transform(decryptedData)
@@ -98,31 +86,55 @@ func (s *dataDealServiceServer) ValidateData(ctx context.Context, req *datadeal.
 	}
 
 	if len(deal.DataSchema) > 0 {
-		if err := s.schema.ValidateJSONSchemata(decryptedData, deal.DataSchema); err != nil {
+		if err := dealRegistry.ValidateDataSchema(deal, decryptedData); err != nil {
 			log.Debugf("failed to validate data: %s", err.Error())
 			return nil, fmt.Errorf("failed to validate data")
 		}
 	}
 
 	if deal.PresentationDefinition != nil {
+		pd, err := dealRegistry.PresentationDefinition(deal)
+		if err != nil {
+			log.Debugf("failed to get presentation definition: %s", err.Error())
+			return nil, fmt.Errorf("failed to validate VP")
+		}
+
 		panaceaVDR := vdr.NewPanaceaVDR(queryClient)
-		if err := validation.ValidateVP(panaceaVDR, decryptedData, deal.PresentationDefinition); err != nil {
+		if err := validation.ValidateVP(panaceaVDR, decryptedData, pd); err != nil {
 			log.Errorf("failed to validate verifiable presentation: %s", err.Error())
 			return nil, fmt.Errorf("failed to validate VP")
 		}
 	}
 
-	// Re-encrypt data using a combined key
-	secretKey := key.GetSecretKey(oraclePrivKey.Serialize(), dealID, dataHashBz)
-	reEncryptedData, err := crypto.Encrypt(secretKey, nil, decryptedData)
-	if err != nil {
-		log.Errorf("failed to re-encrypt data with the combined key: %s", err.Error())
-		return nil, fmt.Errorf("failed to re-encrypt data with the combined key")
+	// Re-encrypt data using a combined key.
+	// In threshold mode, a random key is used instead, and its shares for the oracles are stored in front of the data.
+	var secretKey, sharesBz []byte
+	if s.Config().Threshold.Enabled {
+		var shares *key.SecretKeyShares
+		secretKey, shares, err = key.GenerateSecretKeyShares(ctx, s, dealID, dataHash)
+		if err != nil {
+			log.Errorf("failed to generate secret key shares: %s", err.Error())
+			return nil, fmt.Errorf("failed to generate secret key shares")
+		}
+		sharesBz, err = shares.Bytes()
+		if err != nil {
+			log.Errorf("failed to encode secret key shares: %s", err.Error())
+			return nil, fmt.Errorf("failed to generate secret key shares")
+		}
+	} else {
+		secretKey = key.GetSecretKey(oraclePrivKey.Serialize(), dealID, dataHashBz)
 	}
+	consumerAdditional := crypto.AdditionalData{
+		DealID:   dealID,
+		DataHash: dataHash,
+		Purpose:  crypto.PurposeConsumerData,
+	}
+	reEncryptedData := encryptStream(secretKey, consumerAdditional, decryptedData)
+	defer reEncryptedData.Close()
 
-	// Post reEncryptedData to consumer service
+	// Post reEncryptedData to consumer service while it is being encrypted, so that the ciphertext is not buffered
 	consumerService := s.ConsumerService()
-	if err := consumerService.Add(deal.ConsumerServiceEndpoint, req.DealId, req.DataHash, reEncryptedData); err != nil {
+	if err := consumerService.Add(deal.ConsumerServiceEndpoint, req.DealId, req.DataHash, io.MultiReader(bytes.NewReader(sharesBz), reEncryptedData)); err != nil {
 		log.Errorf("failed to add data to consumer service: %s", err.Error())
 		return nil, fmt.Errorf("failed to add data to consumer service")
 	}
@@ -174,3 +186,102 @@ func validateRequest(req *datadeal.ValidateDataRequest) error {
 
 	return nil
 }
+
+// decryptProviderData decrypts the data from the provider.
+// The data encrypted with an ephemeral key (ECIES) is decrypted only with the oracle private key.
+// Otherwise, it is decrypted with the key shared with the provider account.
+func (s *dataDealServiceServer) decryptProviderData(ctx context.Context, req *datadeal.ValidateDataRequest) ([]byte, error) {
+	oraclePrivKey := s.OraclePrivKey()
+	encryptedData := req.EncryptedData
+
+	if crypto.IsECIESEnvelope(encryptedData) {
+		decryptedData, err := crypto.DecryptECIES(oraclePrivKey, encryptedData)
+		if err != nil {
+			log.Debugf("failed to decrypt ECIES data: %s", err.Error())
+			return nil, fmt.Errorf("failed to decrypt data")
+		}
+		return decryptedData, nil
+	}
+
+	providerAcc, err := s.QueryClient().GetAccount(ctx, req.ProviderAddress)
+	if err != nil {
+		log.Debugf("failed to get provider's account: %v", err)
+		return nil, fmt.Errorf("failed to get provider's account: %w", err)
+	}
+
+	if providerAcc.GetPubKey() == nil {
+		log.Debugf("failed to get public key of provider's account: %s", req.ProviderAddress)
+		return nil, fmt.Errorf("failed to get public key of provider's account: %s", req.ProviderAddress)
+	}
+
+	providerPubKeyBytes := providerAcc.GetPubKey().Bytes()
+
+	providerPubKey, err := btcec.ParsePubKey(providerPubKeyBytes, btcec.S256())
+	if err != nil {
+		log.Debugf("failed to parse provider's public key: %v", err)
+		return nil, fmt.Errorf("failed to parse provider's public key: %w", err)
+	}
+
+	decryptSharedKey := crypto.DeriveSharedKey(oraclePrivKey, providerPubKey, crypto.KDFSHA256)
+
+	providerAdditional := crypto.AdditionalData{
+		DealID:   req.DealId,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeProviderData,
+	}
+	if crypto.IsStreamEnvelope(encryptedData) {
+		decryptedData, err := decryptStream(decryptSharedKey, providerAdditional, encryptedData)
+		if err != nil {
+			log.Debugf("failed to decrypt data stream: %s", err.Error())
+			return nil, fmt.Errorf("failed to decrypt data")
+		}
+		return decryptedData, nil
+	}
+
+	decryptedData, isLegacy, err := crypto.DecryptEnvelopeOrLegacy(decryptSharedKey, providerAdditional, encryptedData, s.Config().AllowLegacyCiphertext)
+	if err != nil {
+		log.Debugf("failed to decrypt data: %s", err.Error())
+		return nil, fmt.Errorf("failed to decrypt data")
+	}
+	if isLegacy {
+		log.Warnf("accepted a deprecated legacy ciphertext from provider(%s) for deal(%d). it will be refused once allow-legacy-ciphertext is disabled", req.ProviderAddress, req.DealId)
+	}
+
+	return decryptedData, nil
+}
+
+// decryptStream decrypts the stream envelope through a StreamReader into a buffer allocated once,
+// since the plaintext is shorter than the ciphertext.
+func decryptStream(secretKey []byte, additional crypto.AdditionalData, ciphertext []byte) ([]byte, error) {
+	sr, err := crypto.NewStreamReader(bytes.NewReader(ciphertext), secretKey, additional)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(ciphertext)+bytes.MinRead))
+	if _, err := io.Copy(buf, sr); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encryptStream returns a reader of the stream envelope which is encrypted from the plaintext in memory as it is read.
+// The reader should be closed so that the encryption stops if the reader is not fully consumed.
+func encryptStream(secretKey []byte, additional crypto.AdditionalData, data []byte) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		sw, err := crypto.NewStreamWriter(pw, secretKey, crypto.DefaultKeyEpoch, additional, crypto.DefaultSegmentSize)
+		if err != nil {
+			_ = pw.CloseWithError(fmt.Errorf("failed to re-encrypt data with the combined key: %w", err))
+			return
+		}
+		if _, err := sw.Write(data); err != nil {
+			_ = pw.CloseWithError(fmt.Errorf("failed to re-encrypt data with the combined key: %w", err))
+			return
+		}
+		_ = pw.CloseWithError(sw.Close())
+	}()
+
+	return pr
+}
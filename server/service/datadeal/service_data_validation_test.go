@@ -1,9 +1,11 @@
 package datadeal
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"io"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -19,7 +21,6 @@ import (
 	datadeal "github.com/medibloc/panacea-oracle/pb/datadeal/v0"
 	"github.com/medibloc/panacea-oracle/server/rpc/interceptor/auth"
 	"github.com/medibloc/panacea-oracle/server/service/key"
-	"github.com/medibloc/panacea-oracle/validation"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -94,7 +95,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataSuccess() {
 	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().NoError(err)
 
@@ -118,11 +119,155 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataSuccess() {
 	reEncryptedData, err := suite.ConsumerService.Get(suite.deal.ConsumerServiceEndpoint, unsignedCertificate.DealId, unsignedCertificate.DataHash)
 	suite.Require().NoError(err)
 	combinedKey := key.GetSecretKey(suite.OraclePrivKey.Serialize(), req.DealId, dataHash[:])
-	decryptedData, err := crypto.Decrypt(combinedKey[:], nil, reEncryptedData)
+	consumerAdditional := crypto.AdditionalData{
+		DealID:   req.DealId,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeConsumerData,
+	}
+	decryptedData, err := crypto.DecryptEnvelope(combinedKey[:], consumerAdditional, reEncryptedData)
 	suite.Require().NoError(err)
 	suite.Require().Equal(jsonDataBz, decryptedData)
 }
 
+func (suite *dataDealServiceServerTestSuite) TestValidateDataECIES() {
+	// the data schema is not validated in this test
+	suite.deal.DataSchema = nil
+
+	jsonDataBz := []byte(`{"name": "name", "description": "description"}`)
+
+	// encrypted provider data with an ephemeral key and oracle public key
+	encryptedData, err := crypto.EncryptECIES(suite.OraclePrivKey.PubKey(), crypto.DefaultKeyEpoch, jsonDataBz)
+	suite.Require().NoError(err)
+
+	jsonData, err := jsoncanonicalizer.Transform(jsonDataBz)
+	suite.Require().NoError(err)
+	dataHash := sha256.Sum256(jsonData)
+
+	req := &datadeal.ValidateDataRequest{
+		DealId:          1,
+		ProviderAddress: panacea.GetAddressFromPrivateKey(suite.providerAccPrivKey),
+		EncryptedData:   encryptedData,
+		DataHash:        hex.EncodeToString(dataHash[:]),
+	}
+
+	// the provider account public key is not needed
+	suite.QueryClient.Account = authtypes.NewBaseAccount(
+		sdk.AccAddress(suite.providerAccPubKey.Address()),
+		nil,
+		1,
+		1,
+	)
+
+	// add authentication in header
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
+
+	server := dataDealServiceServer{Service: suite.Svc}
+	res, err := server.ValidateData(ctx, req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(req.DataHash, res.Certificate.UnsignedCertificate.DataHash)
+
+	// encrypted to another key
+	otherPrivKey, err := crypto.NewPrivKey()
+	suite.Require().NoError(err)
+	req.EncryptedData, err = crypto.EncryptECIES(otherPrivKey.PubKey(), crypto.DefaultKeyEpoch, jsonDataBz)
+	suite.Require().NoError(err)
+
+	res, err = server.ValidateData(ctx, req)
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "failed to decrypt data")
+}
+
+func (suite *dataDealServiceServerTestSuite) TestValidateDataStream() {
+	// the data schema is not validated in this test
+	suite.deal.DataSchema = nil
+
+	jsonDataBz := []byte(`{"name": "name", "description": "description", "body": "body"}`)
+	jsonData, err := jsoncanonicalizer.Transform(jsonDataBz)
+	suite.Require().NoError(err)
+	dataHash := sha256.Sum256(jsonData)
+
+	providerPrivKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), suite.providerAccPrivKey.Bytes())
+	sharedKey := crypto.DeriveSharedKey(providerPrivKey, suite.OraclePrivKey.PubKey(), crypto.KDFSHA256)
+	providerAdditional := crypto.AdditionalData{
+		DealID:   1,
+		DataHash: hex.EncodeToString(dataHash[:]),
+		Purpose:  crypto.PurposeProviderData,
+	}
+
+	// encrypted provider data in several segments
+	var encryptedData bytes.Buffer
+	sw, err := crypto.NewStreamWriter(&encryptedData, sharedKey, crypto.DefaultKeyEpoch, providerAdditional, 16)
+	suite.Require().NoError(err)
+	_, err = sw.Write(jsonDataBz)
+	suite.Require().NoError(err)
+	suite.Require().NoError(sw.Close())
+
+	req := &datadeal.ValidateDataRequest{
+		DealId:          1,
+		ProviderAddress: panacea.GetAddressFromPrivateKey(suite.providerAccPrivKey),
+		EncryptedData:   encryptedData.Bytes(),
+		DataHash:        hex.EncodeToString(dataHash[:]),
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
+
+	server := dataDealServiceServer{Service: suite.Svc}
+	res, err := server.ValidateData(ctx, req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(req.DataHash, res.Certificate.UnsignedCertificate.DataHash)
+
+	// the stored data is read as a stream
+	stored, err := suite.ConsumerService.Open(suite.deal.ConsumerServiceEndpoint, req.DealId, req.DataHash)
+	suite.Require().NoError(err)
+	defer stored.Close()
+
+	combinedKey := key.GetSecretKey(suite.OraclePrivKey.Serialize(), req.DealId, dataHash[:])
+	sr, err := crypto.NewStreamReader(stored, combinedKey, crypto.AdditionalData{
+		DealID:   req.DealId,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeConsumerData,
+	})
+	suite.Require().NoError(err)
+	decryptedData, err := io.ReadAll(sr)
+	suite.Require().NoError(err)
+	suite.Require().Equal(jsonDataBz, decryptedData)
+
+	// a truncated stream is rejected
+	req.EncryptedData = req.EncryptedData[:len(req.EncryptedData)-1]
+	res, err = server.ValidateData(ctx, req)
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "failed to decrypt data")
+}
+
+func (suite *dataDealServiceServerTestSuite) TestValidateDataClosedDeal() {
+	suite.deal.DataSchema = nil
+
+	jsonDataBz := []byte(`{"name": "name"}`)
+	encryptedData, err := crypto.EncryptECIES(suite.OraclePrivKey.PubKey(), crypto.DefaultKeyEpoch, jsonDataBz)
+	suite.Require().NoError(err)
+	dataHash := sha256.Sum256(jsonDataBz)
+
+	req := &datadeal.ValidateDataRequest{
+		DealId:          1,
+		ProviderAddress: panacea.GetAddressFromPrivateKey(suite.providerAccPrivKey),
+		EncryptedData:   encryptedData,
+		DataHash:        hex.EncodeToString(dataHash[:]),
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
+
+	// the deal is closed by its deactivation event, even before the deal queried is updated
+	suite.Require().NoError(suite.Svc.DealRegistry().Close(ctx, req.DealId))
+
+	server := dataDealServiceServer{Service: suite.Svc}
+	res, err := server.ValidateData(ctx, req)
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "cannot provide data to INACTIVE/COMPLETED deal")
+}
+
 func (suite *dataDealServiceServerTestSuite) TestValidateDataInvalidRequest() {
 	req := &datadeal.ValidateDataRequest{
 		DealId:          1,
@@ -134,7 +279,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataInvalidRequest() {
 	ctx := context.Background()
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "invalid provider address:")
@@ -176,7 +321,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataDealStatusIsNotActi
 	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "cannot provide data to INACTIVE/COMPLETED deal")
@@ -208,7 +353,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataNotFoundProviderPub
 	)
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "failed to get public key of provider's account")
@@ -227,7 +372,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataInvalidProviderEncr
 	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "failed to decrypt data")
@@ -313,7 +458,7 @@ func (suite *dataDealServiceServerTestSuite) TestValidateDataInvalidJSONSchema()
 	ctx = context.WithValue(ctx, auth.ContextKeyAuthenticatedAccountAddress{}, req.ProviderAddress)
 
 	// request validation for provider data
-	server := dataDealServiceServer{Service: suite.Svc, schema: validation.NewJSONSchema()}
+	server := dataDealServiceServer{Service: suite.Svc}
 	res, err := server.ValidateData(ctx, req)
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "failed to validate data")
@@ -0,0 +1,107 @@
+package key
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/panacea"
+	"github.com/medibloc/panacea-oracle/service"
+	log "github.com/sirupsen/logrus"
+)
+
+// GenerateSecretKeyShares generates a random secret key for the data,
+// and splits it into the shares for the oracles with the same unique ID.
+func GenerateSecretKeyShares(ctx context.Context, svc service.Service, dealID uint64, dataHash string) ([]byte, *SecretKeyShares, error) {
+	thresholdConf := svc.Config().Threshold
+
+	holders, err := GetShareHolders(ctx, svc.GRPCClient(), svc.QueryClient(), svc.EnclaveInfo().UniqueIDHex(), thresholdConf.TotalShares)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(holders) < thresholdConf.Threshold {
+		return nil, nil, fmt.Errorf("not enough oracles to hold the shares. expected at least %d, got %d", thresholdConf.Threshold, len(holders))
+	}
+
+	secretKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, secretKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate secret key: %w", err)
+	}
+
+	additional := crypto.AdditionalData{
+		DealID:   dealID,
+		DataHash: dataHash,
+		Purpose:  crypto.PurposeSecretKeyShare,
+	}
+	shares, err := SplitSecretKey(secretKey, additional, thresholdConf.Threshold, holders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secretKey, shares, nil
+}
+
+// GetShareHolders returns the approved oracles with the unique ID, sorted by their addresses and capped at maxHolders.
+// The node key of each oracle is queried with verification, since the shares are encrypted to it.
+func GetShareHolders(ctx context.Context, grpcClient panacea.GRPCClient, queryClient panacea.QueryClient, uniqueID string, maxHolders int) ([]ShareHolder, error) {
+	oracles, err := grpcClient.GetOracles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oracles: %w", err)
+	}
+
+	holders := make([]ShareHolder, 0, len(oracles))
+	for _, oracle := range oracles {
+		if oracle.UniqueId != uniqueID {
+			continue
+		}
+
+		nodePubKey, err := getNodePubKey(ctx, queryClient, uniqueID, oracle.OracleAddress)
+		if err != nil {
+			log.Warnf("oracle(%s) is excluded from the share holders: %v", oracle.OracleAddress, err)
+			continue
+		}
+
+		holders = append(holders, ShareHolder{
+			OracleAddress: oracle.OracleAddress,
+			NodePubKey:    nodePubKey,
+		})
+	}
+
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].OracleAddress < holders[j].OracleAddress
+	})
+	if len(holders) > maxHolders {
+		holders = holders[:maxHolders]
+	}
+
+	return holders, nil
+}
+
+// getNodePubKey returns the node public key in the approved registration or upgrade of the oracle.
+func getNodePubKey(ctx context.Context, queryClient panacea.QueryClient, uniqueID, oracleAddress string) (*btcec.PublicKey, error) {
+	var nodePubKeyBz []byte
+
+	registration, err := queryClient.GetOracleRegistration(ctx, uniqueID, oracleAddress)
+	if err == nil && registration != nil && len(registration.EncryptedOraclePrivKey) > 0 {
+		nodePubKeyBz = registration.NodePubKey
+	} else {
+		upgrade, err := queryClient.GetOracleUpgrade(ctx, uniqueID, oracleAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oracle registration or upgrade: %w", err)
+		}
+		if upgrade == nil || len(upgrade.EncryptedOraclePrivKey) == 0 {
+			return nil, fmt.Errorf("the oracle is not approved")
+		}
+		nodePubKeyBz = upgrade.NodePubKey
+	}
+
+	nodePubKey, err := btcec.ParsePubKey(nodePubKeyBz, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node public key: %w", err)
+	}
+	return nodePubKey, nil
+}
@@ -1,11 +1,22 @@
 package key
 
 import (
+	"bytes"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
 
+	"github.com/btcsuite/btcd/btcec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/medibloc/panacea-oracle/crypto"
 )
 
+// maxSecretKeySharesLen limits the size of the shares stored in front of the consumer data.
+const maxSecretKeySharesLen = 1 << 20
+
 func GetSecretKey(oraclePrivKey []byte, dealID uint64, dataHash []byte) []byte {
 	hash := sha256.New()
 	hash.Write(oraclePrivKey)
@@ -13,3 +24,109 @@ func GetSecretKey(oraclePrivKey []byte, dealID uint64, dataHash []byte) []byte {
 	hash.Write(dataHash)
 	return hash.Sum(nil)
 }
+
+// ShareHolder is an oracle which holds a share of a secret key with its node key.
+type ShareHolder struct {
+	OracleAddress string
+	NodePubKey    *btcec.PublicKey
+}
+
+// SecretKeyShare is a share of a secret key encrypted to the node key of its holder.
+type SecretKeyShare struct {
+	Index          uint32 `json:"index"`
+	OracleAddress  string `json:"oracle_address"`
+	NodePubKey     []byte `json:"node_pub_key"`
+	EncryptedShare []byte `json:"encrypted_share"`
+}
+
+// SecretKeyShares is stored in front of the consumer data,
+// so that the consumer can request each holder to release its share of the secret key.
+type SecretKeyShares struct {
+	Threshold uint32           `json:"threshold"`
+	Shares    []SecretKeyShare `json:"shares"`
+}
+
+// Bytes returns the shares prefixed by their length.
+func (s *SecretKeyShares) Bytes() ([]byte, error) {
+	sharesBz, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret key shares: %w", err)
+	}
+
+	buf := make([]byte, 4, 4+len(sharesBz))
+	binary.BigEndian.PutUint32(buf, uint32(len(sharesBz)))
+	return append(buf, sharesBz...), nil
+}
+
+// ReadSecretKeyShares reads the shares stored in front of the consumer data.
+func ReadSecretKeyShares(r io.Reader) (*SecretKeyShares, error) {
+	lenBz := make([]byte, 4)
+	if _, err := io.ReadFull(r, lenBz); err != nil {
+		return nil, fmt.Errorf("failed to read length of secret key shares: %w", err)
+	}
+	sharesLen := binary.BigEndian.Uint32(lenBz)
+	if sharesLen > maxSecretKeySharesLen {
+		return nil, fmt.Errorf("secret key shares are too large: %d", sharesLen)
+	}
+
+	sharesBz := make([]byte, sharesLen)
+	if _, err := io.ReadFull(r, sharesBz); err != nil {
+		return nil, fmt.Errorf("failed to read secret key shares: %w", err)
+	}
+
+	var shares SecretKeyShares
+	if err := json.Unmarshal(sharesBz, &shares); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret key shares: %w", err)
+	}
+	return &shares, nil
+}
+
+// SplitSecretKey splits the secret key into a share for each holder, and encrypts each share to the node key of its holder.
+// The share index is the position of the holder, so no two holders have the same index.
+// The additional data is bound to each share, so that a share is released only for the deal and the data.
+func SplitSecretKey(secretKey []byte, additional crypto.AdditionalData, threshold int, holders []ShareHolder) (*SecretKeyShares, error) {
+	shares, err := crypto.SplitSecret(secretKey, threshold, len(holders), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split secret key: %w", err)
+	}
+
+	secretKeyShares := &SecretKeyShares{
+		Threshold: uint32(threshold),
+		Shares:    make([]SecretKeyShare, 0, len(holders)),
+	}
+	for i, holder := range holders {
+		payload := append(additional.Bytes(), byte(threshold), byte(len(holders)))
+		payload = append(payload, shares[i]...)
+
+		encryptedShare, err := crypto.EncryptECIES(holder.NodePubKey, crypto.DefaultKeyEpoch, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt share for oracle(%s): %w", holder.OracleAddress, err)
+		}
+
+		secretKeyShares.Shares = append(secretKeyShares.Shares, SecretKeyShare{
+			Index:          uint32(crypto.ShareIndex(shares[i])),
+			OracleAddress:  holder.OracleAddress,
+			NodePubKey:     holder.NodePubKey.SerializeCompressed(),
+			EncryptedShare: encryptedShare,
+		})
+	}
+
+	return secretKeyShares, nil
+}
+
+// OpenSecretKeyShare decrypts the share with the node key of its holder, and checks that it is bound to the additional data.
+// It returns the share with the threshold and the total number of the shares.
+func OpenSecretKeyShare(nodePrivKey *btcec.PrivateKey, additional crypto.AdditionalData, encryptedShare []byte) ([]byte, int, int, error) {
+	payload, err := crypto.DecryptECIES(nodePrivKey, encryptedShare)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decrypt share: %w", err)
+	}
+
+	additionalBz := additional.Bytes()
+	if len(payload) <= len(additionalBz)+2 || !bytes.Equal(payload[:len(additionalBz)], additionalBz) {
+		return nil, 0, 0, fmt.Errorf("the share is not for the deal(%d) and the data(%s)", additional.DealID, additional.DataHash)
+	}
+	payload = payload[len(additionalBz):]
+
+	return payload[2:], int(payload[0]), int(payload[1]), nil
+}
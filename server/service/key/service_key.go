@@ -7,17 +7,95 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/medibloc/panacea-oracle/crypto"
+	oraclekey "github.com/medibloc/panacea-oracle/key"
 	key "github.com/medibloc/panacea-oracle/pb/key/v0"
 	"github.com/medibloc/panacea-oracle/server/rpc/interceptor/auth"
 	log "github.com/sirupsen/logrus"
 )
 
 func (s *secretKeyService) GetSecretKey(ctx context.Context, req *key.GetSecretKeyRequest) (*key.GetSecretKeyResponse, error) {
-	queryClient := s.QueryClient()
 	oraclePrivKey := s.OraclePrivKey()
 
+	if s.Config().Threshold.Enabled {
+		return nil, fmt.Errorf("threshold mode is enabled. please request shares of the secret key")
+	}
+
+	dealID := req.DealId
+
+	consumerPubKey, err := s.getConsumerPubKey(ctx, dealID, req.DataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedKey := crypto.DeriveSharedKey(oraclePrivKey, consumerPubKey, crypto.KDFSHA256)
+
+	dataHashBz, err := hex.DecodeString(req.DataHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode dataHash(%s). %w", req.DataHash, err)
+	}
+	secretKey := GetSecretKey(oraclePrivKey.Serialize(), dealID, dataHashBz)
+	additional := crypto.AdditionalData{
+		DealID:   dealID,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeSecretKey,
+	}
+	encryptedSecretKey, err := crypto.EncryptEnvelope(sharedKey, crypto.DefaultKeyEpoch, additional, secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret key with shared key: %w", err)
+	}
+
+	return &key.GetSecretKeyResponse{
+		EncryptedSecretKey: encryptedSecretKey,
+	}, nil
+}
+
+// GetSecretKeyShare releases the share held by this oracle to the consumer.
+// The share is opened with the node key of this oracle, and encrypted with the key shared between the node key and the consumer.
+func (s *secretKeyService) GetSecretKeyShare(ctx context.Context, req *key.GetSecretKeyShareRequest) (*key.GetSecretKeyShareResponse, error) {
+	if !s.Config().Threshold.Enabled {
+		return nil, fmt.Errorf("threshold mode is not enabled")
+	}
+
 	dealID := req.DealId
-	dataHash := req.DataHash
+
+	consumerPubKey, err := s.getConsumerPubKey(ctx, dealID, req.DataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePrivKey, err := oraclekey.LoadNodePrivKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node private key: %w", err)
+	}
+
+	additional := crypto.AdditionalData{
+		DealID:   dealID,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeSecretKeyShare,
+	}
+	share, threshold, totalShares, err := OpenSecretKeyShare(nodePrivKey, additional, req.EncryptedShare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open share of secret key: %w", err)
+	}
+
+	sharedKey := crypto.DeriveSharedKey(nodePrivKey, consumerPubKey, crypto.KDFSHA256)
+	encryptedShare, err := crypto.EncryptEnvelope(sharedKey, crypto.DefaultKeyEpoch, additional, share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt share of secret key with shared key: %w", err)
+	}
+
+	return &key.GetSecretKeyShareResponse{
+		ShareIndex:     uint32(crypto.ShareIndex(share)),
+		Threshold:      uint32(threshold),
+		TotalShares:    uint32(totalShares),
+		EncryptedShare: encryptedShare,
+	}, nil
+}
+
+// getConsumerPubKey checks that the requester is the consumer of the deal and the data is consented,
+// and then returns the public key of the consumer.
+func (s *secretKeyService) getConsumerPubKey(ctx context.Context, dealID uint64, dataHash string) (*btcec.PublicKey, error) {
+	queryClient := s.QueryClient()
 
 	requesterAddress, err := auth.GetRequestAddress(ctx)
 	if err != nil {
@@ -49,19 +127,5 @@ func (s *secretKeyService) GetSecretKey(ctx context.Context, req *key.GetSecretK
 		return nil, fmt.Errorf("failed to parse consumer public key: %w", err)
 	}
 
-	sharedKey := crypto.DeriveSharedKey(oraclePrivKey, consumerPubKey, crypto.KDFSHA256)
-
-	dataHashBz, err := hex.DecodeString(req.DataHash)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode dataHash(%s). %w", req.DataHash, err)
-	}
-	secretKey := GetSecretKey(oraclePrivKey.Serialize(), dealID, dataHashBz)
-	encryptedSecretKey, err := crypto.Encrypt(sharedKey, nil, secretKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt secret key with shared key: %w", err)
-	}
-
-	return &key.GetSecretKeyResponse{
-		EncryptedSecretKey: encryptedSecretKey,
-	}, nil
+	return consumerPubKey, nil
 }
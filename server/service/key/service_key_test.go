@@ -1,8 +1,10 @@
 package key
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"os"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -10,11 +12,13 @@ import (
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	datadealtypes "github.com/medibloc/panacea-core/v2/x/datadeal/types"
+	oracletypes "github.com/medibloc/panacea-core/v2/x/oracle/types"
 	"github.com/medibloc/panacea-oracle/crypto"
 	"github.com/medibloc/panacea-oracle/mocks"
 	"github.com/medibloc/panacea-oracle/panacea"
 	key "github.com/medibloc/panacea-oracle/pb/key/v0"
 	"github.com/medibloc/panacea-oracle/server/rpc/interceptor/auth"
+	"github.com/medibloc/panacea-oracle/sgx"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -73,7 +77,12 @@ func (suite *secretKeyServiceTestSuite) TestGetSecretKey() {
 		crypto.KDFSHA256,
 	)
 
-	secretKey, err := crypto.Decrypt(sharedKey, nil, res.EncryptedSecretKey)
+	additional := crypto.AdditionalData{
+		DealID:   req.DealId,
+		DataHash: req.DataHash,
+		Purpose:  crypto.PurposeSecretKey,
+	}
+	secretKey, err := crypto.DecryptEnvelope(sharedKey, additional, res.EncryptedSecretKey)
 	suite.Require().NoError(err)
 
 	suite.Require().Equal(
@@ -126,3 +135,142 @@ func (suite *secretKeyServiceTestSuite) TestGetSecretKeyNotSameRequesterAndDeals
 	suite.Require().Nil(res)
 	suite.Require().ErrorContains(err, "only consumer request secret key")
 }
+
+func (suite *secretKeyServiceTestSuite) setShareHolders(count int) {
+	suite.GrpcClient.Oracles = nil
+	for i := 0; i < count; i++ {
+		suite.GrpcClient.Oracles = append(suite.GrpcClient.Oracles, &oracletypes.Oracle{
+			OracleAddress: panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey()),
+			UniqueId:      suite.UniqueID,
+		})
+	}
+	// an oracle with another unique ID doesn't hold a share
+	suite.GrpcClient.Oracles = append(suite.GrpcClient.Oracles, &oracletypes.Oracle{
+		OracleAddress: panacea.GetAddressFromPrivateKey(*secp256k1.GenPrivKey()),
+		UniqueId:      "other",
+	})
+	suite.QueryClient.OracleRegistration = &oracletypes.OracleRegistration{
+		NodePubKey:             suite.NodePrivKey.PubKey().SerializeCompressed(),
+		EncryptedOraclePrivKey: []byte("encryptedOraclePrivKey"),
+	}
+}
+
+func (suite *secretKeyServiceTestSuite) TestGetSecretKeyShare() {
+	combinedKeyService := secretKeyService{Service: suite.Svc}
+	dataHash := hex.EncodeToString(crypto.KDFSHA256([]byte("my_data")))
+
+	suite.Config.Threshold.Enabled = true
+	suite.QueryClient.Deal.ConsumerAddress = suite.consumerAddress
+	suite.setShareHolders(4)
+
+	err := suite.SGX.SealToFile(suite.NodePrivKey.Serialize(), suite.Config.AbsNodePrivKeyPath(), sgx.SealPolicyUniqueKey)
+	suite.Require().NoError(err)
+	defer os.Remove(suite.Config.AbsNodePrivKeyPath())
+
+	ctx := context.WithValue(
+		context.Background(),
+		auth.ContextKeyAuthenticatedAccountAddress{},
+		suite.consumerAddress,
+	)
+
+	secretKey, shares, err := GenerateSecretKeyShares(ctx, suite.Svc, 1, dataHash)
+	suite.Require().NoError(err)
+
+	// the shares are read from the front of the consumer data
+	sharesBz, err := shares.Bytes()
+	suite.Require().NoError(err)
+	shares, err = ReadSecretKeyShares(bytes.NewReader(sharesBz))
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(uint32(suite.Config.Threshold.Threshold), shares.Threshold)
+	suite.Require().Len(shares.Shares, suite.Config.Threshold.TotalShares)
+	for i, share := range shares.Shares {
+		suite.Require().Equal(uint32(i+1), share.Index)
+	}
+
+	consumerPrivKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), suite.consumerAccPrivKey.Bytes())
+	additional := crypto.AdditionalData{
+		DealID:   1,
+		DataHash: dataHash,
+		Purpose:  crypto.PurposeSecretKeyShare,
+	}
+
+	var releasedShares [][]byte
+	for _, share := range shares.Shares[:suite.Config.Threshold.Threshold] {
+		res, err := combinedKeyService.GetSecretKeyShare(ctx, &key.GetSecretKeyShareRequest{
+			DealId:         1,
+			DataHash:       dataHash,
+			EncryptedShare: share.EncryptedShare,
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(share.Index, res.ShareIndex)
+		suite.Require().Equal(shares.Threshold, res.Threshold)
+		suite.Require().Equal(uint32(len(shares.Shares)), res.TotalShares)
+
+		nodePubKey, err := btcec.ParsePubKey(share.NodePubKey, btcec.S256())
+		suite.Require().NoError(err)
+		sharedKey := crypto.DeriveSharedKey(consumerPrivKey, nodePubKey, crypto.KDFSHA256)
+		releasedShare, err := crypto.DecryptEnvelope(sharedKey, additional, res.EncryptedShare)
+		suite.Require().NoError(err)
+		releasedShares = append(releasedShares, releasedShare)
+	}
+
+	combinedSecretKey, err := crypto.CombineShares(releasedShares)
+	suite.Require().NoError(err)
+	suite.Require().Equal(secretKey, combinedSecretKey)
+
+	// the share is not released for another data
+	res, err := combinedKeyService.GetSecretKeyShare(ctx, &key.GetSecretKeyShareRequest{
+		DealId:         1,
+		DataHash:       hex.EncodeToString(crypto.KDFSHA256([]byte("other_data"))),
+		EncryptedShare: shares.Shares[0].EncryptedShare,
+	})
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "the share is not for the deal(1)")
+}
+
+func (suite *secretKeyServiceTestSuite) TestGenerateSecretKeySharesNotEnoughHolders() {
+	suite.Config.Threshold.Enabled = true
+	suite.setShareHolders(1)
+
+	dataHash := hex.EncodeToString(crypto.KDFSHA256([]byte("my_data")))
+	_, _, err := GenerateSecretKeyShares(context.Background(), suite.Svc, 1, dataHash)
+	suite.Require().ErrorContains(err, "not enough oracles to hold the shares")
+}
+
+func (suite *secretKeyServiceTestSuite) TestGetSecretKeyThresholdEnabled() {
+	combinedKeyService := secretKeyService{Service: suite.Svc}
+	dataHash := hex.EncodeToString(crypto.KDFSHA256([]byte("my_data")))
+
+	suite.Config.Threshold.Enabled = true
+	suite.QueryClient.Deal.ConsumerAddress = suite.consumerAddress
+
+	req := &key.GetSecretKeyRequest{
+		DealId:   1,
+		DataHash: dataHash,
+	}
+
+	ctx := context.WithValue(
+		context.Background(),
+		auth.ContextKeyAuthenticatedAccountAddress{},
+		suite.consumerAddress,
+	)
+
+	res, err := combinedKeyService.GetSecretKey(ctx, req)
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "threshold mode is enabled")
+}
+
+func (suite *secretKeyServiceTestSuite) TestGetSecretKeyShareThresholdDisabled() {
+	combinedKeyService := secretKeyService{Service: suite.Svc}
+	dataHash := hex.EncodeToString(crypto.KDFSHA256([]byte("my_data")))
+
+	req := &key.GetSecretKeyShareRequest{
+		DealId:   1,
+		DataHash: dataHash,
+	}
+
+	res, err := combinedKeyService.GetSecretKeyShare(context.Background(), req)
+	suite.Require().Nil(res)
+	suite.Require().ErrorContains(err, "threshold mode is not enabled")
+}
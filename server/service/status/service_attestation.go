@@ -0,0 +1,36 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	status "github.com/medibloc/panacea-oracle/pb/status/v0"
+	"github.com/medibloc/panacea-oracle/sgx"
+	log "github.com/sirupsen/logrus"
+)
+
+func (s *statusService) GetAttestation(ctx context.Context, req *status.GetAttestationRequest) (*status.GetAttestationResponse, error) {
+	if err := sgx.ValidateAttestationNonce(req.Nonce); err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+
+	oraclePrivKey := s.OraclePrivKey()
+	if oraclePrivKey == nil {
+		return nil, fmt.Errorf("the oracle private key is not set yet")
+	}
+
+	oraclePubKey := oraclePrivKey.PubKey().SerializeCompressed()
+	oracleAddress := s.OracleAcc().GetAddress()
+
+	remoteReport, err := s.SGX().GenerateRemoteReport(sgx.AttestationReportData(req.Nonce, oraclePubKey, oracleAddress))
+	if err != nil {
+		log.Errorf("failed to generate remote report: %v", err)
+		return nil, fmt.Errorf("failed to generate remote report")
+	}
+
+	return &status.GetAttestationResponse{
+		RemoteReport:         remoteReport,
+		OraclePubKey:         oraclePubKey,
+		OracleAccountAddress: oracleAddress,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package status
+
+import (
+	"context"
+
+	status "github.com/medibloc/panacea-oracle/pb/status/v0"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// GetHealth returns Unavailable if the event subscriber is not connected to Panacea,
+// so that the REST endpoint responds with 503 Service Unavailable.
+func (s *statusService) GetHealth(ctx context.Context, req *status.GetHealthRequest) (*status.GetHealthResponse, error) {
+	subscriberStatus := s.SubscriberStatus()
+	if !subscriberStatus.Connected() {
+		return nil, grpcstatus.Errorf(codes.Unavailable, "event subscriber is %s: %s", subscriberStatus.State, subscriberStatus.LastError)
+	}
+
+	return &status.GetHealthResponse{
+		Subscriber: newStatusSubscriber(subscriberStatus),
+	}, nil
+}
@@ -3,6 +3,8 @@ package status
 import (
 	"context"
 
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/event"
 	status "github.com/medibloc/panacea-oracle/pb/status/v0"
 )
 
@@ -20,5 +22,54 @@ func (s *statusService) GetStatus(ctx context.Context, req *status.GetStatusRequ
 			ProductId: s.EnclaveInfo().ProductID,
 			UniqueId:  s.EnclaveInfo().UniqueIDHex(),
 		},
+		Subscriber:          newStatusSubscriber(s.SubscriberStatus()),
+		ApprovalCoordinator: newStatusApprovalCoordinator(s.ApprovalCoordinator().Stats()),
 	}, nil
 }
+
+func newStatusSubscriber(subscriberStatus event.SubscriberStatus) *status.StatusSubscriber {
+	var lastConnectedAt int64
+	if !subscriberStatus.LastConnectedAt.IsZero() {
+		lastConnectedAt = subscriberStatus.LastConnectedAt.Unix()
+	}
+
+	return &status.StatusSubscriber{
+		State:           string(subscriberStatus.State),
+		Connected:       subscriberStatus.Connected(),
+		LastConnectedAt: lastConnectedAt,
+		Reconnects:      subscriberStatus.Reconnects,
+		LastError:       subscriberStatus.LastError,
+		Dispatcher:      newStatusEventDispatcher(subscriberStatus.Dispatcher),
+	}
+}
+
+func newStatusEventDispatcher(stats event.DispatcherStats) *status.StatusEventDispatcher {
+	handlers := make([]*status.StatusEventHandler, 0, len(stats.Handlers))
+	for _, handler := range stats.Handlers {
+		handlers = append(handlers, &status.StatusEventHandler{
+			Event:         handler.Event,
+			Handled:       handler.Handled,
+			Failed:        handler.Failed,
+			TimedOut:      handler.TimedOut,
+			LastLatencyMs: handler.LastLatency.Milliseconds(),
+			AvgLatencyMs:  handler.AvgLatency.Milliseconds(),
+			MaxLatencyMs:  handler.MaxLatency.Milliseconds(),
+		})
+	}
+
+	return &status.StatusEventDispatcher{
+		Workers:       uint32(stats.Workers),
+		QueueDepth:    uint32(stats.QueueDepth),
+		QueueCapacity: uint32(stats.QueueCapacity),
+		InFlight:      uint32(stats.InFlight),
+		Handlers:      handlers,
+	}
+}
+
+func newStatusApprovalCoordinator(stats approval.CoordinatorStats) *status.StatusApprovalCoordinator {
+	return &status.StatusApprovalCoordinator{
+		Led:      stats.Led,
+		Followed: stats.Followed,
+		Skipped:  stats.Skipped,
+	}
+}
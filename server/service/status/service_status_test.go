@@ -3,9 +3,16 @@ package status
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/medibloc/panacea-oracle/approval"
+	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/mocks"
+	status "github.com/medibloc/panacea-oracle/pb/status/v0"
+	"github.com/medibloc/panacea-oracle/sgx"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 type getStatusTestSuite struct {
@@ -37,4 +44,96 @@ func (suite *getStatusTestSuite) TestGetStatus() {
 	suite.Require().Equal(conf.GRPC.ListenAddr, res.Grpc.ListenAddr)
 	suite.Require().Equal(svc.EnclaveInfo().ProductID, res.EnclaveInfo.ProductId)
 	suite.Require().Equal(svc.EnclaveInfo().UniqueIDHex(), res.EnclaveInfo.UniqueId)
+	suite.Require().Equal(string(svc.SubscriberStatus().State), res.Subscriber.State)
+}
+
+func (suite *getStatusTestSuite) TestGetStatusEventDispatcher() {
+	suite.Svc.SetSubscriberStatus(event.SubscriberStatus{
+		State: event.StateConnected,
+		Dispatcher: event.DispatcherStats{
+			Workers:       4,
+			QueueDepth:    3,
+			QueueCapacity: 400,
+			InFlight:      2,
+			Handlers: []event.HandlerStats{
+				{Event: "RegisterOracleEvent", Handled: 5, Failed: 1, TimedOut: 1, AvgLatency: 1500 * time.Millisecond, MaxLatency: 3 * time.Second},
+			},
+		},
+	})
+
+	statusService := statusService{
+		Service: suite.Svc,
+	}
+
+	res, err := statusService.GetStatus(context.Background(), nil)
+	suite.Require().NoError(err)
+
+	dispatcher := res.Subscriber.Dispatcher
+	suite.Require().Equal(uint32(4), dispatcher.Workers)
+	suite.Require().Equal(uint32(3), dispatcher.QueueDepth)
+	suite.Require().Equal(uint32(400), dispatcher.QueueCapacity)
+	suite.Require().Equal(uint32(2), dispatcher.InFlight)
+	suite.Require().Len(dispatcher.Handlers, 1)
+	suite.Require().Equal("RegisterOracleEvent", dispatcher.Handlers[0].Event)
+	suite.Require().Equal(uint64(1), dispatcher.Handlers[0].TimedOut)
+	suite.Require().Equal(int64(1500), dispatcher.Handlers[0].AvgLatencyMs)
+	suite.Require().Equal(int64(3000), dispatcher.Handlers[0].MaxLatencyMs)
+}
+
+func (suite *getStatusTestSuite) TestGetStatusApprovalCoordinator() {
+	coordinator := approval.NewCoordinator(suite.GrpcClient, suite.UniqueID, suite.OracleAcc.GetAddress(), time.Second, time.Minute)
+	suite.Svc.SetApprovalCoordinator(coordinator)
+
+	// this oracle is the leader since there is no other oracle
+	request := approval.NewRequest(approval.KindRegistration, suite.UniqueID, "panacea1target")
+	ok, err := coordinator.Wait(context.Background(), request, nil)
+	suite.Require().NoError(err)
+	suite.Require().True(ok)
+
+	statusService := statusService{
+		Service: suite.Svc,
+	}
+
+	res, err := statusService.GetStatus(context.Background(), nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), res.ApprovalCoordinator.Led)
+	suite.Require().Equal(uint64(0), res.ApprovalCoordinator.Followed)
+	suite.Require().Equal(uint64(0), res.ApprovalCoordinator.Skipped)
+}
+
+func (suite *getStatusTestSuite) TestGetAttestation() {
+	suite.SGX.RemoteReport = []byte("remote report")
+
+	statusService := statusService{
+		Service: suite.Svc,
+	}
+
+	nonce := make([]byte, sgx.MinAttestationNonceSize)
+	res, err := statusService.GetAttestation(context.Background(), &status.GetAttestationRequest{Nonce: nonce})
+	suite.Require().NoError(err)
+	suite.Require().Equal(suite.SGX.RemoteReport, res.RemoteReport)
+	suite.Require().Equal(suite.OraclePubKey.SerializeCompressed(), res.OraclePubKey)
+	suite.Require().Equal(suite.OracleAcc.GetAddress(), res.OracleAccountAddress)
+
+	_, err = statusService.GetAttestation(context.Background(), &status.GetAttestationRequest{Nonce: nonce[1:]})
+	suite.Require().ErrorContains(err, "invalid nonce")
+}
+
+func (suite *getStatusTestSuite) TestGetHealth() {
+	statusService := statusService{
+		Service: suite.Svc,
+	}
+
+	suite.Svc.SetSubscriberStatus(event.SubscriberStatus{State: event.StateDisconnected, LastError: "connection refused"})
+	_, err := statusService.GetHealth(context.Background(), &status.GetHealthRequest{})
+	suite.Require().Equal(codes.Unavailable, grpcstatus.Code(err))
+	suite.Require().ErrorContains(err, "connection refused")
+
+	connectedAt := time.Now()
+	suite.Svc.SetSubscriberStatus(event.SubscriberStatus{State: event.StateConnected, LastConnectedAt: connectedAt, Reconnects: 1})
+	res, err := statusService.GetHealth(context.Background(), &status.GetHealthRequest{})
+	suite.Require().NoError(err)
+	suite.Require().True(res.Subscriber.Connected)
+	suite.Require().Equal(connectedAt.Unix(), res.Subscriber.LastConnectedAt)
+	suite.Require().Equal(uint64(1), res.Subscriber.Reconnects)
 }
@@ -1,18 +1,22 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/medibloc/panacea-oracle/consumer_service"
 	"github.com/medibloc/panacea-oracle/crypto"
+	"github.com/medibloc/panacea-oracle/deal"
 	"github.com/tendermint/tendermint/libs/os"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/medibloc/panacea-oracle/approval"
 	"github.com/medibloc/panacea-oracle/config"
 	"github.com/medibloc/panacea-oracle/event"
 	"github.com/medibloc/panacea-oracle/panacea"
 	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/medibloc/panacea-oracle/store/sgxleveldb"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,8 +29,15 @@ type Service interface {
 	Config() *config.Config
 	QueryClient() panacea.QueryClient
 	ConsumerService() consumer_service.FileStorage
-	BroadcastTx(...sdk.Msg) (int64, string, error)
+	DealRegistry() *deal.Registry
+	ApprovalPolicy() *approval.Policy
+	ApprovalCoordinator() *approval.Coordinator
+	BroadcastTx(context.Context, ...sdk.Msg) (int64, string, error)
+	BroadcastSignedTx(context.Context, []byte) (int64, string, error)
 	StartSubscriptions(...event.Event) error
+	Dispatcher() *event.Dispatcher
+	DeadLetters() *event.DeadLetterQueue
+	SubscriberStatus() event.SubscriberStatus
 	Close() error
 }
 
@@ -41,26 +52,65 @@ type service struct {
 	queryClient     panacea.QueryClient
 	grpcClient      panacea.GRPCClient
 	consumerService consumer_service.FileStorage
-	subscriber      *event.PanaceaSubscriber
-	txBuilder       *panacea.TxBuilder
+	dealRegistry    *deal.Registry
+	dispatcher      *event.Dispatcher
+	subscriber      event.Source
+	checkpointDB    *sgxleveldb.SgxLevelDB
+	deadLetterDB    *sgxleveldb.SgxLevelDB
+	deadLetters     *event.DeadLetterQueue
+	approvalDB      *sgxleveldb.SgxLevelDB
+	approvalPolicy  *approval.Policy
+	coordinator     *approval.Coordinator
+	txSubmitter     *panacea.TxSubmitter
 }
 
-func New(conf *config.Config, sgx sgx.Sgx, queryClient panacea.QueryClient) (Service, error) {
-	oracleAccount, err := panacea.NewOracleAccount(conf.OracleMnemonic, conf.OracleAccNum, conf.OracleAccIndex)
+func New(conf *config.Config, oracleSgx sgx.Sgx, queryClient panacea.QueryClient) (Service, error) {
+	mnemonic, err := panacea.LoadOracleMnemonic(conf, oracleSgx)
 	if err != nil {
 		return nil, err
 	}
 
+	oracleAccount, err := panacea.NewOracleAccount(mnemonic, conf.OracleAccNum, conf.OracleAccIndex)
+	if err != nil {
+		return nil, err
+	}
+	if conf.Panacea.AuthzGranter != "" {
+		oracleAccount, err = oracleAccount.WithGranter(conf.Panacea.AuthzGranter)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("oracle %s signs txs with the key %s via authz", oracleAccount.GetAddress(), oracleAccount.GetSignerAddress())
+	}
+
+	return newService(conf, oracleSgx, queryClient, oracleAccount)
+}
+
+// NewWithOracleAddress returns a service of the oracle address without loading the oracle mnemonic,
+// for the txs signed offline by the key in custody. The service cannot sign txs by BroadcastTx.
+func NewWithOracleAddress(conf *config.Config, oracleSgx sgx.Sgx, queryClient panacea.QueryClient, oracleAddress string) (Service, error) {
+	oracleAccount, err := panacea.NewOracleAddressAccount(oracleAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return newService(conf, oracleSgx, queryClient, oracleAccount)
+}
+
+func newService(conf *config.Config, oracleSgx sgx.Sgx, queryClient panacea.QueryClient, oracleAccount *panacea.OracleAccount) (Service, error) {
+	if conf.AllowLegacyCiphertext {
+		log.Warn("allow-legacy-ciphertext is deprecated. legacy ciphertexts without an envelope header are still accepted")
+	}
+
 	var oraclePrivKey *btcec.PrivateKey
 	if os.FileExists(conf.AbsOraclePrivKeyPath()) {
-		oraclePrivKeyBz, err := sgx.UnsealFromFile(conf.AbsOraclePrivKeyPath())
+		oraclePrivKeyBz, err := oracleSgx.UnsealFromFile(conf.AbsOraclePrivKeyPath())
 		if err != nil {
 			return nil, fmt.Errorf("failed to unseal oracle_priv_key.sealed file: %w", err)
 		}
 		oraclePrivKey, _ = crypto.PrivKeyFromBytes(oraclePrivKeyBz)
 	}
 
-	selfEnclaveInfo, err := sgx.GenerateSelfEnclaveInfo()
+	selfEnclaveInfo, err := oracleSgx.GenerateSelfEnclaveInfo()
 	if err != nil {
 		return nil, fmt.Errorf("failed to set self-enclave info: %w", err)
 	}
@@ -70,9 +120,33 @@ func New(conf *config.Config, sgx sgx.Sgx, queryClient panacea.QueryClient) (Ser
 		return nil, fmt.Errorf("failed to create a new gRPC client: %w", err)
 	}
 
-	txBuilder := panacea.NewTxBuilder(grpcClient)
+	checkpointDB, err := sgxleveldb.NewSgxLevelDB(event.CheckpointDBName, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(conf.Sealing.EventCheckpointDB))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event checkpoint DB: %w", err)
+	}
+
+	deadLetterDB, err := sgxleveldb.NewSgxLevelDB(event.DeadLetterDBName, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(conf.Sealing.EventDeadLetterDB))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event dead-letter DB: %w", err)
+	}
+	deadLetters := event.NewDeadLetterQueue(event.NewDBDeadLetterStore(deadLetterDB), conf.Event.MaxRetries, conf.Event.RetryInterval)
+
+	approvalDB, err := sgxleveldb.NewSgxLevelDB(approval.DBName, conf.AbsDataDirPath(), oracleSgx, sgx.SealPolicy(conf.Sealing.ApprovalDB))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open approval DB: %w", err)
+	}
+
+	approvalPolicy, err := approval.NewPolicy(conf.Approval, queryClient, approval.NewDBStore(approvalDB))
+	if err != nil {
+		return nil, fmt.Errorf("failed to init approval policy: %w", err)
+	}
+
+	dispatcher, err := event.NewDispatcher(conf.Event.Workers, conf.Event.QueueSize, conf.Event.HandlerTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init event dispatcher: %w", err)
+	}
 
-	subscriber, err := event.NewSubscriber(conf.Panacea.RPCAddr)
+	subscriber, err := newEventSource(conf, dispatcher, deadLetters, event.NewDBCheckpointStore(checkpointDB), queryClient)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init subscriber: %w", err)
 	}
@@ -88,19 +162,53 @@ func New(conf *config.Config, sgx sgx.Sgx, queryClient panacea.QueryClient) (Ser
 		oracleAccount:   oracleAccount,
 		oraclePrivKey:   oraclePrivKey,
 		enclaveInfo:     selfEnclaveInfo,
-		sgx:             sgx,
+		sgx:             oracleSgx,
 		queryClient:     queryClient,
 		grpcClient:      grpcClient,
 		consumerService: consumerService,
-		txBuilder:       txBuilder,
+		dealRegistry:    deal.NewRegistry(),
+		txSubmitter:     panacea.NewTxSubmitter(grpcClient, oracleAccount.GetPrivKey(), conf),
+		dispatcher:      dispatcher,
 		subscriber:      subscriber,
+		checkpointDB:    checkpointDB,
+		deadLetterDB:    deadLetterDB,
+		deadLetters:     deadLetters,
+		approvalDB:      approvalDB,
+		approvalPolicy:  approvalPolicy,
+		coordinator:     approval.NewCoordinator(grpcClient, selfEnclaveInfo.UniqueIDHex(), oracleAccount.GetAddress(), conf.Approval.FollowerDelay, conf.MaxFollowerWait()),
 	}, nil
 }
 
+// newEventSource returns the source of the events from Panacea, selected by the subscriber config.
+func newEventSource(conf *config.Config, dispatcher *event.Dispatcher, deadLetters *event.DeadLetterQueue, checkpoints event.CheckpointStore, lightClient event.LightBlockGetter) (event.Source, error) {
+	if conf.Subscriber == "polling" {
+		poller, err := event.NewPoller(conf.Panacea.RPCAddr, conf.Event.PollInterval, dispatcher, deadLetters, checkpoints, lightClient)
+		if err != nil {
+			return nil, err
+		}
+		return poller, nil
+	}
+
+	subscriber, err := event.NewSubscriber(conf.Panacea.RPCAddr, dispatcher, deadLetters, checkpoints, lightClient)
+	if err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
 func (s *service) StartSubscriptions(events ...event.Event) error {
 	return s.subscriber.Run(events...)
 }
 
+// Dispatcher returns the dispatcher of the events from Panacea.
+func (s *service) Dispatcher() *event.Dispatcher {
+	return s.dispatcher
+}
+
+func (s *service) SubscriberStatus() event.SubscriberStatus {
+	return s.subscriber.Status()
+}
+
 func (s *service) Close() error {
 	log.Info("calling the service's close function")
 	if err := s.grpcClient.Close(); err != nil {
@@ -109,6 +217,15 @@ func (s *service) Close() error {
 	if err := s.subscriber.Close(); err != nil {
 		log.Warn(err)
 	}
+	if err := s.checkpointDB.Close(); err != nil {
+		log.Warn(err)
+	}
+	if err := s.deadLetterDB.Close(); err != nil {
+		log.Warn(err)
+	}
+	if err := s.approvalDB.Close(); err != nil {
+		log.Warn(err)
+	}
 
 	return nil
 }
@@ -145,27 +262,38 @@ func (s *service) ConsumerService() consumer_service.FileStorage {
 	return s.consumerService
 }
 
-func (s *service) BroadcastTx(msg ...sdk.Msg) (int64, string, error) {
-	defaultFeeAmount, _ := sdk.ParseCoinsNormalized(s.Config().Panacea.DefaultFeeAmount)
+func (s *service) DealRegistry() *deal.Registry {
+	return s.dealRegistry
+}
 
-	txBytes, err := s.txBuilder.GenerateSignedTxBytes(
-		s.OracleAcc().GetPrivKey(),
-		s.Config().Panacea.DefaultGasLimit,
-		defaultFeeAmount,
-		msg...,
-	)
-	if err != nil {
-		return 0, "", fmt.Errorf("failed to generate signed Tx bytes: %w", err)
-	}
+func (s *service) ApprovalPolicy() *approval.Policy {
+	return s.approvalPolicy
+}
 
-	resp, err := s.GRPCClient().BroadcastTx(txBytes)
+func (s *service) DeadLetters() *event.DeadLetterQueue {
+	return s.deadLetters
+}
+
+func (s *service) ApprovalCoordinator() *approval.Coordinator {
+	return s.coordinator
+}
+
+// BroadcastTx submits the msgs in a tx, and waits until the tx is included in a block or the ctx is done.
+func (s *service) BroadcastTx(ctx context.Context, msg ...sdk.Msg) (int64, string, error) {
+	resp, err := s.txSubmitter.Submit(ctx, msg...)
 	if err != nil {
-		return 0, "", fmt.Errorf("broadcast transaction failed. txBytes(%v)", txBytes)
+		return 0, "", err
 	}
 
-	if resp.TxResponse.Code != 0 {
-		return 0, "", fmt.Errorf("transaction failed: %v", resp.TxResponse.RawLog)
+	return resp.Height, resp.TxHash, nil
+}
+
+// BroadcastSignedTx submits the tx signed offline, and waits until the tx is included in a block or the ctx is done.
+func (s *service) BroadcastSignedTx(ctx context.Context, txBytes []byte) (int64, string, error) {
+	resp, err := s.txSubmitter.SubmitSigned(ctx, txBytes)
+	if err != nil {
+		return 0, "", err
 	}
 
-	return resp.TxResponse.Height, resp.TxResponse.TxHash, nil
+	return resp.Height, resp.TxHash, nil
 }
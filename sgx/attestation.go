@@ -0,0 +1,34 @@
+package sgx
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	MinAttestationNonceSize = 16
+	MaxAttestationNonceSize = 256
+
+	attestationDomain = "panacea-oracle/attestation"
+)
+
+// AttestationReportData returns the data of a remote report which binds the client nonce to the oracle public key and address.
+// It is the hash of the length-prefixed nonce, oracle public key and oracle address.
+func AttestationReportData(nonce, oraclePubKey []byte, oracleAddress string) []byte {
+	h := sha256.New()
+	h.Write([]byte(attestationDomain))
+	for _, field := range [][]byte{nonce, oraclePubKey, []byte(oracleAddress)} {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+		h.Write(field)
+	}
+	return h.Sum(nil)
+}
+
+// ValidateAttestationNonce checks that the nonce has a proper length to make a report fresh.
+func ValidateAttestationNonce(nonce []byte) error {
+	if len(nonce) < MinAttestationNonceSize || len(nonce) > MaxAttestationNonceSize {
+		return fmt.Errorf("nonce should be %d to %d bytes, but %d bytes", MinAttestationNonceSize, MaxAttestationNonceSize, len(nonce))
+	}
+	return nil
+}
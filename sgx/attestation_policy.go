@@ -0,0 +1,131 @@
+package sgx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/edgelesssys/ego/attestation"
+	"github.com/edgelesssys/ego/attestation/tcbstatus"
+	"github.com/medibloc/panacea-oracle/config"
+)
+
+// AttestationPolicy is a set of requirements that a remote report should satisfy.
+type AttestationPolicy struct {
+	// SignerID is the expected signer ID (MRSIGNER). If empty, any signer ID is allowed.
+	SignerID []byte
+	// ProductID is the expected product ID (ISVPRODID). If 0, any product ID is allowed.
+	ProductID          uint16
+	MinSecurityVersion uint
+	AllowDebug         bool
+	AllowedTCBStatuses []tcbstatus.Status
+}
+
+// DefaultAttestationPolicy returns the policy which requires the minimum security version, the up-to-date TCB
+// and a production enclave.
+func DefaultAttestationPolicy() AttestationPolicy {
+	return AttestationPolicy{
+		MinSecurityVersion: PromisedMinSecurityVersion,
+		AllowDebug:         false,
+		AllowedTCBStatuses: []tcbstatus.Status{tcbstatus.UpToDate},
+	}
+}
+
+// SimulationAttestationPolicy returns the default policy which allows debug enclaves,
+// since all reports of the SGX simulation are of debug enclaves. Never use it with SGX hardware.
+func SimulationAttestationPolicy() AttestationPolicy {
+	policy := DefaultAttestationPolicy()
+	policy.AllowDebug = true
+	return policy
+}
+
+// NewAttestationPolicy returns the policy from the attestation config.
+func NewAttestationPolicy(conf config.AttestationConfig) (AttestationPolicy, error) {
+	signerID, err := hex.DecodeString(conf.SignerID)
+	if err != nil {
+		return AttestationPolicy{}, fmt.Errorf("invalid signer ID: %w", err)
+	}
+
+	var allowedTCBStatuses []tcbstatus.Status
+	for _, name := range conf.AllowedTCBStatuses {
+		status, err := parseTCBStatus(strings.TrimSpace(name))
+		if err != nil {
+			return AttestationPolicy{}, err
+		}
+		allowedTCBStatuses = append(allowedTCBStatuses, status)
+	}
+
+	return AttestationPolicy{
+		SignerID:           signerID,
+		ProductID:          conf.ProductID,
+		MinSecurityVersion: conf.MinSecurityVersion,
+		AllowDebug:         conf.AllowDebug,
+		AllowedTCBStatuses: allowedTCBStatuses,
+	}, nil
+}
+
+// Verify checks the report against the policy.
+// If the report violates the policy, it returns a PolicyViolationError with all the reasons.
+func (p AttestationPolicy) Verify(report attestation.Report) error {
+	var reasons []string
+
+	if len(p.SignerID) > 0 && !bytes.Equal(report.SignerID, p.SignerID) {
+		reasons = append(reasons, fmt.Sprintf("signer ID is %X, but expected %X", report.SignerID, p.SignerID))
+	}
+	if p.ProductID != 0 {
+		if productID := ReportProductID(report); productID != p.ProductID {
+			reasons = append(reasons, fmt.Sprintf("product ID is %d, but expected %d", productID, p.ProductID))
+		}
+	}
+	if report.SecurityVersion < p.MinSecurityVersion {
+		reasons = append(reasons, fmt.Sprintf("security version %d is lower than the minimum %d", report.SecurityVersion, p.MinSecurityVersion))
+	}
+	if report.Debug && !p.AllowDebug {
+		reasons = append(reasons, "debug enclave is not allowed")
+	}
+	if !p.isAllowedTCBStatus(report.TCBStatus) {
+		reasons = append(reasons, fmt.Sprintf("TCB status %s is not allowed (%s)", report.TCBStatus, tcbstatus.Explain(report.TCBStatus)))
+	}
+
+	if len(reasons) > 0 {
+		return &PolicyViolationError{Reasons: reasons}
+	}
+	return nil
+}
+
+func (p AttestationPolicy) isAllowedTCBStatus(status tcbstatus.Status) bool {
+	for _, allowed := range p.AllowedTCBStatuses {
+		if status == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyViolationError is returned if a report violates the attestation policy.
+type PolicyViolationError struct {
+	Reasons []string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("report is rejected by the attestation policy: %s", strings.Join(e.Reasons, "; "))
+}
+
+// ReportProductID returns ISVPRODID, which is encoded in little endian in the report.
+func ReportProductID(report attestation.Report) uint16 {
+	if len(report.ProductID) < 2 {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(report.ProductID)
+}
+
+func parseTCBStatus(name string) (tcbstatus.Status, error) {
+	for status := tcbstatus.UpToDate; status <= tcbstatus.Unknown; status++ {
+		if status.String() == name {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid TCB status: %s", name)
+}
@@ -0,0 +1,100 @@
+package sgx_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgelesssys/ego/attestation"
+	"github.com/edgelesssys/ego/attestation/tcbstatus"
+	"github.com/medibloc/panacea-oracle/config"
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/stretchr/testify/require"
+)
+
+func newReport() attestation.Report {
+	signerID := sha256.Sum256([]byte("signer"))
+	return attestation.Report{
+		SecurityVersion: 2,
+		Debug:           false,
+		SignerID:        signerID[:],
+		ProductID:       []byte{0x01, 0x00},
+		TCBStatus:       tcbstatus.UpToDate,
+	}
+}
+
+// TestAttestationPolicyVerify tests that all the violations are given as reasons.
+func TestAttestationPolicyVerify(t *testing.T) {
+	signerID := sha256.Sum256([]byte("signer"))
+	conf := config.AttestationConfig{
+		SignerID:           "0x",
+		ProductID:          1,
+		MinSecurityVersion: 2,
+		AllowDebug:         false,
+		AllowedTCBStatuses: []string{"UpToDate", " SWHardeningNeeded"},
+	}
+	_, err := sgx.NewAttestationPolicy(conf)
+	require.Error(t, err)
+	conf.SignerID = hex.EncodeToString(signerID[:])
+
+	policy, err := sgx.NewAttestationPolicy(conf)
+	require.NoError(t, err)
+
+	report := newReport()
+	require.NoError(t, policy.Verify(report))
+	report.TCBStatus = tcbstatus.SWHardeningNeeded
+	require.NoError(t, policy.Verify(report))
+
+	report.SignerID = make([]byte, 32)
+	report.ProductID = []byte{0x02, 0x00}
+	report.SecurityVersion = 1
+	report.Debug = true
+	report.TCBStatus = tcbstatus.OutOfDate
+
+	err = policy.Verify(report)
+	var violation *sgx.PolicyViolationError
+	require.True(t, errors.As(err, &violation))
+	require.Len(t, violation.Reasons, 5)
+	require.ErrorContains(t, err, "signer ID")
+	require.ErrorContains(t, err, "product ID is 2, but expected 1")
+	require.ErrorContains(t, err, "security version 1 is lower than the minimum 2")
+	require.ErrorContains(t, err, "debug enclave is not allowed")
+	require.ErrorContains(t, err, "TCB status OutOfDate is not allowed")
+
+	conf.AllowedTCBStatuses = []string{"Invalid"}
+	_, err = sgx.NewAttestationPolicy(conf)
+	require.ErrorContains(t, err, "invalid TCB status")
+}
+
+// TestSimulationAttestationPolicy tests that the simulation verifies reports with the policy.
+func TestSimulationAttestationPolicy(t *testing.T) {
+	// debug enclaves are not allowed by default
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.DefaultAttestationPolicy())
+	require.NoError(t, err)
+
+	data := sha256.Sum256([]byte("public key"))
+	reportBz, err := simulation.GenerateRemoteReport(data[:])
+	require.NoError(t, err)
+	enclaveInfo, err := simulation.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	// simulated reports are always of debug enclaves
+	err = simulation.VerifyRemoteReport(reportBz, data[:], enclaveInfo.UniqueID)
+	require.ErrorContains(t, err, "debug enclave is not allowed")
+}
+
+// TestConfigAttestationPolicy tests that debug enclaves are allowed only in the SGX simulation.
+func TestConfigAttestationPolicy(t *testing.T) {
+	conf := config.DefaultConfig()
+
+	policy, err := sgx.NewConfigAttestationPolicy(conf)
+	require.NoError(t, err)
+	require.False(t, policy.AllowDebug)
+
+	conf.SgxSimulation = true
+	policy, err = sgx.NewConfigAttestationPolicy(conf)
+	require.NoError(t, err)
+	require.True(t, policy.AllowDebug)
+}
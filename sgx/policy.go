@@ -0,0 +1,15 @@
+package sgx
+
+// SealPolicy determines the key used for sealing data.
+type SealPolicy string
+
+const (
+	// SealPolicyUniqueKey seals data with a key bound to the unique ID (MRENCLAVE) of the enclave.
+	// Only the same enclave binary can unseal the data.
+	SealPolicyUniqueKey SealPolicy = "unique-key"
+
+	// SealPolicyProductKey seals data with a key bound to the signer ID (MRSIGNER) and the product ID of the enclave.
+	// Enclaves of the same signer and product with an equal or higher security version can unseal the data,
+	// so the data survives enclave upgrades.
+	SealPolicyProductKey SealPolicy = "product-key"
+)
@@ -0,0 +1,233 @@
+package sgx
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RA-TLS binds a TLS certificate to the enclave.
+// The TLS key is generated inside the enclave, and the certificate has an extension with a remote report
+// whose data is the hash of the certificate public key (SubjectPublicKeyInfo).
+
+// RATLSReportOID is the OID of the X.509 extension which has a remote report. It is the same as the one of Open Enclave.
+var RATLSReportOID = asn1.ObjectIdentifier{1, 2, 840, 113741, 1337, 6}
+
+// CreateRATLSCertificate generates a TLS key and a self-signed certificate with a remote report over the public key.
+func CreateRATLSCertificate(sgx Sgx, commonName string, validity time.Duration) (tls.Certificate, error) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate TLS key: %w", err)
+	}
+
+	pubKeyBz, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to marshal TLS public key: %w", err)
+	}
+
+	report, err := sgx.GenerateRemoteReport(RATLSReportData(pubKeyBz))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate remote report of TLS public key: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{
+			{Id: RATLSReportOID, Value: report},
+		},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create TLS certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{certDER},
+		PrivateKey:  privKey,
+	}, nil
+}
+
+// RATLSReportData returns the data of the remote report embedded in the certificate.
+func RATLSReportData(subjectPublicKeyInfo []byte) []byte {
+	hash := sha256.Sum256(subjectPublicKeyInfo)
+	return hash[:]
+}
+
+// ParseRATLSCertificate checks the self-signature of the certificate and its validity period,
+// and returns the remote report embedded in the certificate.
+// The caller must verify the report and check that its data is RATLSReportData of the certificate.
+func ParseRATLSCertificate(certDER []byte) (*x509.Certificate, []byte, error) {
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return nil, nil, fmt.Errorf("invalid self-signature of certificate: %w", err)
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, nil, fmt.Errorf("certificate is not valid at %s", now)
+	}
+
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(RATLSReportOID) {
+			return cert, ext.Value, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no remote report in certificate")
+}
+
+// VerifyRATLSCertificate verifies the certificate using the Sgx with the expected unique ID.
+func VerifyRATLSCertificate(sgx Sgx, certDER []byte, expectedUniqueID []byte) error {
+	cert, report, err := ParseRATLSCertificate(certDER)
+	if err != nil {
+		return err
+	}
+
+	return sgx.VerifyRemoteReport(report, RATLSReportData(cert.RawSubjectPublicKeyInfo), expectedUniqueID)
+}
+
+// RATLSCertificates serves an RA-TLS certificate, which is replaced with a new one with a fresh remote report
+// at the half of its validity period. So, a client never accepts a report older than the validity period.
+type RATLSCertificates struct {
+	sgx        Sgx
+	commonName string
+	validity   time.Duration
+
+	mtx      sync.RWMutex
+	current  *tls.Certificate
+	previous *tls.Certificate // still accepted by the pinned clients until the connections are re-established
+
+	closeCh chan struct{}
+	once    sync.Once
+}
+
+// NewRATLSCertificates creates the first certificate.
+func NewRATLSCertificates(sgx Sgx, commonName string, validity time.Duration) (*RATLSCertificates, error) {
+	c := &RATLSCertificates{
+		sgx:        sgx,
+		commonName: commonName,
+		validity:   validity,
+		closeCh:    make(chan struct{}),
+	}
+	if err := c.Rotate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Rotate replaces the current certificate with a new one.
+func (c *RATLSCertificates) Rotate() error {
+	cert, err := CreateRATLSCertificate(c.sgx, c.commonName, c.validity)
+	if err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.previous = c.current
+	c.current = &cert
+	return nil
+}
+
+// Current returns the current certificate.
+func (c *RATLSCertificates) Current() *tls.Certificate {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.current
+}
+
+// Run rotates the certificate at the half of its validity period until Close is called.
+// If a rotation fails, it is retried every minute, and an error is returned once the current certificate expires.
+func (c *RATLSCertificates) Run() error {
+	rotatedAt := time.Now()
+	timer := time.NewTimer(c.validity / 2)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.closeCh:
+			return nil
+		case <-timer.C:
+		}
+
+		if err := c.Rotate(); err != nil {
+			if time.Since(rotatedAt) >= c.validity {
+				return fmt.Errorf("failed to rotate RA-TLS certificate before it expires: %w", err)
+			}
+			log.Warnf("failed to rotate RA-TLS certificate. retry in a minute: %v", err)
+			timer.Reset(time.Minute)
+			continue
+		}
+
+		log.Info("RA-TLS certificate is rotated")
+		rotatedAt = time.Now()
+		timer.Reset(c.validity / 2)
+	}
+}
+
+func (c *RATLSCertificates) Close() error {
+	c.once.Do(func() { close(c.closeCh) })
+	return nil
+}
+
+// ServerTLSConfig returns a server TLS config which serves the current certificate.
+func (c *RATLSCertificates) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.Current(), nil
+		},
+	}
+}
+
+// PinnedTLSConfig returns a client TLS config which accepts only the current or the previous certificate.
+// It is used to connect to a server of the same enclave.
+func (c *RATLSCertificates) PinnedTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// the certificate is self-signed, so it is verified by VerifyPeerCertificate
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("unexpected server certificate")
+			}
+
+			c.mtx.RLock()
+			defer c.mtx.RUnlock()
+			for _, cert := range []*tls.Certificate{c.current, c.previous} {
+				if cert != nil && bytes.Equal(rawCerts[0], cert.Certificate[0]) {
+					return nil
+				}
+			}
+			return fmt.Errorf("unexpected server certificate")
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package sgx_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRATLSCertificate tests that an RA-TLS certificate is verified only with the unique ID of the enclave.
+func TestRATLSCertificate(t *testing.T) {
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+	enclaveInfo, err := simulation.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	cert, err := sgx.CreateRATLSCertificate(simulation, "panacea-oracle", time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, sgx.VerifyRATLSCertificate(simulation, cert.Certificate[0], enclaveInfo.UniqueID))
+	require.Error(t, sgx.VerifyRATLSCertificate(simulation, cert.Certificate[0], []byte("other unique ID")))
+
+	// the report of another certificate is not bound to the public key of this certificate
+	otherCert, err := sgx.CreateRATLSCertificate(simulation, "panacea-oracle", time.Hour)
+	require.NoError(t, err)
+	_, otherReport, err := sgx.ParseRATLSCertificate(otherCert.Certificate[0])
+	require.NoError(t, err)
+	parsed, _, err := sgx.ParseRATLSCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	require.Error(t, simulation.VerifyRemoteReport(otherReport, sgx.RATLSReportData(parsed.RawSubjectPublicKeyInfo), enclaveInfo.UniqueID))
+
+	// expired certificate
+	expiredCert, err := sgx.CreateRATLSCertificate(simulation, "panacea-oracle", -time.Hour)
+	require.NoError(t, err)
+	_, _, err = sgx.ParseRATLSCertificate(expiredCert.Certificate[0])
+	require.ErrorContains(t, err, "not valid")
+}
+
+// TestRATLSCertificatesRotate tests that the pinned clients accept the current and the previous certificates.
+func TestRATLSCertificatesRotate(t *testing.T) {
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	certs, err := sgx.NewRATLSCertificates(simulation, "panacea-oracle", time.Hour)
+	require.NoError(t, err)
+	first := certs.Current()
+
+	require.NoError(t, certs.Rotate())
+	second := certs.Current()
+	require.NotEqual(t, first.Certificate[0], second.Certificate[0])
+
+	serverCert, err := certs.ServerTLSConfig().GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, second, serverCert)
+
+	verify := certs.PinnedTLSConfig().VerifyPeerCertificate
+	require.NoError(t, verify(first.Certificate, nil))
+	require.NoError(t, verify(second.Certificate, nil))
+
+	require.NoError(t, certs.Rotate())
+	require.Error(t, verify(first.Certificate, nil))
+	require.NoError(t, verify(second.Certificate, nil))
+
+	// Run returns once it is closed
+	errCh := make(chan error)
+	go func() { errCh <- certs.Run() }()
+	require.NoError(t, certs.Close())
+	require.NoError(t, <-errCh)
+}
@@ -2,11 +2,14 @@ package sgx
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/edgelesssys/ego/attestation"
 	"github.com/edgelesssys/ego/ecrypto"
 	"github.com/edgelesssys/ego/enclave"
+	"github.com/medibloc/panacea-oracle/config"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -15,23 +18,66 @@ type Sgx interface {
 
 	GenerateSelfEnclaveInfo() (*EnclaveInfo, error)
 
+	// ParseRemoteReport verifies the signature of the report and returns its content without checking the attestation policy.
+	ParseRemoteReport(reportBytes []byte) (attestation.Report, error)
+
 	VerifyRemoteReport(reportBytes, expectedData []byte, expectedUniqueID []byte) error
 
-	SealToFile(data []byte, filePath string) error
+	SealToFile(data []byte, filePath string, policy SealPolicy) error
 
 	UnsealFromFile(filePath string) ([]byte, error)
 
-	Seal(data []byte) ([]byte, error)
+	Seal(data []byte, policy SealPolicy) ([]byte, error)
 
 	Unseal(data []byte) ([]byte, error)
 }
 
 var _ Sgx = oracleSgx{}
 
-type oracleSgx struct{}
+type oracleSgx struct {
+	policy AttestationPolicy
+}
 
+// NewOracleSGX returns the SGX backend using the hardware, which verifies reports with the default attestation policy.
 func NewOracleSGX() Sgx {
-	return &oracleSgx{}
+	return NewOracleSGXWithPolicy(DefaultAttestationPolicy())
+}
+
+func NewOracleSGXWithPolicy(policy AttestationPolicy) Sgx {
+	return &oracleSgx{policy: policy}
+}
+
+// New returns the SGX simulation if it is enabled by the config or the sgx_simulation build tag.
+// Otherwise, it returns the SGX backend using the hardware.
+// Both verify reports with the attestation policy of the config.
+func New(conf *config.Config) (Sgx, error) {
+	policy, err := NewConfigAttestationPolicy(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attestation policy: %w", err)
+	}
+
+	if isSimulation(conf) {
+		return NewSimulationSGX(conf.Panacea.ChainID, conf.AbsSgxSimulationKeyPath(), policy)
+	}
+	return NewOracleSGXWithPolicy(policy), nil
+}
+
+// NewConfigAttestationPolicy returns the attestation policy in the config.
+// In the SGX simulation, debug enclaves are allowed, since all reports of the simulation are of debug enclaves.
+func NewConfigAttestationPolicy(conf *config.Config) (AttestationPolicy, error) {
+	policy, err := NewAttestationPolicy(conf.Attestation)
+	if err != nil {
+		return AttestationPolicy{}, err
+	}
+
+	if isSimulation(conf) {
+		policy.AllowDebug = true
+	}
+	return policy, nil
+}
+
+func isSimulation(conf *config.Config) bool {
+	return conf.SgxSimulation || simulationBuild
 }
 
 func (s oracleSgx) GenerateRemoteReport(data []byte) ([]byte, error) {
@@ -54,27 +100,27 @@ func (s oracleSgx) GenerateSelfEnclaveInfo() (*EnclaveInfo, error) {
 	return NewEnclaveInfo(report.ProductID, report.UniqueID), nil
 }
 
-func (s oracleSgx) VerifyRemoteReport(reportBytes, expectedData []byte, expectedUniqueID []byte) error {
+func (s oracleSgx) ParseRemoteReport(reportBytes []byte) (attestation.Report, error) {
 	report, err := enclave.VerifyRemoteReport(reportBytes)
-	if err != nil {
-		return err
+	// the TCB status of the report is checked by the attestation policy
+	if err != nil && !errors.Is(err, attestation.ErrTCBLevelInvalid) {
+		return attestation.Report{}, err
 	}
 
-	if report.SecurityVersion < PromisedMinSecurityVersion {
-		return fmt.Errorf("invalid security version in the report")
-	}
-	if !bytes.Equal(report.UniqueID, expectedUniqueID) {
-		return fmt.Errorf("invalid unique ID in the report")
-	}
-	if !bytes.Equal(report.Data[:len(expectedData)], expectedData) {
-		return fmt.Errorf("invalid data in the report")
+	return report, nil
+}
+
+func (s oracleSgx) VerifyRemoteReport(reportBytes, expectedData []byte, expectedUniqueID []byte) error {
+	report, err := s.ParseRemoteReport(reportBytes)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return verifyReport(s.policy, report, expectedData, expectedUniqueID)
 }
 
-func (s oracleSgx) SealToFile(data []byte, filePath string) error {
-	sealedData, err := s.Seal(data)
+func (s oracleSgx) SealToFile(data []byte, filePath string, policy SealPolicy) error {
+	sealedData, err := s.Seal(data, policy)
 	if err != nil {
 		return fmt.Errorf("failed to seal oracle private key: %w", err)
 	}
@@ -101,10 +147,32 @@ func (s oracleSgx) UnsealFromFile(filePath string) ([]byte, error) {
 	return key, nil
 }
 
-func (s oracleSgx) Seal(data []byte) ([]byte, error) {
-	return ecrypto.SealWithUniqueKey(data, nil)
+func (s oracleSgx) Seal(data []byte, policy SealPolicy) ([]byte, error) {
+	switch policy {
+	case SealPolicyUniqueKey:
+		return ecrypto.SealWithUniqueKey(data, nil)
+	case SealPolicyProductKey:
+		return ecrypto.SealWithProductKey(data, nil)
+	default:
+		return nil, fmt.Errorf("invalid seal policy: %s", policy)
+	}
 }
 
 func (s oracleSgx) Unseal(data []byte) ([]byte, error) {
 	return ecrypto.Unseal(data, nil)
 }
+
+// verifyReport checks the report against the attestation policy, and then the expected unique ID and data.
+func verifyReport(policy AttestationPolicy, report attestation.Report, expectedData, expectedUniqueID []byte) error {
+	if err := policy.Verify(report); err != nil {
+		return err
+	}
+	if !bytes.Equal(report.UniqueID, expectedUniqueID) {
+		return fmt.Errorf("invalid unique ID in the report. expected(%X) got(%X)", expectedUniqueID, report.UniqueID)
+	}
+	if len(report.Data) < len(expectedData) || !bytes.Equal(report.Data[:len(expectedData)], expectedData) {
+		return fmt.Errorf("invalid data in the report")
+	}
+
+	return nil
+}
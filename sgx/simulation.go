@@ -0,0 +1,352 @@
+package sgx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/edgelesssys/ego/attestation"
+	"github.com/edgelesssys/ego/attestation/tcbstatus"
+	log "github.com/sirupsen/logrus"
+)
+
+// The SGX simulation is a software backend for development and CI on machines without SGX hardware.
+// It provides NO security guarantee:
+//   - data is sealed with a key stored in a plain file
+//   - reports are self-signed, so anyone can forge them
+//
+// Its reports are only accepted by the SGX simulation, never by the hardware backend.
+
+const (
+	simulationReportType = "panacea-oracle/sgx-simulation-report"
+	simulationSealAAD    = "panacea-oracle/sgx-simulation-seal"
+
+	simulationUniqueKeyPolicy  byte = 1
+	simulationProductKeyPolicy byte = 2
+)
+
+var (
+	simulationProductID = []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	simulationSignerID  = sha256.Sum256([]byte("panacea-oracle/sgx-simulation-signer"))
+
+	// mainnetChainIDPattern matches chain IDs of the Panacea mainnet (panacea-1, panacea-2, ...)
+	mainnetChainIDPattern = regexp.MustCompile(`^panacea-[0-9]+$`)
+)
+
+// SimulationReport is a self-signed report generated by the SGX simulation.
+type SimulationReport struct {
+	Type            string `json:"type"`
+	Data            []byte `json:"data"`
+	SecurityVersion uint   `json:"security_version"`
+	Debug           bool   `json:"debug"`
+	UniqueID        []byte `json:"unique_id"`
+	SignerID        []byte `json:"signer_id"`
+	ProductID       []byte `json:"product_id"`
+	PubKey          []byte `json:"pub_key"`
+	Signature       []byte `json:"signature,omitempty"`
+}
+
+// AttestationReport converts the simulation report to the report of the hardware, whose TCB status is always up-to-date.
+func (r SimulationReport) AttestationReport() attestation.Report {
+	return attestation.Report{
+		Data:            r.Data,
+		SecurityVersion: r.SecurityVersion,
+		Debug:           r.Debug,
+		UniqueID:        r.UniqueID,
+		SignerID:        r.SignerID,
+		ProductID:       r.ProductID,
+		TCBStatus:       tcbstatus.UpToDate,
+	}
+}
+
+func (r SimulationReport) signBytes() ([]byte, error) {
+	r.Signature = nil
+	return json.Marshal(r)
+}
+
+var _ Sgx = &simulationSgx{}
+
+type simulationSgx struct {
+	keyPath string
+	policy  AttestationPolicy
+
+	keyOnce sync.Once
+	key     []byte
+	keyErr  error
+}
+
+// NewSimulationSGX returns the SGX simulation which seals data with the key in keyPath and verifies reports with the policy.
+// The key is generated if it doesn't exist. It returns an error for a mainnet chain ID.
+func NewSimulationSGX(chainID, keyPath string, policy AttestationPolicy) (Sgx, error) {
+	if mainnetChainIDPattern.MatchString(chainID) {
+		return nil, fmt.Errorf("SGX simulation cannot be used with the mainnet chain ID: %s", chainID)
+	}
+
+	log.Warn("SGX SIMULATION MODE: sealing and remote reports are simulated. Never use it in production")
+
+	return &simulationSgx{
+		keyPath: keyPath,
+		policy:  policy,
+	}, nil
+}
+
+// loadKey reads the simulation key from the file, or generates it if the file doesn't exist.
+func (s *simulationSgx) loadKey() ([]byte, error) {
+	s.keyOnce.Do(func() {
+		key, err := os.ReadFile(s.keyPath)
+		if err == nil {
+			if len(key) != 32 {
+				s.keyErr = fmt.Errorf("invalid SGX simulation key in %s", s.keyPath)
+				return
+			}
+			s.key = key
+			return
+		}
+		if !os.IsNotExist(err) {
+			s.keyErr = fmt.Errorf("failed to read %s: %w", s.keyPath, err)
+			return
+		}
+
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			s.keyErr = err
+			return
+		}
+		if err := os.WriteFile(s.keyPath, key, 0600); err != nil {
+			s.keyErr = fmt.Errorf("failed to write %s: %w", s.keyPath, err)
+			return
+		}
+		log.Infof("SGX simulation key is generated in %s", s.keyPath)
+		s.key = key
+	})
+
+	return s.key, s.keyErr
+}
+
+func (s *simulationSgx) signingKey() (ed25519.PrivateKey, error) {
+	key, err := s.loadKey()
+	if err != nil {
+		return nil, err
+	}
+	seed := sha256.Sum256(append([]byte("report-signing-key"), key...))
+	return ed25519.NewKeyFromSeed(seed[:]), nil
+}
+
+func (s *simulationSgx) GenerateRemoteReport(data []byte) ([]byte, error) {
+	signingKey, err := s.signingKey()
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueID, err := simulationUniqueID()
+	if err != nil {
+		return nil, err
+	}
+
+	report := SimulationReport{
+		Type:            simulationReportType,
+		Data:            data,
+		SecurityVersion: PromisedMinSecurityVersion,
+		Debug:           true,
+		UniqueID:        uniqueID,
+		SignerID:        simulationSignerID[:],
+		ProductID:       simulationProductID,
+		PubKey:          signingKey.Public().(ed25519.PublicKey),
+	}
+
+	signBytes, err := report.signBytes()
+	if err != nil {
+		return nil, err
+	}
+	report.Signature = ed25519.Sign(signingKey, signBytes)
+
+	return json.Marshal(report)
+}
+
+// GenerateSelfEnclaveInfo returns EnclaveInfo whose unique ID is the hash of the running executable.
+func (s *simulationSgx) GenerateSelfEnclaveInfo() (*EnclaveInfo, error) {
+	uniqueID, err := simulationUniqueID()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEnclaveInfo(simulationProductID, uniqueID), nil
+}
+
+func (s *simulationSgx) ParseRemoteReport(reportBytes []byte) (attestation.Report, error) {
+	report, err := ParseSimulationReport(reportBytes)
+	if err != nil {
+		return attestation.Report{}, err
+	}
+
+	return report.AttestationReport(), nil
+}
+
+func (s *simulationSgx) VerifyRemoteReport(reportBytes, expectedData []byte, expectedUniqueID []byte) error {
+	report, err := s.ParseRemoteReport(reportBytes)
+	if err != nil {
+		return err
+	}
+
+	return verifyReport(s.policy, report, expectedData, expectedUniqueID)
+}
+
+func (s *simulationSgx) SealToFile(data []byte, filePath string, policy SealPolicy) error {
+	sealedData, err := s.Seal(data, policy)
+	if err != nil {
+		return fmt.Errorf("failed to seal oracle private key: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, sealedData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	log.Infof("%s is sealed (simulated) and written successfully", filePath)
+
+	return nil
+}
+
+func (s *simulationSgx) UnsealFromFile(filePath string) ([]byte, error) {
+	sealed, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	key, err := s.Unseal(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal oracle key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Seal seals data with a key derived from the simulation key and the identity selected by the policy,
+// like SGX does. The sealed data starts with a byte of the policy.
+func (s *simulationSgx) Seal(data []byte, policy SealPolicy) ([]byte, error) {
+	policyByte, err := simulationPolicyByte(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	aesGCM, err := s.newSealCipher(policyByte)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append([]byte{policyByte}, nonce...)
+	return aesGCM.Seal(sealed, nonce, data, []byte(simulationSealAAD)), nil
+}
+
+func (s *simulationSgx) Unseal(data []byte) ([]byte, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("sealed data is too short")
+	}
+
+	aesGCM, err := s.newSealCipher(data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 1+aesGCM.NonceSize() {
+		return nil, fmt.Errorf("sealed data is too short")
+	}
+	nonce, sealed := data[1:1+aesGCM.NonceSize()], data[1+aesGCM.NonceSize():]
+
+	return aesGCM.Open(nil, nonce, sealed, []byte(simulationSealAAD))
+}
+
+func (s *simulationSgx) newSealCipher(policyByte byte) (cipher.AEAD, error) {
+	key, err := s.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var identity []byte
+	switch policyByte {
+	case simulationUniqueKeyPolicy:
+		identity, err = simulationUniqueID()
+		if err != nil {
+			return nil, err
+		}
+	case simulationProductKeyPolicy:
+		identity = append(simulationSignerID[:], simulationProductID...)
+	default:
+		return nil, fmt.Errorf("invalid seal policy in the sealed data")
+	}
+
+	sealKey := sha256.Sum256(append(append([]byte{}, key...), identity...))
+	block, err := aes.NewCipher(sealKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func simulationPolicyByte(policy SealPolicy) (byte, error) {
+	switch policy {
+	case SealPolicyUniqueKey:
+		return simulationUniqueKeyPolicy, nil
+	case SealPolicyProductKey:
+		return simulationProductKeyPolicy, nil
+	default:
+		return 0, fmt.Errorf("invalid seal policy: %s", policy)
+	}
+}
+
+// ParseSimulationReport parses the report generated by the SGX simulation and verifies its self-signature.
+func ParseSimulationReport(reportBytes []byte) (*SimulationReport, error) {
+	var report SimulationReport
+	if err := json.Unmarshal(reportBytes, &report); err != nil {
+		return nil, fmt.Errorf("not a report of the SGX simulation: %w", err)
+	}
+	if report.Type != simulationReportType {
+		return nil, fmt.Errorf("not a report of the SGX simulation")
+	}
+	if len(report.PubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key in the simulation report")
+	}
+
+	signBytes, err := report.signBytes()
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(report.PubKey, signBytes, report.Signature) {
+		return nil, fmt.Errorf("invalid signature of the simulation report")
+	}
+
+	return &report, nil
+}
+
+// simulationUniqueID returns the hash of the running executable, like MRENCLAVE of an enclave.
+func simulationUniqueID() ([]byte, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the executable path: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the executable: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("failed to hash the executable: %w", err)
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,6 @@
+//go:build sgx_simulation
+
+package sgx
+
+// simulationBuild forces the SGX simulation regardless of the config.
+const simulationBuild = true
@@ -0,0 +1,79 @@
+package sgx_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSimulationSealUnseal tests that data sealed by the simulation is unsealed only with the same key file.
+func TestSimulationSealUnseal(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "sgx_simulation.key")
+
+	simulation, err := sgx.NewSimulationSGX("testing", keyPath, sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	data := []byte("sealed data")
+	sealed, err := simulation.Seal(data, sgx.SealPolicyUniqueKey)
+	require.NoError(t, err)
+
+	// the key is loaded from the file again
+	simulation, err = sgx.NewSimulationSGX("testing", keyPath, sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+	unsealed, err := simulation.Unseal(sealed)
+	require.NoError(t, err)
+	require.Equal(t, data, unsealed)
+
+	other, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+	_, err = other.Unseal(sealed)
+	require.Error(t, err)
+}
+
+// TestSimulationRemoteReport tests that a simulated report is verified by another simulation.
+func TestSimulationRemoteReport(t *testing.T) {
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	data := sha256.Sum256([]byte("public key"))
+	reportBz, err := simulation.GenerateRemoteReport(data[:])
+	require.NoError(t, err)
+
+	enclaveInfo, err := simulation.GenerateSelfEnclaveInfo()
+	require.NoError(t, err)
+
+	verifier, err := sgx.NewSimulationSGX("testing", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+	require.NoError(t, verifier.VerifyRemoteReport(reportBz, data[:], enclaveInfo.UniqueID))
+
+	parsed, err := verifier.ParseRemoteReport(reportBz)
+	require.NoError(t, err)
+	require.Equal(t, data[:], parsed.Data)
+	require.Equal(t, enclaveInfo.UniqueID, parsed.UniqueID)
+	require.Equal(t, uint16(1), sgx.ReportProductID(parsed))
+
+	otherData := sha256.Sum256([]byte("other public key"))
+	require.ErrorContains(t, verifier.VerifyRemoteReport(reportBz, otherData[:], enclaveInfo.UniqueID), "invalid data")
+	require.ErrorContains(t, verifier.VerifyRemoteReport(reportBz, data[:], []byte("other unique ID")), "invalid unique ID")
+
+	// the report cannot be modified without the signature being invalid
+	report, err := sgx.ParseSimulationReport(reportBz)
+	require.NoError(t, err)
+	report.Data = otherData[:]
+	tamperedBz, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.ErrorContains(t, verifier.VerifyRemoteReport(tamperedBz, otherData[:], enclaveInfo.UniqueID), "invalid signature")
+
+	// the hardware backend doesn't accept a simulated report
+	require.Error(t, sgx.NewOracleSGX().VerifyRemoteReport(reportBz, data[:], enclaveInfo.UniqueID))
+}
+
+// TestSimulationMainnet tests that the simulation is refused for the mainnet.
+func TestSimulationMainnet(t *testing.T) {
+	_, err := sgx.NewSimulationSGX("panacea-3", filepath.Join(t.TempDir(), "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.ErrorContains(t, err, "mainnet")
+}
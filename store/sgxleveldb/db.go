@@ -13,32 +13,42 @@ import (
 )
 
 type SgxLevelDB struct {
-	sgx sgx.Sgx
+	sgx    sgx.Sgx
+	policy sgx.SealPolicy
 	*tmdb.GoLevelDB
 }
 
-func NewSgxLevelDB(name string, dir string, sgx sgx.Sgx) (*SgxLevelDB, error) {
-	return NewSgxLevelDBWithOpts(name, dir, sgx, nil)
+func NewSgxLevelDB(name string, dir string, sgx sgx.Sgx, policy sgx.SealPolicy) (*SgxLevelDB, error) {
+	return NewSgxLevelDBWithOpts(name, dir, sgx, policy, nil)
 }
 
-func NewSgxLevelDBWithOpts(name string, dir string, sgx sgx.Sgx, o *opt.Options) (*SgxLevelDB, error) {
+func NewSgxLevelDBWithOpts(name string, dir string, sgx sgx.Sgx, policy sgx.SealPolicy, o *opt.Options) (*SgxLevelDB, error) {
 	goLevelDB, err := tmdb.NewGoLevelDBWithOpts(name, dir, o)
 	if err != nil {
 		return nil, fmt.Errorf("failed to NewGoLevelDBWithOpts: %w", err)
 	}
 
-	return &SgxLevelDB{sgx, goLevelDB}, nil
+	return &SgxLevelDB{sgx, policy, goLevelDB}, nil
 }
 
 func (sdb *SgxLevelDB) Set(key, value []byte) error {
 	log.Debug("sealing before writing to leveldb")
-	sealValue, err := sdb.sgx.Seal(value)
+	sealValue, err := sdb.sgx.Seal(value, sdb.policy)
 	if err != nil {
 		return err
 	}
 	return sdb.GoLevelDB.Set(key, sealValue)
 }
 
+func (sdb *SgxLevelDB) SetSync(key, value []byte) error {
+	log.Debug("sealing before writing to leveldb synchronously")
+	sealValue, err := sdb.sgx.Seal(value, sdb.policy)
+	if err != nil {
+		return err
+	}
+	return sdb.GoLevelDB.SetSync(key, sealValue)
+}
+
 func (sdb *SgxLevelDB) Get(key []byte) ([]byte, error) {
 	val, err := sdb.GoLevelDB.Get(key)
 	if err != nil {
@@ -56,7 +66,64 @@ func (sdb *SgxLevelDB) Get(key []byte) ([]byte, error) {
 	return unsealedVal, nil
 }
 
+func (sdb *SgxLevelDB) Iterator(start, end []byte) (tmdb.Iterator, error) {
+	itr, err := sdb.GoLevelDB.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &sgxLevelDBIterator{sgx: sdb.sgx, Iterator: itr}, nil
+}
+
+func (sdb *SgxLevelDB) ReverseIterator(start, end []byte) (tmdb.Iterator, error) {
+	itr, err := sdb.GoLevelDB.ReverseIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &sgxLevelDBIterator{sgx: sdb.sgx, Iterator: itr}, nil
+}
+
 func (sdb *SgxLevelDB) NewBatch() tmdb.Batch {
 	batch := sdb.GoLevelDB.NewBatch()
-	return &sgxLevelDBBatch{sdb.sgx, batch}
+	return &sgxLevelDBBatch{sdb.sgx, sdb.policy, batch}
+}
+
+// Reseal unseals all values in the DB and seals them again with the current enclave and the seal policy.
+// It is used to migrate the DB sealed by a previous enclave, so the previous enclave should have sealed it with a product key.
+// It returns the number of resealed values.
+func (sdb *SgxLevelDB) Reseal() (int, error) {
+	itr, err := sdb.GoLevelDB.Iterator(nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create an iterator: %w", err)
+	}
+	defer itr.Close()
+
+	batch := sdb.GoLevelDB.NewBatch()
+	defer batch.Close()
+
+	count := 0
+	for ; itr.Valid(); itr.Next() {
+		unsealedVal, err := sdb.sgx.Unseal(itr.Value())
+		if err != nil {
+			return 0, fmt.Errorf("failed to unseal value of key %X: %w", itr.Key(), err)
+		}
+
+		sealedVal, err := sdb.sgx.Seal(unsealedVal, sdb.policy)
+		if err != nil {
+			return 0, fmt.Errorf("failed to seal value of key %X: %w", itr.Key(), err)
+		}
+
+		if err := batch.Set(itr.Key(), sealedVal); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if err := itr.Error(); err != nil {
+		return 0, err
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return 0, fmt.Errorf("failed to write resealed values: %w", err)
+	}
+
+	return count, nil
 }
@@ -7,13 +7,14 @@ import (
 )
 
 type sgxLevelDBBatch struct {
-	sgx sgx.Sgx
+	sgx    sgx.Sgx
+	policy sgx.SealPolicy
 	tmdb.Batch
 }
 
 func (sbatch *sgxLevelDBBatch) Set(key, value []byte) error {
 	log.Debug("sealing before writing to leveldb in batch")
-	sealValue, err := sbatch.sgx.Seal(value)
+	sealValue, err := sbatch.sgx.Seal(value, sbatch.policy)
 	if err != nil {
 		return err
 	}
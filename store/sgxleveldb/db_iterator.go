@@ -0,0 +1,34 @@
+package sgxleveldb
+
+import (
+	"fmt"
+
+	"github.com/medibloc/panacea-oracle/sgx"
+	log "github.com/sirupsen/logrus"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+// sgxLevelDBIterator unseals values while iterating.
+// If a value cannot be unsealed, Value returns nil and Error returns the error.
+type sgxLevelDBIterator struct {
+	sgx sgx.Sgx
+	err error
+	tmdb.Iterator
+}
+
+func (sitr *sgxLevelDBIterator) Value() []byte {
+	log.Debug("unsealing after reading from leveldb in iterator")
+	unsealedVal, err := sitr.sgx.Unseal(sitr.Iterator.Value())
+	if err != nil {
+		sitr.err = fmt.Errorf("failed to unseal value of key %X: %w", sitr.Key(), err)
+		return nil
+	}
+	return unsealedVal
+}
+
+func (sitr *sgxLevelDBIterator) Error() error {
+	if sitr.err != nil {
+		return sitr.err
+	}
+	return sitr.Iterator.Error()
+}
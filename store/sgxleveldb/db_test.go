@@ -0,0 +1,107 @@
+package sgxleveldb_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/medibloc/panacea-oracle/sgx"
+	"github.com/medibloc/panacea-oracle/store/sgxleveldb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReseal tests that all values are resealed with the seal policy of the DB.
+func TestReseal(t *testing.T) {
+	dir := t.TempDir()
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(dir, "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	db, err := sgxleveldb.NewSgxLevelDB("test", dir, simulation, sgx.SealPolicyUniqueKey)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.Set([]byte("key2"), []byte("value2")))
+	sealedValue, err := db.GoLevelDB.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = sgxleveldb.NewSgxLevelDB("test", dir, simulation, sgx.SealPolicyProductKey)
+	require.NoError(t, err)
+	defer db.Close()
+
+	count, err := db.Reseal()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	resealedValue, err := db.GoLevelDB.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.NotEqual(t, sealedValue, resealedValue)
+
+	value, err := db.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+	value, err = db.Get([]byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+
+	// a value which cannot be unsealed
+	require.NoError(t, db.GoLevelDB.Set([]byte("key3"), []byte("invalid sealed value")))
+	_, err = db.Reseal()
+	require.Error(t, err)
+}
+
+// TestSetSync tests that values written synchronously are sealed too.
+func TestSetSync(t *testing.T) {
+	dir := t.TempDir()
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(dir, "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	db, err := sgxleveldb.NewSgxLevelDB("test", dir, simulation, sgx.SealPolicyUniqueKey)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SetSync([]byte("key"), []byte("value")))
+
+	sealedValue, err := db.GoLevelDB.Get([]byte("key"))
+	require.NoError(t, err)
+	require.NotEqual(t, []byte("value"), sealedValue)
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
+
+// TestIterator tests that values are unsealed while iterating.
+func TestIterator(t *testing.T) {
+	dir := t.TempDir()
+	simulation, err := sgx.NewSimulationSGX("testing", filepath.Join(dir, "sgx_simulation.key"), sgx.SimulationAttestationPolicy())
+	require.NoError(t, err)
+
+	db, err := sgxleveldb.NewSgxLevelDB("test", dir, simulation, sgx.SealPolicyUniqueKey)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Set([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.Set([]byte("key2"), []byte("value2")))
+
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	var values []string
+	for ; itr.Valid(); itr.Next() {
+		values = append(values, string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+	require.NoError(t, itr.Close())
+	require.Equal(t, []string{"value1", "value2"}, values)
+
+	itr, err = db.ReverseIterator(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), itr.Value())
+	require.NoError(t, itr.Close())
+
+	// a value which cannot be unsealed
+	require.NoError(t, db.GoLevelDB.Set([]byte("key3"), []byte("invalid sealed value")))
+	itr, err = db.ReverseIterator(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, itr.Value())
+	require.Error(t, itr.Error())
+	require.NoError(t, itr.Close())
+}
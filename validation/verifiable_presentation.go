@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 
@@ -14,13 +16,21 @@ type didResolver interface {
 	Resolve(did string, opts ...vdr.DIDMethodOption) (*did.DocResolution, error)
 }
 
-// ValidateVP validates verifiable presentation
-func ValidateVP(vdr didResolver, vpBytes, pdBytes []byte) error {
+// ValidateVP validates verifiable presentation against the compiled presentation definition, if any.
+func ValidateVP(vdr didResolver, vpBytes []byte, pd *presexch.PresentationDefinition) error {
 	f, err := vc.NewFramework(vdr)
 	if err != nil {
 		return fmt.Errorf("failed to create a framework for VP verification: %w", err)
 	}
 
+	var pdBytes []byte
+	if pd != nil {
+		pdBytes, err = json.Marshal(pd)
+		if err != nil {
+			return fmt.Errorf("failed to marshal presentation definition: %w", err)
+		}
+	}
+
 	if _, err := f.VerifyPresentation(vpBytes, vc.WithPresentationDefinition(pdBytes)); err != nil {
 		return fmt.Errorf("invalid VP: %w", err)
 	}